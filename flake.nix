@@ -25,7 +25,7 @@
           ];
         };
 
-        service_names = ["kardinal-manager" "kardinal-cli" "redis-proxy-overlay"];
+        service_names = ["kardinal-manager" "kardinal-cli" "redis-proxy-overlay" "flow-header-propagator"];
         architectures = ["amd64" "arm64"];
         imageRegistry = "kurtosistech";
 
@@ -106,6 +106,10 @@
             inherit pkgs;
           };
 
+          packages.flow-header-propagator = pkgs.callPackage ./sidecars/flow-header-propagator/default.nix {
+            inherit pkgs;
+          };
+
           packages.cli-kontrol-api = pkgs.callPackage ./libs/cli-kontrol-api/default.nix {
             inherit pkgs;
           };
@@ -9,20 +9,19 @@ import (
 	"net/http"
 	"os"
 	"path"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/kurtosis-tech/stacktrace"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"kardinal.cli/deployment"
 	"kardinal.cli/kontrol"
+	"kardinal.cli/manifest"
 	"kardinal.cli/tenant"
 
 	api "github.com/kurtosis-tech/kardinal/libs/cli-kontrol-api/api/golang/client"
 	api_types "github.com/kurtosis-tech/kardinal/libs/cli-kontrol-api/api/golang/types"
-	appv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes/scheme"
 )
 
 const (
@@ -40,9 +39,45 @@ const (
 
 	httpSchme   = "http"
 	httpsScheme = httpSchme + "s"
+
+	defaultManagerReplicas = 1
+
+	defaultWaitForClusterSync = true
+	defaultClusterSyncTimeout = time.Minute * 5
+	clusterStatusPollInterval = time.Second * 2
+	// Kontrol returns the version of the cluster resources with the change, so the CLI waits for its cluster status
+	clusterResourcesVersionHeaderKey = "Kardinal-Cluster-Resources-Version"
+
+	minTrafficPercentage = 0
+	maxTrafficPercentage = 100
+
+	minChaosPercentage = 0
+	maxChaosPercentage = 100
+
+	// The abort HTTP status range accepted by the Kontrol API
+	minAbortHTTPStatus = http.StatusBadRequest
+	maxAbortHTTPStatus = 599
 )
 
-var kubernetesManifestFile string
+var kubernetesManifests []string
+
+var helmChart string
+var helmValuesFiles []string
+var helmReleaseName string
+
+var managerReplicas int
+var managerExtraResources []string
+
+var waitForClusterSync bool
+var clusterSyncTimeout time.Duration
+
+var trafficPercentage int
+var mirrorTraffic bool
+
+var chaosDelay time.Duration
+var chaosAbortHTTPStatus int
+var chaosPercentage float32
+var clearChaos bool
 
 var rootCmd = &cobra.Command{
 	Use:   "kardinal",
@@ -64,7 +99,7 @@ var deployCmd = &cobra.Command{
 	Short: "Deploy services",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		serviceConfigs, err := parseKubernetesManifestFile(kubernetesManifestFile)
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
 		if err != nil {
 			log.Fatalf("Error loading k8s manifest file: %v", err)
 		}
@@ -83,7 +118,106 @@ var createCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		serviceName, imageName := args[0], args[1]
-		serviceConfigs, err := parseKubernetesManifestFile(kubernetesManifestFile)
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
+		if err != nil {
+			log.Fatalf("Error loading k8s manifest file: %v", err)
+		}
+
+		tenantUuid, err := tenant.GetOrCreateUserTenantUUID()
+		if err != nil {
+			log.Fatal("Error getting or creating user tenant UUID", err)
+		}
+
+		var flowTrafficPercentage *int
+		if cmd.Flags().Changed("traffic-percentage") {
+			if err := validateTrafficPercentage(trafficPercentage); err != nil {
+				log.Fatal("Invalid traffic percentage", err)
+			}
+			flowTrafficPercentage = &trafficPercentage
+		}
+
+		// Without --mirror the flow is a canary only when the traffic percentage is set
+		var flowMode api_types.DevFlowSpecMode
+		switch {
+		case mirrorTraffic:
+			flowMode = api_types.Mirror
+			mirroredPercentage := maxTrafficPercentage
+			if flowTrafficPercentage != nil {
+				mirroredPercentage = *flowTrafficPercentage
+			}
+			fmt.Printf("Creating service %s with image %s receiving a copy of %d%% of the prod traffic...\n", serviceName, imageName, mirroredPercentage)
+		case flowTrafficPercentage != nil:
+			flowMode = api_types.Canary
+			fmt.Printf("Creating service %s with image %s as a canary receiving %d%% of the prod traffic...\n", serviceName, imageName, trafficPercentage)
+		default:
+			flowMode = api_types.Dev
+			fmt.Printf("Creating service %s with image %s in development mode...\n", serviceName, imageName)
+		}
+		createDevFlow(tenantUuid.String(), serviceConfigs, imageName, serviceName, flowMode, flowTrafficPercentage)
+	},
+}
+
+var canaryCmd = &cobra.Command{
+	Use:   "canary",
+	Short: "Manage canary flows, which send a percentage of the prod traffic to the dev image",
+}
+
+var canaryWeightCmd = &cobra.Command{
+	Use:   "weight [service name] [traffic percentage]",
+	Short: "Change the percentage of the prod traffic sent to the dev image of a canary flow",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceName := args[0]
+		percentage, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("The traffic percentage must be a number, got '%s'", args[1])
+		}
+		if err := validateTrafficPercentage(percentage); err != nil {
+			log.Fatal("Invalid traffic percentage", err)
+		}
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
+		if err != nil {
+			log.Fatalf("Error loading k8s manifest file: %v", err)
+		}
+
+		tenantUuid, err := tenant.GetOrCreateUserTenantUUID()
+		if err != nil {
+			log.Fatal("Error getting or creating user tenant UUID", err)
+		}
+
+		fmt.Printf("Sending %d%% of the prod traffic to the canary of service %s...\n", percentage, serviceName)
+		setCanaryWeight(tenantUuid.String(), serviceConfigs, serviceName, percentage)
+	},
+}
+
+var canaryPromoteCmd = &cobra.Command{
+	Use:   "promote [service name]",
+	Short: "Promote the dev image of a canary flow to prod",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceName := args[0]
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
+		if err != nil {
+			log.Fatalf("Error loading k8s manifest file: %v", err)
+		}
+
+		tenantUuid, err := tenant.GetOrCreateUserTenantUUID()
+		if err != nil {
+			log.Fatal("Error getting or creating user tenant UUID", err)
+		}
+
+		fmt.Printf("Promoting the canary of service %s to prod...\n", serviceName)
+		promoteCanary(tenantUuid.String(), serviceConfigs, serviceName)
+	},
+}
+
+var canaryAbortCmd = &cobra.Command{
+	Use:   "abort [service name]",
+	Short: "Abort a canary flow, sending all the prod traffic back to the prod image",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceName := args[0]
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
 		if err != nil {
 			log.Fatalf("Error loading k8s manifest file: %v", err)
 		}
@@ -93,8 +227,47 @@ var createCmd = &cobra.Command{
 			log.Fatal("Error getting or creating user tenant UUID", err)
 		}
 
-		fmt.Printf("Creating service %s with image %s in development mode...\n", serviceName, imageName)
-		createDevFlow(tenantUuid.String(), serviceConfigs, imageName, serviceName)
+		fmt.Printf("Aborting the canary of service %s...\n", serviceName)
+		abortCanary(tenantUuid.String(), serviceConfigs, serviceName)
+	},
+}
+
+var chaosCmd = &cobra.Command{
+	Use:   "chaos [flow id]",
+	Short: "Inject delays and aborts into the requests of a flow, without disturbing the other flows",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		flowID := args[0]
+
+		body := api_types.PostTenantUuidFlowChaosJSONRequestBody{
+			FlowId: flowID,
+		}
+		if clearChaos {
+			if cmd.Flags().Changed("delay") || cmd.Flags().Changed("abort") {
+				log.Fatal("The --clear flag can't be used with --delay or --abort")
+			}
+			fmt.Printf("Removing the faults of flow %s...\n", flowID)
+		} else {
+			if err := validateChaos(chaosDelay, chaosAbortHTTPStatus, chaosPercentage); err != nil {
+				log.Fatal("Invalid chaos", err)
+			}
+			if chaosDelay > 0 {
+				delay := chaosDelay.String()
+				body.Delay = &delay
+			}
+			if chaosAbortHTTPStatus != 0 {
+				body.AbortHttpStatus = &chaosAbortHTTPStatus
+			}
+			body.Percentage = &chaosPercentage
+			fmt.Printf("Injecting faults into %v%% of the requests of flow %s...\n", chaosPercentage, flowID)
+		}
+
+		tenantUuid, err := tenant.GetOrCreateUserTenantUUID()
+		if err != nil {
+			log.Fatal("Error getting or creating user tenant UUID", err)
+		}
+
+		setFlowChaos(tenantUuid.String(), body)
 	},
 }
 
@@ -103,7 +276,7 @@ var deleteCmd = &cobra.Command{
 	Short: "Delete services",
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		serviceConfigs, err := parseKubernetesManifestFile(kubernetesManifestFile)
+		serviceConfigs, err := parseKubernetesManifests(kubernetesManifests, helmChart, helmValuesFiles, helmReleaseName)
 		if err != nil {
 			log.Fatalf("Error loading k8s manifest file: %v", err)
 		}
@@ -136,11 +309,11 @@ var deployManagerCmd = &cobra.Command{
 			log.Fatal("Error getting or creating user tenant UUID", err)
 		}
 
-		if err := deployManager(tenantUuid.String(), kontrolLocation); err != nil {
+		if err := deployManager(tenantUuid.String(), kontrolLocation, managerReplicas, managerExtraResources); err != nil {
 			log.Fatal("Error deploying Kardinal manager", err)
 		}
 
-		logrus.Infof("Kardinal manager deployed using '%s' Kontrol", kontrolLocation)
+		logrus.Infof("Kardinal manager deployed with %d replicas using '%s' Kontrol", managerReplicas, kontrolLocation)
 	},
 }
 
@@ -157,6 +330,17 @@ var removeManagerCmd = &cobra.Command{
 	},
 }
 
+var planManagerCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes Kardinal manager would make in the cluster, without applying them",
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := planManager(); err != nil {
+			log.Fatal("Error planning Kardinal manager changes", err)
+		}
+	},
+}
+
 var dashboardCmd = &cobra.Command{
 	Use:   "dashboard",
 	Short: "Open your Kardinal Dashboard",
@@ -178,83 +362,294 @@ func init() {
 	rootCmd.AddCommand(managerCmd)
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(dashboardCmd)
-	flowCmd.AddCommand(createCmd, deleteCmd)
-	managerCmd.AddCommand(deployManagerCmd, removeManagerCmd)
+	flowCmd.AddCommand(createCmd, deleteCmd, canaryCmd, chaosCmd)
+	canaryCmd.AddCommand(canaryWeightCmd, canaryPromoteCmd, canaryAbortCmd)
+	managerCmd.AddCommand(deployManagerCmd, removeManagerCmd, planManagerCmd)
+
+	// The chaos command only needs the flow ID
+	for _, cmd := range []*cobra.Command{createCmd, deleteCmd, canaryCmd, deployCmd} {
+		cmd.PersistentFlags().StringArrayVarP(&kubernetesManifests, "k8s-manifest", "k", nil, "Path to a K8S manifest file, a directory of manifests (a kustomization directory is built with kustomize), a glob pattern or '-' to read the standard input. Can be set several times")
+		cmd.PersistentFlags().StringVar(&helmChart, "helm-chart", "", "Path to a Helm chart, rendered in process like helm template does, instead of or along with the K8S manifests. Only its Services and Deployments are used")
+		cmd.PersistentFlags().StringArrayVar(&helmValuesFiles, "values", nil, "Values file of the Helm chart, can be set several times and the last one takes precedence")
+		cmd.PersistentFlags().StringVar(&helmReleaseName, "helm-release-name", "", "Release name the Helm chart is rendered with, Helm's default one when it's not set")
+		cmd.MarkFlagsOneRequired("k8s-manifest", "helm-chart")
+	}
+
+	deployManagerCmd.Flags().IntVar(&managerReplicas, "replicas", defaultManagerReplicas, "Number of Kardinal manager replicas, only the elected leader applies the flows while the rest stand by to take over")
+	deployManagerCmd.Flags().StringArrayVar(&managerExtraResources, "extra-resource", nil, "Resource of the extra resources sent by Kontrol the Kardinal manager is allowed to manage, like 'rollouts.argoproj.io' or 'widgets' for the core group. Its ClusterRole only grants access to the kinds Kardinal manages otherwise. Can be set several times")
+
+	createCmd.Flags().BoolVar(&waitForClusterSync, "wait", defaultWaitForClusterSync, "Wait for the Kardinal manager to apply the flow in the cluster and report the result")
+	createCmd.Flags().DurationVar(&clusterSyncTimeout, "wait-timeout", defaultClusterSyncTimeout, "How long to wait for the Kardinal manager to apply the flow in the cluster")
+	createCmd.Flags().IntVar(&trafficPercentage, "traffic-percentage", minTrafficPercentage, "Percentage of the prod traffic sent to the dev image, which makes the flow a canary. With --mirror it's the percentage of the prod traffic mirrored to the dev image, all of it when it's not set. When it's not set only the requests of the flow reach the dev image")
+	createCmd.Flags().BoolVar(&mirrorTraffic, "mirror", false, "Send a copy of the prod traffic to the dev image, discarding its responses, to validate it against real traffic")
 
-	flowCmd.PersistentFlags().StringVarP(&kubernetesManifestFile, "k8s-manifest", "k", "", "Path to the K8S manifest file")
-	flowCmd.MarkPersistentFlagRequired("k8s-manifest")
-	deployCmd.PersistentFlags().StringVarP(&kubernetesManifestFile, "k8s-manifest", "k", "", "Path to the K8S manifest file")
-	deployCmd.MarkPersistentFlagRequired("k8s-manifest")
+	canaryCmd.PersistentFlags().BoolVar(&waitForClusterSync, "wait", defaultWaitForClusterSync, "Wait for the Kardinal manager to apply the change in the cluster and report the result")
+	canaryCmd.PersistentFlags().DurationVar(&clusterSyncTimeout, "wait-timeout", defaultClusterSyncTimeout, "How long to wait for the Kardinal manager to apply the change in the cluster")
+
+	chaosCmd.Flags().DurationVar(&chaosDelay, "delay", 0, "Delay added to the requests of the flow, e.g. 2s")
+	chaosCmd.Flags().IntVar(&chaosAbortHTTPStatus, "abort", 0, "Error HTTP status, between 400 and 599, returned to the requests of the flow instead of forwarding them, e.g. 503")
+	chaosCmd.Flags().Float32Var(&chaosPercentage, "percent", maxChaosPercentage, "Percentage of the requests of the flow the faults are injected into")
+	chaosCmd.Flags().BoolVar(&clearChaos, "clear", false, "Remove the faults of the flow")
+	chaosCmd.Flags().BoolVar(&waitForClusterSync, "wait", defaultWaitForClusterSync, "Wait for the Kardinal manager to apply the change in the cluster and report the result")
+	chaosCmd.Flags().DurationVar(&clusterSyncTimeout, "wait-timeout", defaultClusterSyncTimeout, "How long to wait for the Kardinal manager to apply the change in the cluster")
 }
 
 func Execute() error {
 	return rootCmd.Execute()
 }
 
-func loadKubernetesManifestFile(filename string) ([]byte, error) {
+func parseKubernetesManifests(kubernetesManifests []string, helmChartPath string, helmValuesFiles []string, helmReleaseName string) ([]api_types.ServiceConfig, error) {
+	if helmChartPath == "" && len(helmValuesFiles) > 0 {
+		return nil, stacktrace.NewError("The Helm values files are set but there's no Helm chart to render them with")
+	}
 
-	fileBytes, err := os.ReadFile(filename)
+	var chart *manifest.HelmChart
+	if helmChartPath != "" {
+		chart = manifest.NewHelmChart(helmChartPath, helmValuesFiles, helmReleaseName)
+	}
+
+	serviceConfigs, err := manifest.ParseServiceConfigsFromSources(kubernetesManifests, chart, os.Stdin)
 	if err != nil {
-		return fileBytes, stacktrace.Propagate(err, "attempted to read kubernetes manifest file with path '%s' but failed", filename)
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the kubernetes manifests")
 	}
 
-	return fileBytes, nil
+	return serviceConfigs, nil
 }
 
-func parseKubernetesManifestFile(kubernetesManifestFile string) ([]api_types.ServiceConfig, error) {
-	fileBytes, err := loadKubernetesManifestFile(kubernetesManifestFile)
+func createDevFlow(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig, imageLocator, serviceName string, mode api_types.DevFlowSpecMode, trafficPercentage *int) {
+	ctx := context.Background()
+
+	body := api_types.PostTenantUuidFlowCreateJSONRequestBody{
+		ServiceConfigs:    &serviceConfigs,
+		ServiceName:       &serviceName,
+		ImageLocator:      &imageLocator,
+		Mode:              &mode,
+		TrafficPercentage: trafficPercentage,
+	}
+	client := getKontrolServiceClient()
+
+	resp, err := client.PostTenantUuidFlowCreateWithResponse(ctx, tenantUuid, body)
 	if err != nil {
-		log.Fatalf("Error loading kubernetest manifest file: %v", err)
-		return nil, err
+		log.Fatalf("Failed to create dev flow: %v", err)
 	}
 
-	manifest := string(fileBytes)
-	// TODO: Check format of manifest file
-	blocks := strings.Split(manifest, "---")
-	if len(blocks)%2 != 0 {
-		return nil, stacktrace.NewError("The manifest should contain pairs of service / deployment specifications")
+	fmt.Printf("Response: %s\n", string(resp.Body))
+
+	waitForFlowToBeApplied(ctx, client, tenantUuid, resp.HTTPResponse, "dev flow")
+}
+
+func setCanaryWeight(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig, serviceName string, trafficPercentage int) {
+	ctx := context.Background()
+
+	body := api_types.PostTenantUuidFlowCanaryWeightJSONRequestBody{
+		ServiceConfigs:    &serviceConfigs,
+		ServiceName:       serviceName,
+		TrafficPercentage: trafficPercentage,
 	}
-	serviceConfigs := make([]api_types.ServiceConfig, len(blocks)/2)
-	decode := scheme.Codecs.UniversalDeserializer().Decode
-	for index, spec := range strings.Split(manifest, "---") {
-		if len(spec) == 0 {
-			continue
-		}
-		obj, _, err := decode([]byte(spec), nil, nil)
-		if err != nil {
-			continue
-		}
-		switch obj := obj.(type) {
-		case *corev1.Service:
-			service := obj
-			serviceConfigs[index/2].Service = *service
-		case *appv1.Deployment:
-			deployment := obj
-			serviceConfigs[index/2].Deployment = *deployment
-		default:
-			return nil, stacktrace.NewError("An error occurred parsing the manifest because of an unsupported kubernetes type")
-		}
+	client := getKontrolServiceClient()
+
+	resp, err := client.PostTenantUuidFlowCanaryWeightWithResponse(ctx, tenantUuid, body)
+	if err != nil {
+		log.Fatalf("Failed to change the canary weight: %v", err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		log.Fatalf("There is no canary flow for service '%s', create it with 'kardinal flow create --traffic-percentage'", serviceName)
 	}
 
-	return serviceConfigs, nil
+	fmt.Printf("Response: %s\n", string(resp.Body))
+
+	waitForFlowToBeApplied(ctx, client, tenantUuid, resp.HTTPResponse, "canary weight")
 }
 
-func createDevFlow(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig, imageLocator, serviceName string) {
+func promoteCanary(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig, serviceName string) {
 	ctx := context.Background()
 
-	body := api_types.PostTenantUuidFlowCreateJSONRequestBody{
+	body := api_types.PostTenantUuidFlowCanaryPromoteJSONRequestBody{
 		ServiceConfigs: &serviceConfigs,
-		ServiceName:    &serviceName,
-		ImageLocator:   &imageLocator,
+		ServiceName:    serviceName,
 	}
 	client := getKontrolServiceClient()
 
-	resp, err := client.PostTenantUuidFlowCreateWithResponse(ctx, tenantUuid, body)
+	resp, err := client.PostTenantUuidFlowCanaryPromoteWithResponse(ctx, tenantUuid, body)
 	if err != nil {
-		log.Fatalf("Failed to create dev flow: %v", err)
+		log.Fatalf("Failed to promote the canary: %v", err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		log.Fatalf("There is no canary flow for service '%s'", serviceName)
+	}
+
+	fmt.Printf("Response: %s\n", string(resp.Body))
+
+	waitForFlowToBeApplied(ctx, client, tenantUuid, resp.HTTPResponse, "canary promotion")
+}
+
+func abortCanary(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig, serviceName string) {
+	ctx := context.Background()
+
+	body := api_types.PostTenantUuidFlowCanaryAbortJSONRequestBody{
+		ServiceConfigs: &serviceConfigs,
+		ServiceName:    serviceName,
+	}
+	client := getKontrolServiceClient()
+
+	resp, err := client.PostTenantUuidFlowCanaryAbortWithResponse(ctx, tenantUuid, body)
+	if err != nil {
+		log.Fatalf("Failed to abort the canary: %v", err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		log.Fatalf("There is no canary flow for service '%s'", serviceName)
+	}
+
+	fmt.Printf("Response: %s\n", string(resp.Body))
+
+	waitForFlowToBeApplied(ctx, client, tenantUuid, resp.HTTPResponse, "canary abort")
+}
+
+func setFlowChaos(tenantUuid api_types.Uuid, body api_types.FlowChaosSpec) {
+	ctx := context.Background()
+
+	client := getKontrolServiceClient()
+
+	resp, err := client.PostTenantUuidFlowChaosWithResponse(ctx, tenantUuid, body)
+	if err != nil {
+		log.Fatalf("Failed to change the faults of the flow: %v", err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		log.Fatalf("Flow '%s' doesn't exist", body.FlowId)
 	}
 
 	fmt.Printf("Response: %s\n", string(resp.Body))
+
+	waitForFlowToBeApplied(ctx, client, tenantUuid, resp.HTTPResponse, "flow faults")
+}
+
+func validateChaos(delay time.Duration, abortHTTPStatus int, percentage float32) error {
+	if delay == 0 && abortHTTPStatus == 0 {
+		return stacktrace.NewError("Set a --delay, an --abort HTTP status or both, or --clear to remove the faults of the flow")
+	}
+	if delay < 0 {
+		return stacktrace.NewError("The delay can't be negative, got %s", delay)
+	}
+	if abortHTTPStatus != 0 && (abortHTTPStatus < minAbortHTTPStatus || abortHTTPStatus > maxAbortHTTPStatus) {
+		return stacktrace.NewError("The abort HTTP status must be between %d and %d, got %d", minAbortHTTPStatus, maxAbortHTTPStatus, abortHTTPStatus)
+	}
+	if percentage < minChaosPercentage || percentage > maxChaosPercentage {
+		return stacktrace.NewError("The percentage must be between %d and %d, got %v", minChaosPercentage, maxChaosPercentage, percentage)
+	}
+	return nil
+}
+
+func validateTrafficPercentage(percentage int) error {
+	if percentage < minTrafficPercentage || percentage > maxTrafficPercentage {
+		return stacktrace.NewError("The traffic percentage must be between %d and %d, got %d", minTrafficPercentage, maxTrafficPercentage, percentage)
+	}
+	return nil
+}
+
+// waitForFlowToBeApplied waits for the manager to report the result of the change when --wait is set, exiting if it
+// failed to apply it
+func waitForFlowToBeApplied(ctx context.Context, client *api.ClientWithResponses, tenantUuid api_types.Uuid, changeResponse *http.Response, change string) {
+	if !waitForClusterSync {
+		return
+	}
+
+	version := changeResponse.Header.Get(clusterResourcesVersionHeaderKey)
+	if version == "" {
+		logrus.Warnf("Kontrol didn't return the version of the cluster resources with the %s, so the CLI can't wait for the Kardinal manager to apply it", change)
+		return
+	}
+
+	fmt.Printf("Waiting up to %s for the Kardinal manager to apply the %s in the cluster...\n", clusterSyncTimeout, change)
+	clusterStatus, err := waitForClusterStatusOfVersion(ctx, client, tenantUuid, version)
+	if err != nil {
+		log.Fatalf("Failed to wait for the %s to be applied in the cluster: %v", change, err)
+	}
+
+	printClusterStatus(clusterStatus)
+	if len(clusterStatus.Errors) > 0 {
+		log.Fatalf("The Kardinal manager failed to apply the %s in the cluster", change)
+	}
+	if clusterStatus.DryRun != nil && *clusterStatus.DryRun {
+		log.Fatalf("The Kardinal manager runs in dry-run mode, so the %s was only planned and not applied in the cluster", change)
+	}
+	fmt.Printf("The %s was applied in the cluster\n", change)
+}
+
+// getClusterStatus returns nil when the manager didn't report any cluster status yet
+func getClusterStatus(ctx context.Context, client *api.ClientWithResponses, tenantUuid api_types.Uuid) (*api_types.ClusterStatus, error) {
+	resp, err := client.GetTenantUuidClusterStatusWithResponse(ctx, tenantUuid)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the cluster status of tenant '%s'", tenantUuid)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return resp.JSON200, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, stacktrace.NewError("Getting the cluster status of tenant '%s' returned an unexpected status '%s' with body '%s'", tenantUuid, resp.Status(), string(resp.Body))
+	}
+}
+
+// waitForClusterStatusOfVersion polls the cluster status until the manager reports the sync of the version, the manager
+// reports every sync so the version is reported even when it didn't change anything in the cluster
+func waitForClusterStatusOfVersion(ctx context.Context, client *api.ClientWithResponses, tenantUuid api_types.Uuid, version string) (*api_types.ClusterStatus, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, clusterSyncTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(clusterStatusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		clusterStatus, err := getClusterStatus(timeoutCtx, client, tenantUuid)
+		if err != nil {
+			logrus.Debugf("An error occurred getting the cluster status, retrying. Error was:\n%s", err)
+		} else if clusterStatus != nil && clusterStatus.Version != nil && *clusterStatus.Version == version {
+			return clusterStatus, nil
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return nil, stacktrace.NewError("The Kardinal manager didn't report the sync of cluster resources version '%s' within %s, make sure it's deployed with 'kardinal manager deploy'", version, clusterSyncTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func printClusterStatus(clusterStatus *api_types.ClusterStatus) {
+	version := ""
+	if clusterStatus.Version != nil {
+		version = *clusterStatus.Version
+	}
+	fmt.Printf("Cluster resources version '%s' synced at %s by manager version '%s'\n", version, clusterStatus.SyncTime.Local().Format(time.RFC3339), clusterStatus.ManagerVersion)
+
+	// The applied and unchanged resources are only counted, the rest are listed with the reason
+	statusCounts := map[api_types.ResourceStatusStatus]int{}
+	for _, resource := range clusterStatus.Resources {
+		statusCounts[resource.Status]++
+	}
+	fmt.Printf("Resources: %d applied, %d unchanged, %d failed, %d not-ready, %d held-back\n",
+		statusCounts[api_types.Applied], statusCounts[api_types.Unchanged], statusCounts[api_types.Failed], statusCounts[api_types.NotReady], statusCounts[api_types.HeldBack])
+
+	for _, resource := range clusterStatus.Resources {
+		if resource.Status == api_types.Applied || resource.Status == api_types.Unchanged {
+			continue
+		}
+		resourceName := resource.Name
+		if resource.Namespace != nil {
+			resourceName = *resource.Namespace + "/" + resource.Name
+		}
+		message := ""
+		if resource.Message != nil {
+			message = ": " + *resource.Message
+		}
+		fmt.Printf("  %s '%s' is %s%s\n", resource.Kind, resourceName, resource.Status, message)
+	}
+
+	for _, errorMessage := range clusterStatus.Errors {
+		fmt.Printf("Error: %s\n", errorMessage)
+	}
 }
 
 func deploy(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceConfig) {
@@ -297,7 +692,7 @@ func deleteFlow(tenantUuid api_types.Uuid, serviceConfigs []api_types.ServiceCon
 	fmt.Printf("Response: %s\n", string(resp.Body))
 }
 
-func deployManager(tenantUuid api_types.Uuid, kontrolLocation string) error {
+func deployManager(tenantUuid api_types.Uuid, kontrolLocation string, replicas int, extraResources []string) error {
 
 	ctx := context.Background()
 
@@ -306,13 +701,28 @@ func deployManager(tenantUuid api_types.Uuid, kontrolLocation string) error {
 		return stacktrace.Propagate(err, "Error getting cluster resources URL")
 	}
 
-	if err := deployment.DeployKardinalManagerInCluster(ctx, clusterResourcesURL, kontrolLocation); err != nil {
+	if err := deployment.DeployKardinalManagerInCluster(ctx, clusterResourcesURL, tenantUuid, kontrolLocation, replicas, extraResources); err != nil {
 		return stacktrace.Propagate(err, "An error occurred deploying Kardinal manager into the cluster with cluster resources URL '%s'", clusterResourcesURL)
 	}
 
 	return nil
 }
 
+// planManager prints the plan computed by the Kardinal manager deployed in the cluster, for the cluster resources it
+// gets from Kontrol
+func planManager() error {
+	ctx := context.Background()
+
+	plan, err := deployment.GetKardinalManagerPlan(ctx)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the Kardinal manager plan")
+	}
+
+	fmt.Print(plan)
+
+	return nil
+}
+
 func removeManager() error {
 	ctx := context.Background()
 
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestValidateChaos_OnlyAcceptsErrorAbortHTTPStatuses(t *testing.T) {
+	require.NoError(t, validateChaos(0, 503, 100))
+	require.NoError(t, validateChaos(0, 400, 100))
+	require.NoError(t, validateChaos(0, 599, 100))
+	require.NoError(t, validateChaos(time.Second, 0, 100))
+
+	// An abort with a successful or a redirect status wouldn't inject any error
+	require.Error(t, validateChaos(0, 200, 100))
+	require.Error(t, validateChaos(0, 302, 100))
+	require.Error(t, validateChaos(0, 600, 100))
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"kardinal.cli/kontrol"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/kurtosis-tech/stacktrace"
@@ -13,6 +15,8 @@ import (
 const (
 	kardinalNamespace                 = "default"
 	kardinalManagerDeploymentTmplName = "kardinal-manager-deployment"
+	minReplicas                       = 1
+	extraResourceGroupSeparator       = "."
 
 	kardinalManagerDeploymentTmpl = `
 apiVersion: v1
@@ -32,8 +36,13 @@ metadata:
     {{.KardinalAppIDLabelKey}}: {{.KardinalManagerAppIDLabelValue}}
 rules:
   - apiGroups: ["*"]
-    resources: ["namespaces", "pods", "services", "deployments", "virtualservices", "workloadgroups", "workloadentries", "sidecars", "serviceentries", "gateways", "envoyfilters", "destinationrules"]
+    resources: ["namespaces", "pods", "services", "deployments", "virtualservices", "workloadgroups", "workloadentries", "sidecars", "serviceentries", "gateways", "envoyfilters", "destinationrules", "configmaps", "secrets", "serviceaccounts", "persistentvolumeclaims", "statefulsets", "replicasets", "jobs", "cronjobs", "endpointslices", "leases", "pods/portforward"]
     verbs: ["get", "list", "watch", "create", "update", "patch", "delete"]
+{{- range .ExtraResourceRules}}
+  - apiGroups: ["{{.APIGroup}}"]
+    resources: ["{{.Resource}}"]
+    verbs: ["get", "list", "watch", "create", "update", "patch", "delete"]
+{{- end}}
 
 ---
 apiVersion: rbac.authorization.k8s.io/v1
@@ -60,7 +69,7 @@ metadata:
   labels:
     {{.KardinalAppIDLabelKey}}: {{.KardinalManagerAppIDLabelValue}}
 spec:
-  replicas: 1
+  replicas: {{.Replicas}}
   selector:
     matchLabels:
       {{.KardinalAppIDLabelKey}}: {{.KardinalManagerAppIDLabelValue}}
@@ -68,12 +77,48 @@ spec:
     metadata:
       labels:
         {{.KardinalAppIDLabelKey}}: {{.KardinalManagerAppIDLabelValue}}
+      annotations:
+        prometheus.io/scrape: "true"
+        prometheus.io/port: "8080"
+        prometheus.io/path: /metrics
     spec:
       serviceAccountName: kardinal-manager
+      # Spreading the replicas across nodes lets a standby take over the leader election when a node is drained
+      affinity:
+        podAntiAffinity:
+          preferredDuringSchedulingIgnoredDuringExecution:
+            - weight: 100
+              podAffinityTerm:
+                topologyKey: kubernetes.io/hostname
+                labelSelector:
+                  matchLabels:
+                    {{.KardinalAppIDLabelKey}}: {{.KardinalManagerAppIDLabelValue}}
       containers:
         - name: kardinal-manager
           image: kurtosistech/kardinal-manager:latest
           imagePullPolicy: {{.KardinalManagerContainerImagePullPolicy}}
+          ports:
+            - name: http
+              containerPort: 8080
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+            periodSeconds: 10
+            failureThreshold: 3
+          # Only the leader waits for its first sync of the cluster resources to be ready, the standbys are ready right away
+          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: http
+            periodSeconds: 5
+            failureThreshold: 3
+          startupProbe:
+            httpGet:
+              path: /healthz
+              port: http
+            periodSeconds: 2
+            failureThreshold: 30
           env:
             - name: KUBERNETES_SERVICE_HOST
               value: "kubernetes.default.svc"
@@ -81,20 +126,53 @@ spec:
               value: "443"
             - name: KARDINAL_MANAGER_CLUSTER_CONFIG_ENDPOINT
               value: "{{.ClusterResourcesURL}}"
+            - name: KARDINAL_MANAGER_TENANT_UUID
+              value: "{{.TenantUuid}}"
             - name: KARDINAL_MANAGER_FETCHER_JOB_DURATION_SECONDS
               value: "10"
+            - name: KARDINAL_MANAGER_POD_NAME
+              valueFrom:
+                fieldRef:
+                  fieldPath: metadata.name
+            - name: KARDINAL_MANAGER_POD_NAMESPACE
+              valueFrom:
+                fieldRef:
+                  fieldPath: metadata.namespace
 `
 )
 
+var extraResourceRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
 type templateData struct {
 	Namespace                               string
 	ClusterResourcesURL                     string
+	TenantUuid                              string
 	KardinalAppIDLabelKey                   string
 	KardinalManagerAppIDLabelValue          string
 	KardinalManagerContainerImagePullPolicy string
+	Replicas                                int
+	ExtraResourceRules                      []extraResourceRule
+}
+
+// extraResourceRule grants the manager access to the objects of an extra resource kind sent by Kontrol
+type extraResourceRule struct {
+	APIGroup string
+	Resource string
 }
 
-func DeployKardinalManagerInCluster(ctx context.Context, clusterResourcesURL string, kontrolLocation string) error {
+// DeployKardinalManagerInCluster deploys the manager with the given number of replicas, only the one holding the leader
+// lease applies the cluster resources while the rest wait as standbys. The manager is only allowed to manage the extra
+// resources of the given resources, named like 'rollouts.argoproj.io'
+func DeployKardinalManagerInCluster(ctx context.Context, clusterResourcesURL string, tenantUuid string, kontrolLocation string, replicas int, extraResources []string) error {
+	if replicas < minReplicas {
+		return stacktrace.NewError("The kardinal-manager needs at least %d replica, got %d", minReplicas, replicas)
+	}
+
+	extraResourceRules, err := getExtraResourceRules(extraResources)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the ClusterRole rules of the extra resources")
+	}
+
 	kubernetesClientObj, err := createKubernetesClient()
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred while creating the Kubernetes client")
@@ -119,9 +197,12 @@ func DeployKardinalManagerInCluster(ctx context.Context, clusterResourcesURL str
 	templateDataObj := templateData{
 		Namespace:                               kardinalNamespace,
 		ClusterResourcesURL:                     clusterResourcesURL,
+		TenantUuid:                              tenantUuid,
 		KardinalAppIDLabelKey:                   consts.KardinalAppIDLabelKey,
 		KardinalManagerAppIDLabelValue:          consts.KardinalManagerAppIDLabelValue,
 		KardinalManagerContainerImagePullPolicy: imagePullPolicy,
+		Replicas:                                replicas,
+		ExtraResourceRules:                      extraResourceRules,
 	}
 
 	yamlFileContentsBuffer := &bytes.Buffer{}
@@ -153,3 +234,17 @@ func RemoveKardinalManagerFromCluster(ctx context.Context) error {
 
 	return nil
 }
+
+// getExtraResourceRules splits every resource, named like kubectl does it, in its resource and API group. A resource
+// without a group, like 'widgets', is in the core group
+func getExtraResourceRules(extraResources []string) ([]extraResourceRule, error) {
+	var extraResourceRules []extraResourceRule
+	for _, extraResource := range extraResources {
+		if !extraResourceRegexp.MatchString(extraResource) {
+			return nil, stacktrace.NewError("Invalid extra resource '%s', it has to be a lowercase resource name optionally followed by its API group, like 'rollouts.argoproj.io'", extraResource)
+		}
+		resource, apiGroup, _ := strings.Cut(extraResource, extraResourceGroupSeparator)
+		extraResourceRules = append(extraResourceRules, extraResourceRule{APIGroup: apiGroup, Resource: resource})
+	}
+	return extraResourceRules, nil
+}
@@ -0,0 +1,46 @@
+package deployment
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"text/template"
+)
+
+func TestGetExtraResourceRules_SplitsTheResourceAndTheAPIGroup(t *testing.T) {
+	extraResourceRules, err := getExtraResourceRules([]string{"rollouts.argoproj.io", "certificates.cert-manager.io", "widgets"})
+	require.NoError(t, err)
+	require.Equal(t, []extraResourceRule{
+		{APIGroup: "argoproj.io", Resource: "rollouts"},
+		{APIGroup: "cert-manager.io", Resource: "certificates"},
+		{APIGroup: "", Resource: "widgets"},
+	}, extraResourceRules)
+
+	for _, invalidExtraResource := range []string{"", "Rollouts.argoproj.io", "rollouts.argoproj.io\"]", "rollouts/status"} {
+		_, err = getExtraResourceRules([]string{invalidExtraResource})
+		require.Error(t, err, invalidExtraResource)
+	}
+}
+
+func TestKardinalManagerDeploymentTmpl_AddsTheExtraResourcesToTheClusterRole(t *testing.T) {
+	kardinalManagerDeploymentTemplate, err := template.New(kardinalManagerDeploymentTmplName).Parse(kardinalManagerDeploymentTmpl)
+	require.NoError(t, err)
+
+	yamlFileContentsBuffer := &bytes.Buffer{}
+	err = kardinalManagerDeploymentTemplate.Execute(yamlFileContentsBuffer, templateData{
+		Namespace:          kardinalNamespace,
+		Replicas:           minReplicas,
+		ExtraResourceRules: []extraResourceRule{{APIGroup: "argoproj.io", Resource: "rollouts"}},
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, yamlFileContentsBuffer.String(), `
+    verbs: ["get", "list", "watch", "create", "update", "patch", "delete"]
+  - apiGroups: ["argoproj.io"]
+    resources: ["rollouts"]
+    verbs: ["get", "list", "watch", "create", "update", "patch", "delete"]
+
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding`)
+}
@@ -0,0 +1,137 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/stacktrace"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"kardinal.cli/consts"
+	"net/http"
+	"os"
+)
+
+const (
+	// These have to match the port and the path the Kardinal manager serves the plan on, only on localhost
+	kardinalManagerRestAPIPort uint16 = 8081
+	kardinalManagerPlanPath           = "/api/plan"
+
+	// Zero lets the port forward pick a free local port
+	anyLocalPort uint16 = 0
+
+	portForwardLocalHost = "localhost"
+)
+
+// GetKardinalManagerPlan returns the kubectl-style diff of the changes the Kardinal manager would make in the cluster
+// when applying the cluster resources currently published by Kontrol. The manager computes the plan itself, the CLI
+// reaches its REST API through a port forward to one of its pods
+func GetKardinalManagerPlan(ctx context.Context) (string, error) {
+	kubernetesClientObj, err := createKubernetesClient()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred while creating the Kubernetes client")
+	}
+
+	podName, err := kubernetesClientObj.getRunningKardinalManagerPodName(ctx)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting a running Kardinal manager pod")
+	}
+
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+
+	localPort, err := kubernetesClientObj.forwardPodPort(ctx, kardinalNamespace, podName, kardinalManagerRestAPIPort, stopChan)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred forwarding a local port to the Kardinal manager pod '%s'", podName)
+	}
+
+	planURL := fmt.Sprintf("http://%s:%d%s", portForwardLocalHost, localPort, kardinalManagerPlanPath)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, planURL, nil)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred creating the request to get the Kardinal manager plan")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the Kardinal manager plan from pod '%s'", podName)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred reading the Kardinal manager plan response body")
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", stacktrace.NewError("The Kardinal manager answered with status '%s' when planning the changes, response body was: %s", response.Status, string(responseBody))
+	}
+
+	return string(responseBody), nil
+}
+
+// getRunningKardinalManagerPodName returns any running replica, the standbys can plan the changes too
+func (client *kubernetesClient) getRunningKardinalManagerPodName(ctx context.Context) (string, error) {
+	listOptions := buildListOptionsFromLabels(map[string]string{
+		consts.KardinalAppIDLabelKey: consts.KardinalManagerAppIDLabelValue,
+	})
+
+	pods, err := client.clientSet.CoreV1().Pods(kardinalNamespace).List(ctx, listOptions)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred listing the Kardinal manager pods in namespace '%s'", kardinalNamespace)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			return pod.Name, nil
+		}
+	}
+
+	return "", stacktrace.NewError("No Kardinal manager pod is running in namespace '%s', deploy it with 'kardinal manager deploy'", kardinalNamespace)
+}
+
+// forwardPodPort returns the local port forwarded to the pod port, the forward lasts until the stop channel is closed
+func (client *kubernetesClient) forwardPodPort(ctx context.Context, namespace string, podName string, podPort uint16, stopChan chan struct{}) (uint16, error) {
+	roundTripper, upgrader, err := spdy.RoundTripperFor(client.config)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred creating the port forward round tripper")
+	}
+
+	request := client.clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, request.URL())
+
+	readyChan := make(chan struct{})
+	ports := []string{fmt.Sprintf("%d:%d", anyLocalPort, podPort)}
+	portForwarder, err := portforward.NewOnAddresses(dialer, []string{portForwardLocalHost}, ports, stopChan, readyChan, io.Discard, os.Stderr)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred creating the port forward to pod '%s'", podName)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- portForwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyChan:
+	case err = <-errChan:
+		return 0, stacktrace.Propagate(err, "An error occurred forwarding the port %d of pod '%s'", podPort, podName)
+	case <-ctx.Done():
+		return 0, stacktrace.Propagate(ctx.Err(), "The port forward to pod '%s' wasn't ready before the context was done", podName)
+	}
+
+	forwardedPorts, err := portForwarder.GetPorts()
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred getting the local port forwarded to pod '%s'", podName)
+	}
+	if len(forwardedPorts) == 0 {
+		return 0, stacktrace.NewError("No local port was forwarded to pod '%s'", podName)
+	}
+
+	return forwardedPorts[0].Local, nil
+}
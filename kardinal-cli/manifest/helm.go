@@ -0,0 +1,89 @@
+package manifest
+
+import (
+	"github.com/kurtosis-tech/stacktrace"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	// The release name `helm template` uses when none is set
+	defaultHelmReleaseName = "release-name"
+	helmChartSourceSuffix  = " (helm template)"
+)
+
+// HelmChart is a chart rendered with the values files, in order, the same as `helm template`
+type HelmChart struct {
+	Path        string
+	ValuesFiles []string
+	// ReleaseName is used by the templates to name the objects, Helm's default one when it's empty
+	ReleaseName string
+}
+
+func NewHelmChart(path string, valuesFiles []string, releaseName string) *HelmChart {
+	return &HelmChart{
+		Path:        path,
+		ValuesFiles: valuesFiles,
+		ReleaseName: releaseName,
+	}
+}
+
+// RenderHelmChart renders the chart in process with a client-only dry-run install, like `helm template` does, and
+// decodes its Services and Deployments. The hooks, the chart tests included, aren't part of the rendered manifest
+func RenderHelmChart(helmChart *HelmChart) ([]*Document, error) {
+	chart, err := loader.Load(helmChart.Path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred loading Helm chart '%s'", helmChart.Path)
+	}
+	if dependencies := chart.Metadata.Dependencies; dependencies != nil {
+		if err = action.CheckDependencies(chart, dependencies); err != nil {
+			return nil, stacktrace.Propagate(err, "The dependencies of Helm chart '%s' are missing, they can be fetched with `helm dependency build`", helmChart.Path)
+		}
+	}
+
+	values, err := getHelmChartValues(helmChart.ValuesFiles)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the values files of Helm chart '%s'", helmChart.Path)
+	}
+
+	release, err := newHelmTemplateInstall(helmChart).Run(chart, values)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred rendering Helm chart '%s'", helmChart.Path)
+	}
+
+	documents, err := DecodeDocuments(helmChart.Path+helmChartSourceSuffix, []byte(release.Manifest))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred decoding the objects rendered by Helm chart '%s'", helmChart.Path)
+	}
+	return documents, nil
+}
+
+func newHelmTemplateInstall(helmChart *HelmChart) *action.Install {
+	install := action.NewInstall(&action.Configuration{
+		Log: func(string, ...interface{}) {},
+	})
+	install.DryRun = true
+	install.ClientOnly = true
+	// The release is never stored, so its name is never taken
+	install.Replace = true
+	install.ReleaseName = helmChart.ReleaseName
+	if install.ReleaseName == "" {
+		install.ReleaseName = defaultHelmReleaseName
+	}
+	install.Namespace = defaultNamespace
+	return install
+}
+
+// getHelmChartValues merges the values files, the values of a file override the ones of the files before it
+func getHelmChartValues(valuesFiles []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, valuesFile := range valuesFiles {
+		fileValues, err := chartutil.ReadValuesFile(valuesFile)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred reading values file '%s'", valuesFile)
+		}
+		values = chartutil.CoalesceTables(fileValues, values)
+	}
+	return values, nil
+}
@@ -0,0 +1,105 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	renderedHelmChartYAML = `---
+# Source: reviews/templates/serviceaccount.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: reviews
+---
+# Source: reviews/templates/service.yaml
+` + reviewsServiceYAML + `
+---
+# Source: reviews/templates/deployment.yaml
+` + reviewsDeploymentYAML + `
+---
+# Source: reviews/templates/servicemonitor.yaml
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: reviews
+`
+)
+
+func TestDecodeDocuments_KeepsTheServicesAndDeploymentsOfTheHelmChart(t *testing.T) {
+	documents, err := DecodeDocuments("reviews (helm template)", []byte(renderedHelmChartYAML))
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	require.Equal(t, "Service", documents[0].Object.GetObjectKind().GroupVersionKind().Kind)
+	require.Equal(t, 9, documents[0].Line)
+	require.Equal(t, "Deployment", documents[1].Object.GetObjectKind().GroupVersionKind().Kind)
+}
+
+func TestRenderHelmChart_MergesTheValuesFilesInOrder(t *testing.T) {
+	chartDir := t.TempDir()
+	writeTestFile(t, filepath.Join(chartDir, "Chart.yaml"), "apiVersion: v2\nname: reviews\nversion: 0.1.0\n")
+	writeTestFile(t, filepath.Join(chartDir, "values.yaml"), "image: reviews:v1\nport: 9080\n")
+	writeTestFile(t, filepath.Join(chartDir, "templates", "service.yaml"), `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Release.Name }}-reviews
+spec:
+  selector:
+    app: reviews
+  ports:
+    - port: {{ .Values.port }}
+`)
+	writeTestFile(t, filepath.Join(chartDir, "templates", "deployment.yaml"), `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}-reviews-v1
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+  template:
+    metadata:
+      labels:
+        app: reviews
+    spec:
+      containers:
+        - name: reviews
+          image: {{ .Values.image }}
+`)
+	writeTestFile(t, filepath.Join(chartDir, "templates", "serviceaccount.yaml"), "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: reviews\n")
+	writeTestFile(t, filepath.Join(chartDir, "templates", "tests", "test-connection.yaml"), `apiVersion: v1
+kind: Service
+metadata:
+  name: reviews-test
+  annotations:
+    "helm.sh/hook": test
+`)
+	valuesDir := t.TempDir()
+	devValuesFile := filepath.Join(valuesDir, "values-dev.yaml")
+	writeTestFile(t, devValuesFile, "image: reviews:dev\nport: 9081\n")
+	portValuesFile := filepath.Join(valuesDir, "values-port.yaml")
+	writeTestFile(t, portValuesFile, "port: 9082\n")
+
+	documents, err := RenderHelmChart(NewHelmChart(chartDir, []string{devValuesFile, portValuesFile}, "bookinfo"))
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	require.Equal(t, chartDir+" (helm template)", documents[0].Source)
+
+	serviceConfigs, err := GetServiceConfigs(documents)
+	require.NoError(t, err)
+	require.Len(t, serviceConfigs, 1)
+	require.Equal(t, "bookinfo-reviews", serviceConfigs[0].Service.Name)
+	require.Equal(t, int32(9082), serviceConfigs[0].Service.Spec.Ports[0].Port)
+	require.Equal(t, "bookinfo-reviews-v1", serviceConfigs[0].Deployment.Name)
+	require.Equal(t, "reviews:dev", serviceConfigs[0].Deployment.Spec.Template.Spec.Containers[0].Image)
+
+	documents, err = RenderHelmChart(NewHelmChart(chartDir, nil, ""))
+	require.NoError(t, err)
+	serviceConfigs, err = GetServiceConfigs(documents)
+	require.NoError(t, err)
+	require.Equal(t, "release-name-reviews", serviceConfigs[0].Service.Name)
+	require.Equal(t, int32(9080), serviceConfigs[0].Service.Spec.Ports[0].Port)
+}
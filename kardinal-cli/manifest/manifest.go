@@ -0,0 +1,273 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kurtosis-tech/stacktrace"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	api_types "github.com/kurtosis-tech/kardinal/libs/cli-kontrol-api/api/golang/types"
+)
+
+const (
+	documentSeparator    = "---"
+	documentEndMarker    = "..."
+	yamlCommentPrefix    = "#"
+	defaultNamespace     = "default"
+	lineSeparator        = "\n"
+	carriageReturn       = "\r"
+	serviceKindName      = "Service"
+	deploymentKindName   = "Deployment"
+	serviceAPIVersion    = "v1"
+	deploymentAPIVersion = "apps/v1"
+	objectKeyTmpl        = "%s/%s"
+	documentPositionTmpl = "%s:%d"
+)
+
+// The YAML errors report the line relative to the start of the document, e.g. "yaml: line 3: could not find expected ':'"
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// Document is a Kubernetes object of a manifest, with the line where it starts in its source
+type Document struct {
+	Source string
+	Line   int
+	Object runtime.Object
+}
+
+// DecodeDocuments decodes the Services and Deployments of the YAML stream. The rest of the objects, e.g. the ConfigMaps,
+// ServiceAccounts or custom resources the applications usually come with, are skipped, as well as the documents which
+// are empty or only have comments
+func DecodeDocuments(source string, content []byte) ([]*Document, error) {
+	return decodeRawDocuments(source, getServiceAndDeploymentDocuments(splitDocuments(string(content))))
+}
+
+// getServiceAndDeploymentDocuments checks the kind before decoding, so the objects of custom resources, which can't be
+// decoded, are skipped too
+func getServiceAndDeploymentDocuments(rawDocuments []*rawDocument) []*rawDocument {
+	var serviceAndDeploymentDocuments []*rawDocument
+	for _, rawDocument := range rawDocuments {
+		typeMeta := metav1.TypeMeta{APIVersion: "", Kind: ""}
+		// The invalid documents are kept so decoding them reports the error
+		if err := yaml.Unmarshal([]byte(rawDocument.content), &typeMeta); err == nil && !isServiceOrDeployment(typeMeta) {
+			continue
+		}
+		serviceAndDeploymentDocuments = append(serviceAndDeploymentDocuments, rawDocument)
+	}
+	return serviceAndDeploymentDocuments
+}
+
+func isServiceOrDeployment(typeMeta metav1.TypeMeta) bool {
+	return (typeMeta.Kind == serviceKindName && typeMeta.APIVersion == serviceAPIVersion) ||
+		(typeMeta.Kind == deploymentKindName && typeMeta.APIVersion == deploymentAPIVersion)
+}
+
+func decodeRawDocuments(source string, rawDocuments []*rawDocument) ([]*Document, error) {
+	decode := scheme.Codecs.UniversalDeserializer().Decode
+
+	var documents []*Document
+	for _, rawDocument := range rawDocuments {
+		obj, groupVersionKind, err := decode([]byte(rawDocument.content), nil, nil)
+		if err != nil {
+			return nil, stacktrace.NewError("Invalid Kubernetes object at %s: %s", getPosition(source, rawDocument.line), getErrorMessageWithAbsoluteLines(err, rawDocument.line))
+		}
+		// The typed objects don't always keep their kind once decoded
+		obj.GetObjectKind().SetGroupVersionKind(*groupVersionKind)
+		documents = append(documents, &Document{
+			Source: source,
+			Line:   rawDocument.line,
+			Object: obj,
+		})
+	}
+
+	return documents, nil
+}
+
+// GetServiceConfigs pairs every Service with the Deployment whose pod template labels match its selector, so the
+// documents can come in any order. Every Service must select exactly one Deployment, and every Deployment must be
+// selected by exactly one Service
+func GetServiceConfigs(documents []*Document) ([]api_types.ServiceConfig, error) {
+	var serviceDocuments, deploymentDocuments []*Document
+	documentsByKey := map[string]*Document{}
+	for _, document := range documents {
+		var kindName string
+		var object interface {
+			GetNamespace() string
+			GetName() string
+		}
+		switch obj := document.Object.(type) {
+		case *corev1.Service:
+			kindName, object = serviceKindName, obj
+			serviceDocuments = append(serviceDocuments, document)
+		case *appv1.Deployment:
+			kindName, object = deploymentKindName, obj
+			deploymentDocuments = append(deploymentDocuments, document)
+		default:
+			return nil, stacktrace.NewError("Unsupported kind '%s' at %s, only Services and Deployments are supported", document.Object.GetObjectKind().GroupVersionKind().Kind, document.position())
+		}
+
+		key := kindName + "/" + getObjectKey(object.GetNamespace(), object.GetName())
+		if previousDocument, found := documentsByKey[key]; found {
+			return nil, stacktrace.NewError("%s '%s' at %s is already defined at %s", kindName, getObjectKey(object.GetNamespace(), object.GetName()), document.position(), previousDocument.position())
+		}
+		documentsByKey[key] = document
+	}
+
+	selectedDeployments := map[*Document]*Document{}
+	serviceConfigs := make([]api_types.ServiceConfig, 0, len(serviceDocuments))
+	for _, serviceDocument := range serviceDocuments {
+		service := serviceDocument.Object.(*corev1.Service)
+		serviceKey := getObjectKey(service.Namespace, service.Name)
+		if len(service.Spec.Selector) == 0 {
+			return nil, stacktrace.NewError("Service '%s' at %s has no selector, so it can't be paired with a Deployment", serviceKey, serviceDocument.position())
+		}
+
+		var matchingDeploymentDocuments []*Document
+		for _, deploymentDocument := range deploymentDocuments {
+			if isDeploymentSelectedByService(deploymentDocument.Object.(*appv1.Deployment), service) {
+				matchingDeploymentDocuments = append(matchingDeploymentDocuments, deploymentDocument)
+			}
+		}
+
+		switch len(matchingDeploymentDocuments) {
+		case 0:
+			return nil, stacktrace.NewError("Service '%s' at %s doesn't select the pods of any Deployment", serviceKey, serviceDocument.position())
+		case 1:
+		default:
+			return nil, stacktrace.NewError("Service '%s' at %s selects the pods of several Deployments: %s", serviceKey, serviceDocument.position(), getDeploymentsDescription(matchingDeploymentDocuments))
+		}
+
+		deploymentDocument := matchingDeploymentDocuments[0]
+		if otherServiceDocument, found := selectedDeployments[deploymentDocument]; found {
+			otherService := otherServiceDocument.Object.(*corev1.Service)
+			return nil, stacktrace.NewError("Deployment %s is selected by Service '%s' at %s and by Service '%s' at %s, only one Service per Deployment is supported",
+				getDeploymentsDescription([]*Document{deploymentDocument}), getObjectKey(otherService.Namespace, otherService.Name), otherServiceDocument.position(), serviceKey, serviceDocument.position())
+		}
+		selectedDeployments[deploymentDocument] = serviceDocument
+
+		serviceConfigs = append(serviceConfigs, api_types.ServiceConfig{
+			Service:    *service,
+			Deployment: *deploymentDocument.Object.(*appv1.Deployment),
+		})
+	}
+
+	for _, deploymentDocument := range deploymentDocuments {
+		if _, found := selectedDeployments[deploymentDocument]; !found {
+			deployment := deploymentDocument.Object.(*appv1.Deployment)
+			return nil, stacktrace.NewError("Deployment '%s' at %s isn't selected by any Service", getObjectKey(deployment.Namespace, deployment.Name), deploymentDocument.position())
+		}
+	}
+
+	return serviceConfigs, nil
+}
+
+type rawDocument struct {
+	// line is the number of the first line of the content in the stream, starting at 1
+	line    int
+	content string
+}
+
+// splitDocuments splits the YAML stream by its document separators. The comments and blank lines before the content of
+// a document are dropped, so the document starts at its first line of content
+func splitDocuments(stream string) []*rawDocument {
+	var documents []*rawDocument
+	var current *rawDocument
+	var currentLines []string
+
+	endDocument := func() {
+		if current != nil {
+			current.content = strings.Join(currentLines, lineSeparator)
+			documents = append(documents, current)
+		}
+		current = nil
+		currentLines = nil
+	}
+
+	for index, line := range strings.Split(stream, lineSeparator) {
+		line = strings.TrimSuffix(line, carriageReturn)
+		if isDocumentBoundary(line) {
+			endDocument()
+			continue
+		}
+		if current == nil {
+			trimmedLine := strings.TrimSpace(line)
+			if trimmedLine == "" || strings.HasPrefix(trimmedLine, yamlCommentPrefix) {
+				continue
+			}
+			current = &rawDocument{line: index + 1, content: ""}
+		}
+		currentLines = append(currentLines, line)
+	}
+	endDocument()
+
+	return documents
+}
+
+// isDocumentBoundary matches the document separators, optionally followed by a comment, and the document end markers
+func isDocumentBoundary(line string) bool {
+	for _, marker := range []string{documentSeparator, documentEndMarker} {
+		if !strings.HasPrefix(line, marker) {
+			continue
+		}
+		rest := strings.TrimSpace(strings.TrimPrefix(line, marker))
+		if rest == "" || strings.HasPrefix(rest, yamlCommentPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDeploymentSelectedByService(deployment *appv1.Deployment, service *corev1.Service) bool {
+	if getNamespace(deployment.Namespace) != getNamespace(service.Namespace) {
+		return false
+	}
+	return labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(deployment.Spec.Template.Labels))
+}
+
+// getErrorMessageWithAbsoluteLines makes the line numbers of the YAML errors relative to the whole stream
+func getErrorMessageWithAbsoluteLines(err error, documentLine int) string {
+	return yamlErrorLineRegex.ReplaceAllStringFunc(err.Error(), func(match string) string {
+		relativeLine, convErr := strconv.Atoi(yamlErrorLineRegex.FindStringSubmatch(match)[1])
+		if convErr != nil {
+			return match
+		}
+		return fmt.Sprintf("line %d", documentLine+relativeLine-1)
+	})
+}
+
+func getDeploymentsDescription(deploymentDocuments []*Document) string {
+	descriptions := make([]string, 0, len(deploymentDocuments))
+	for _, deploymentDocument := range deploymentDocuments {
+		deployment := deploymentDocument.Object.(*appv1.Deployment)
+		descriptions = append(descriptions, fmt.Sprintf("'%s' at %s", getObjectKey(deployment.Namespace, deployment.Name), deploymentDocument.position()))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func getObjectKey(namespace string, name string) string {
+	return fmt.Sprintf(objectKeyTmpl, getNamespace(namespace), name)
+}
+
+// getNamespace returns the namespace the object is applied to, the default one when it's not set
+func getNamespace(namespace string) string {
+	if namespace == "" {
+		return defaultNamespace
+	}
+	return namespace
+}
+
+func (document *Document) position() string {
+	return getPosition(document.Source, document.Line)
+}
+
+func getPosition(source string, line int) string {
+	return fmt.Sprintf(documentPositionTmpl, source, line)
+}
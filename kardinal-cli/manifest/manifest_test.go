@@ -0,0 +1,137 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api_types "github.com/kurtosis-tech/kardinal/libs/cli-kontrol-api/api/golang/types"
+)
+
+const (
+	testSource = "manifest.yaml"
+
+	reviewsServiceYAML = `apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+  ports:
+    - port: 9080`
+
+	reviewsDeploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reviews-v1
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+  template:
+    metadata:
+      labels:
+        app: reviews
+        version: v1
+    spec:
+      containers:
+        - name: reviews
+          image: reviews:v1`
+
+	ratingsServiceYAML = `apiVersion: v1
+kind: Service
+metadata:
+  name: ratings
+spec:
+  selector:
+    app: ratings`
+
+	ratingsDeploymentYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ratings-v1
+spec:
+  selector:
+    matchLabels:
+      app: ratings
+  template:
+    metadata:
+      labels:
+        app: ratings
+    spec:
+      containers:
+        - name: ratings
+          image: ratings:v1`
+)
+
+func TestGetServiceConfigs_PairsTheObjectsInAnyOrder(t *testing.T) {
+	manifest := "# The bookinfo services\n---\n" +
+		reviewsDeploymentYAML + "\n---\n" +
+		ratingsServiceYAML + "\n--- # the ratings deployment\n" +
+		"# comments before the object\n" + ratingsDeploymentYAML + "\n---\n" +
+		reviewsServiceYAML + "\n---\n"
+
+	serviceConfigs, err := parseTestServiceConfigs(t, manifest)
+	require.NoError(t, err)
+	require.Len(t, serviceConfigs, 2)
+	require.Equal(t, "ratings", serviceConfigs[0].Service.Name)
+	require.Equal(t, "ratings-v1", serviceConfigs[0].Deployment.Name)
+	require.Equal(t, "reviews", serviceConfigs[1].Service.Name)
+	require.Equal(t, "reviews-v1", serviceConfigs[1].Deployment.Name)
+}
+
+func TestDecodeDocuments_ReportsTheLineOfTheDocuments(t *testing.T) {
+	manifest := "---\n" + reviewsServiceYAML + "\n---\n\n# comment\n" + reviewsDeploymentYAML
+
+	documents, err := DecodeDocuments(testSource, []byte(manifest))
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	require.Equal(t, 2, documents[0].Line)
+	require.Equal(t, 14, documents[1].Line)
+}
+
+func TestDecodeDocuments_ReportsTheAbsoluteLineOfTheYAMLErrors(t *testing.T) {
+	manifest := reviewsServiceYAML + "\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: broken\n  labels: [\n"
+
+	_, err := DecodeDocuments(testSource, []byte(manifest))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "manifest.yaml:11")
+	require.NotContains(t, err.Error(), "line 5:")
+}
+
+func TestGetServiceConfigs_RejectsUnpairedObjects(t *testing.T) {
+	// A Service without Deployment
+	_, err := parseTestServiceConfigs(t, reviewsServiceYAML+"\n---\n"+ratingsServiceYAML+"\n---\n"+ratingsDeploymentYAML)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Service 'default/reviews' at manifest.yaml:1 doesn't select the pods of any Deployment")
+
+	// A Deployment without Service
+	_, err = parseTestServiceConfigs(t, reviewsServiceYAML+"\n---\n"+reviewsDeploymentYAML+"\n---\n"+ratingsDeploymentYAML)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Deployment 'default/ratings-v1' at manifest.yaml:29 isn't selected by any Service")
+
+	// An unsupported kind
+	configMap := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}}
+	_, err = GetServiceConfigs([]*Document{{Source: testSource, Line: 11, Object: configMap}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unsupported kind 'ConfigMap' at manifest.yaml:11")
+}
+
+func TestDecodeDocuments_SkipsTheOtherKinds(t *testing.T) {
+	manifest := reviewsServiceYAML + "\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n---\n" +
+		"apiVersion: argoproj.io/v1alpha1\nkind: Rollout\nmetadata:\n  name: reviews\n---\n" + reviewsDeploymentYAML
+
+	serviceConfigs, err := parseTestServiceConfigs(t, manifest)
+	require.NoError(t, err)
+	require.Len(t, serviceConfigs, 1)
+	require.Equal(t, "reviews", serviceConfigs[0].Service.Name)
+}
+
+func parseTestServiceConfigs(t *testing.T, manifest string) ([]api_types.ServiceConfig, error) {
+	documents, err := DecodeDocuments(testSource, []byte(manifest))
+	require.NoError(t, err)
+	return GetServiceConfigs(documents)
+}
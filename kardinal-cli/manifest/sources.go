@@ -0,0 +1,221 @@
+package manifest
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kurtosis-tech/stacktrace"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	api_types "github.com/kurtosis-tech/kardinal/libs/cli-kontrol-api/api/golang/types"
+)
+
+const (
+	// StdinSource reads the manifest from the standard input
+	StdinSource = "-"
+
+	stdinSourceName       = "<stdin>"
+	globMetaCharacters    = "*?["
+	yamlFileExtension     = ".yaml"
+	ymlFileExtension      = ".yml"
+	jsonFileExtension     = ".json"
+	kustomizeSourceSuffix = " (kustomize build)"
+)
+
+var manifestFileExtensions = []string{yamlFileExtension, ymlFileExtension, jsonFileExtension}
+
+// ParseServiceConfigsFromSources loads the Kubernetes objects of every source and of the Helm chart, if any, and pairs
+// their Services with their Deployments, so an application can be split across several files
+func ParseServiceConfigsFromSources(sources []string, helmChart *HelmChart, stdin io.Reader) ([]api_types.ServiceConfig, error) {
+	if len(sources) == 0 && helmChart == nil {
+		return nil, stacktrace.NewError("At least one manifest or a Helm chart is required")
+	}
+
+	var documents []*Document
+	if len(sources) > 0 {
+		sourcesDocuments, err := LoadDocuments(sources, stdin)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred loading the Kubernetes objects of manifests '%s'", strings.Join(sources, "', '"))
+		}
+		documents = append(documents, sourcesDocuments...)
+	}
+	if helmChart != nil {
+		helmChartDocuments, err := RenderHelmChart(helmChart)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred loading the Kubernetes objects of Helm chart '%s'", helmChart.Path)
+		}
+		documents = append(documents, helmChartDocuments...)
+	}
+
+	serviceConfigs, err := GetServiceConfigs(documents)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred pairing the Services and Deployments of the manifests")
+	}
+
+	return serviceConfigs, nil
+}
+
+// LoadDocuments decodes the Kubernetes objects of the sources, in order. A source is either a file, a directory, a glob
+// pattern or StdinSource. The directories with a kustomization file are built with kustomize, the rest are walked
+// recursively for their YAML and JSON files
+func LoadDocuments(sources []string, stdin io.Reader) ([]*Document, error) {
+	if len(sources) == 0 {
+		return nil, stacktrace.NewError("At least one manifest is required")
+	}
+
+	var documents []*Document
+	stdinRead := false
+	for _, source := range sources {
+		var sourceDocuments []*Document
+		var err error
+		switch {
+		case source == StdinSource:
+			if stdinRead {
+				return nil, stacktrace.NewError("The standard input can only be read once, but '%s' is set several times", StdinSource)
+			}
+			stdinRead = true
+			sourceDocuments, err = loadStdinDocuments(stdin)
+		case isGlobPattern(source):
+			sourceDocuments, err = loadGlobDocuments(source)
+		default:
+			sourceDocuments, err = loadPathDocuments(source)
+		}
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred loading the Kubernetes objects of manifest '%s'", source)
+		}
+		documents = append(documents, sourceDocuments...)
+	}
+
+	return documents, nil
+}
+
+func loadStdinDocuments(stdin io.Reader) ([]*Document, error) {
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the manifest from the standard input")
+	}
+	return DecodeDocuments(stdinSourceName, content)
+}
+
+func loadGlobDocuments(pattern string) ([]*Document, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Invalid glob pattern '%s'", pattern)
+	}
+	if len(paths) == 0 {
+		return nil, stacktrace.NewError("No manifest matches the glob pattern '%s'", pattern)
+	}
+
+	var documents []*Document
+	for _, path := range paths {
+		pathDocuments, err := loadPathDocuments(path)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred loading the Kubernetes objects of '%s'", path)
+		}
+		documents = append(documents, pathDocuments...)
+	}
+	return documents, nil
+}
+
+func loadPathDocuments(path string) ([]*Document, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "attempted to read kubernetes manifest with path '%s' but failed", path)
+	}
+	if !fileInfo.IsDir() {
+		return loadFileDocuments(path)
+	}
+
+	var documents []*Document
+	err = filepath.WalkDir(path, func(currentPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			// The files of a kustomization are often patches rather than whole objects, so it's built instead
+			if !hasKustomizationFile(currentPath) {
+				return nil
+			}
+			kustomizeDocuments, err := loadKustomizeDocuments(currentPath)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, kustomizeDocuments...)
+			return filepath.SkipDir
+		}
+		if !isManifestFile(currentPath) {
+			return nil
+		}
+		fileDocuments, err := loadFileDocuments(currentPath)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, fileDocuments...)
+		return nil
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred walking the manifest directory '%s'", path)
+	}
+	if len(documents) == 0 {
+		return nil, stacktrace.NewError("The manifest directory '%s' doesn't have any Kubernetes object", path)
+	}
+
+	return documents, nil
+}
+
+func loadFileDocuments(path string) ([]*Document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "attempted to read kubernetes manifest file with path '%s' but failed", path)
+	}
+	return DecodeDocuments(path, content)
+}
+
+// loadKustomizeDocuments builds the kustomization in process, the same as `kustomize build <dir>`
+func loadKustomizeDocuments(dir string) ([]*Document, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resources, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred building the kustomization in '%s'", dir)
+	}
+
+	content, err := resources.AsYaml()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred rendering the kustomization in '%s' as YAML", dir)
+	}
+
+	return DecodeDocuments(dir+kustomizeSourceSuffix, content)
+}
+
+func hasKustomizationFile(dir string) bool {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		if fileInfo, err := os.Stat(filepath.Join(dir, fileName)); err == nil && !fileInfo.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+func isManifestFile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	for _, manifestFileExtension := range manifestFileExtensions {
+		if extension == manifestFileExtension {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlobPattern tells the glob patterns from the paths, a path which exists is never a pattern
+func isGlobPattern(source string) bool {
+	if !strings.ContainsAny(source, globMetaCharacters) {
+		return false
+	}
+	_, err := os.Stat(source)
+	return err != nil
+}
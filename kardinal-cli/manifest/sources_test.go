@@ -0,0 +1,67 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDocuments_WalksTheDirectoriesRecursively(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "reviews", "service.yaml"), reviewsServiceYAML)
+	writeTestFile(t, filepath.Join(dir, "reviews", "deployment.yml"), reviewsDeploymentYAML)
+	writeTestFile(t, filepath.Join(dir, "ratings.yaml"), ratingsServiceYAML+"\n---\n"+ratingsDeploymentYAML)
+	writeTestFile(t, filepath.Join(dir, "README.md"), "# Not a manifest")
+	writeTestFile(t, filepath.Join(dir, "reviews", "service-account.yaml"), "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: reviews\n")
+
+	serviceConfigs, err := ParseServiceConfigsFromSources([]string{dir}, nil, strings.NewReader(""))
+	require.NoError(t, err)
+	require.Len(t, serviceConfigs, 2)
+	require.Equal(t, "ratings", serviceConfigs[0].Service.Name)
+	require.Equal(t, "reviews", serviceConfigs[1].Service.Name)
+	require.Equal(t, "reviews-v1", serviceConfigs[1].Deployment.Name)
+}
+
+func TestLoadDocuments_CombinesGlobsFilesAndStdin(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "reviews-service.yaml"), reviewsServiceYAML)
+	writeTestFile(t, filepath.Join(dir, "reviews-deployment.yaml"), reviewsDeploymentYAML)
+	ratingsFile := filepath.Join(dir, "ratings.yaml")
+	writeTestFile(t, ratingsFile, ratingsServiceYAML)
+
+	sources := []string{filepath.Join(dir, "reviews-*.yaml"), ratingsFile, StdinSource}
+	documents, err := LoadDocuments(sources, strings.NewReader(ratingsDeploymentYAML))
+	require.NoError(t, err)
+	require.Len(t, documents, 4)
+	require.Equal(t, "<stdin>", documents[3].Source)
+
+	_, err = LoadDocuments([]string{StdinSource, StdinSource}, strings.NewReader(ratingsDeploymentYAML))
+	require.Error(t, err)
+
+	_, err = LoadDocuments([]string{filepath.Join(dir, "details-*.yaml")}, strings.NewReader(""))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "No manifest matches the glob pattern")
+}
+
+func TestLoadDocuments_BuildsTheKustomizations(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "base", "kustomization.yaml"), "resources:\n  - reviews.yaml\nconfigMapGenerator:\n  - name: reviews-config\n    literals:\n      - LOG_LEVEL=debug\n")
+	writeTestFile(t, filepath.Join(dir, "base", "reviews.yaml"), reviewsServiceYAML+"\n---\n"+reviewsDeploymentYAML)
+	writeTestFile(t, filepath.Join(dir, "overlay", "kustomization.yaml"), "resources:\n  - ../base\nnamespace: bookinfo\nnamePrefix: dev-\n")
+
+	serviceConfigs, err := ParseServiceConfigsFromSources([]string{filepath.Join(dir, "overlay")}, nil, strings.NewReader(""))
+	require.NoError(t, err)
+	require.Len(t, serviceConfigs, 1)
+	require.Equal(t, "dev-reviews", serviceConfigs[0].Service.Name)
+	require.Equal(t, "bookinfo", serviceConfigs[0].Service.Namespace)
+	require.Equal(t, "dev-reviews-v1", serviceConfigs[0].Deployment.Name)
+	require.Equal(t, "bookinfo", serviceConfigs[0].Deployment.Namespace)
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
@@ -5,7 +5,7 @@
   pname = "kardinal-manager";
   ldflags = pkgs.lib.concatStringsSep "\n" [
     "-X github.com/kurtosis-tech/kurtosis/kardinal.AppName=${pname}"
-    "-X github.com/kurtosis-tech/kurtosis/kardinal.Commit=${commit_hash}"
+    "-X kardinal.kontrol/kardinal-manager/version.Commit=${commit_hash}"
   ];
 in
   pkgs.buildGoApplication {
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/oapi-codegen/runtime"
 	. "kardinal.kontrol/kardinal-manager/api/http_rest/types"
 )
 
@@ -89,20 +90,118 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetDestinationRules request
+	GetDestinationRules(ctx context.Context, params *GetDestinationRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostDestinationRulesWithBody request with any body
+	PostDestinationRulesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostDestinationRules(ctx context.Context, body PostDestinationRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostDestinationRulesNameSubsetsWithBody request with any body
+	PostDestinationRulesNameSubsetsWithBody(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostDestinationRulesNameSubsets(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, body PostDestinationRulesNameSubsetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteDestinationRulesNameSubsetsSubset request
+	DeleteDestinationRulesNameSubsetsSubset(ctx context.Context, name string, subset string, params *DeleteDestinationRulesNameSubsetsSubsetParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPlan request
+	GetPlan(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTopologyNamespace request
+	GetTopologyNamespace(ctx context.Context, namespace string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteVirtualServices request
-	DeleteVirtualServices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteVirtualServices(ctx context.Context, params *DeleteVirtualServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetVirtualServices request
-	GetVirtualServices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetVirtualServices(ctx context.Context, params *GetVirtualServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostVirtualServicesWithBody request with any body
 	PostVirtualServicesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostVirtualServices(ctx context.Context, body PostVirtualServicesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetVirtualServicesNameRoutingRules request
+	GetVirtualServicesNameRoutingRules(ctx context.Context, name string, params *GetVirtualServicesNameRoutingRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostVirtualServicesNameRoutingRulesWithBody request with any body
+	PostVirtualServicesNameRoutingRulesWithBody(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostVirtualServicesNameRoutingRules(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, body PostVirtualServicesNameRoutingRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteVirtualServicesNameRoutingRulesRule request
+	DeleteVirtualServicesNameRoutingRulesRule(ctx context.Context, name string, rule string, params *DeleteVirtualServicesNameRoutingRulesRuleParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutVirtualServicesNameRoutingRulesRuleWithBody request with any body
+	PutVirtualServicesNameRoutingRulesRuleWithBody(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutVirtualServicesNameRoutingRulesRule(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetDestinationRules(ctx context.Context, params *GetDestinationRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDestinationRulesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDestinationRulesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDestinationRulesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDestinationRules(ctx context.Context, body PostDestinationRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDestinationRulesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDestinationRulesNameSubsetsWithBody(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDestinationRulesNameSubsetsRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDestinationRulesNameSubsets(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, body PostDestinationRulesNameSubsetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDestinationRulesNameSubsetsRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteVirtualServices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteVirtualServicesRequest(c.Server)
+func (c *Client) DeleteDestinationRulesNameSubsetsSubset(ctx context.Context, name string, subset string, params *DeleteDestinationRulesNameSubsetsSubsetParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteDestinationRulesNameSubsetsSubsetRequest(c.Server, name, subset, params)
 	if err != nil {
 		return nil, err
 	}
@@ -113,8 +212,44 @@ func (c *Client) DeleteVirtualServices(ctx context.Context, reqEditors ...Reques
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetVirtualServices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetVirtualServicesRequest(c.Server)
+func (c *Client) GetPlan(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPlanRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTopologyNamespace(ctx context.Context, namespace string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTopologyNamespaceRequest(c.Server, namespace)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteVirtualServices(ctx context.Context, params *DeleteVirtualServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteVirtualServicesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetVirtualServices(ctx context.Context, params *GetVirtualServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVirtualServicesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -149,35 +284,80 @@ func (c *Client) PostVirtualServices(ctx context.Context, body PostVirtualServic
 	return c.Client.Do(req)
 }
 
-// NewDeleteVirtualServicesRequest generates requests for DeleteVirtualServices
-func NewDeleteVirtualServicesRequest(server string) (*http.Request, error) {
-	var err error
+func (c *Client) GetVirtualServicesNameRoutingRules(ctx context.Context, name string, params *GetVirtualServicesNameRoutingRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVirtualServicesNameRoutingRulesRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostVirtualServicesNameRoutingRulesWithBody(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostVirtualServicesNameRoutingRulesRequestWithBody(c.Server, name, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/virtual-services")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) PostVirtualServicesNameRoutingRules(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, body PostVirtualServicesNameRoutingRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostVirtualServicesNameRoutingRulesRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) DeleteVirtualServicesNameRoutingRulesRule(ctx context.Context, name string, rule string, params *DeleteVirtualServicesNameRoutingRulesRuleParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteVirtualServicesNameRoutingRulesRuleRequest(c.Server, name, rule, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+func (c *Client) PutVirtualServicesNameRoutingRulesRuleWithBody(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutVirtualServicesNameRoutingRulesRuleRequestWithBody(c.Server, name, rule, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) PutVirtualServicesNameRoutingRulesRule(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutVirtualServicesNameRoutingRulesRuleRequest(c.Server, name, rule, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetVirtualServicesRequest generates requests for GetVirtualServices
-func NewGetVirtualServicesRequest(server string) (*http.Request, error) {
+// NewGetDestinationRulesRequest generates requests for GetDestinationRules
+func NewGetDestinationRulesRequest(server string, params *GetDestinationRulesParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -185,7 +365,7 @@ func NewGetVirtualServicesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/virtual-services")
+	operationPath := fmt.Sprintf("/destination-rules")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -195,6 +375,28 @@ func NewGetVirtualServicesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -203,19 +405,19 @@ func NewGetVirtualServicesRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostVirtualServicesRequest calls the generic PostVirtualServices builder with application/json body
-func NewPostVirtualServicesRequest(server string, body PostVirtualServicesJSONRequestBody) (*http.Request, error) {
+// NewPostDestinationRulesRequest calls the generic PostDestinationRules builder with application/json body
+func NewPostDestinationRulesRequest(server string, body PostDestinationRulesJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostVirtualServicesRequestWithBody(server, "application/json", bodyReader)
+	return NewPostDestinationRulesRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostVirtualServicesRequestWithBody generates requests for PostVirtualServices with any type of body
-func NewPostVirtualServicesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostDestinationRulesRequestWithBody generates requests for PostDestinationRules with any type of body
+func NewPostDestinationRulesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -223,7 +425,7 @@ func NewPostVirtualServicesRequestWithBody(server string, contentType string, bo
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/virtual-services")
+	operationPath := fmt.Sprintf("/destination-rules")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -243,101 +445,883 @@ func NewPostVirtualServicesRequestWithBody(server string, contentType string, bo
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostDestinationRulesNameSubsetsRequest calls the generic PostDestinationRulesNameSubsets builder with application/json body
+func NewPostDestinationRulesNameSubsetsRequest(server string, name string, params *PostDestinationRulesNameSubsetsParams, body PostDestinationRulesNameSubsetsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewPostDestinationRulesNameSubsetsRequestWithBody(server, name, params, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewPostDestinationRulesNameSubsetsRequestWithBody generates requests for PostDestinationRulesNameSubsets with any type of body
+func NewPostDestinationRulesNameSubsetsRequestWithBody(server string, name string, params *PostDestinationRulesNameSubsetsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// DeleteVirtualServicesWithResponse request
-	DeleteVirtualServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesResponse, error)
+	operationPath := fmt.Sprintf("/destination-rules/%s/subsets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// GetVirtualServicesWithResponse request
-	GetVirtualServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVirtualServicesResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostVirtualServicesWithBodyWithResponse request with any body
-	PostVirtualServicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error)
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	PostVirtualServicesWithResponse(ctx context.Context, body PostVirtualServicesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error)
-}
+		if params.Namespace != nil {
 
-type DeleteVirtualServicesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *VirtualService
-	JSONDefault  *NotOk
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// Status returns HTTPResponse.Status
-func (r DeleteVirtualServicesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteVirtualServicesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetVirtualServicesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]VirtualService
-	JSONDefault  *NotOk
-}
+	req.Header.Add("Content-Type", contentType)
 
-// Status returns HTTPResponse.Status
-func (r GetVirtualServicesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetVirtualServicesResponse) StatusCode() int {
+// NewDeleteDestinationRulesNameSubsetsSubsetRequest generates requests for DeleteDestinationRulesNameSubsetsSubset
+func NewDeleteDestinationRulesNameSubsetsSubsetRequest(server string, name string, subset string, params *DeleteDestinationRulesNameSubsetsSubsetParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subset", runtime.ParamLocationPath, subset)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/destination-rules/%s/subsets/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPlanRequest generates requests for GetPlan
+func NewGetPlanRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/plan")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTopologyNamespaceRequest generates requests for GetTopologyNamespace
+func NewGetTopologyNamespaceRequest(server string, namespace string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "namespace", runtime.ParamLocationPath, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/topology/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteVirtualServicesRequest generates requests for DeleteVirtualServices
+func NewDeleteVirtualServicesRequest(server string, params *DeleteVirtualServicesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, params.Name); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetVirtualServicesRequest generates requests for GetVirtualServices
+func NewGetVirtualServicesRequest(server string, params *GetVirtualServicesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostVirtualServicesRequest calls the generic PostVirtualServices builder with application/json body
+func NewPostVirtualServicesRequest(server string, body PostVirtualServicesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostVirtualServicesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostVirtualServicesRequestWithBody generates requests for PostVirtualServices with any type of body
+func NewPostVirtualServicesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetVirtualServicesNameRoutingRulesRequest generates requests for GetVirtualServicesNameRoutingRules
+func NewGetVirtualServicesNameRoutingRulesRequest(server string, name string, params *GetVirtualServicesNameRoutingRulesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services/%s/routing-rules", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostVirtualServicesNameRoutingRulesRequest calls the generic PostVirtualServicesNameRoutingRules builder with application/json body
+func NewPostVirtualServicesNameRoutingRulesRequest(server string, name string, params *PostVirtualServicesNameRoutingRulesParams, body PostVirtualServicesNameRoutingRulesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostVirtualServicesNameRoutingRulesRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewPostVirtualServicesNameRoutingRulesRequestWithBody generates requests for PostVirtualServicesNameRoutingRules with any type of body
+func NewPostVirtualServicesNameRoutingRulesRequestWithBody(server string, name string, params *PostVirtualServicesNameRoutingRulesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services/%s/routing-rules", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteVirtualServicesNameRoutingRulesRuleRequest generates requests for DeleteVirtualServicesNameRoutingRulesRule
+func NewDeleteVirtualServicesNameRoutingRulesRuleRequest(server string, name string, rule string, params *DeleteVirtualServicesNameRoutingRulesRuleParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "rule", runtime.ParamLocationPath, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services/%s/routing-rules/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutVirtualServicesNameRoutingRulesRuleRequest calls the generic PutVirtualServicesNameRoutingRulesRule builder with application/json body
+func NewPutVirtualServicesNameRoutingRulesRuleRequest(server string, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutVirtualServicesNameRoutingRulesRuleRequestWithBody(server, name, rule, params, "application/json", bodyReader)
+}
+
+// NewPutVirtualServicesNameRoutingRulesRuleRequestWithBody generates requests for PutVirtualServicesNameRoutingRulesRule with any type of body
+func NewPutVirtualServicesNameRoutingRulesRuleRequestWithBody(server string, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "rule", runtime.ParamLocationPath, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/virtual-services/%s/routing-rules/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Namespace != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "namespace", runtime.ParamLocationQuery, *params.Namespace); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetDestinationRulesWithResponse request
+	GetDestinationRulesWithResponse(ctx context.Context, params *GetDestinationRulesParams, reqEditors ...RequestEditorFn) (*GetDestinationRulesResponse, error)
+
+	// PostDestinationRulesWithBodyWithResponse request with any body
+	PostDestinationRulesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDestinationRulesResponse, error)
+
+	PostDestinationRulesWithResponse(ctx context.Context, body PostDestinationRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDestinationRulesResponse, error)
+
+	// PostDestinationRulesNameSubsetsWithBodyWithResponse request with any body
+	PostDestinationRulesNameSubsetsWithBodyWithResponse(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDestinationRulesNameSubsetsResponse, error)
+
+	PostDestinationRulesNameSubsetsWithResponse(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, body PostDestinationRulesNameSubsetsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDestinationRulesNameSubsetsResponse, error)
+
+	// DeleteDestinationRulesNameSubsetsSubsetWithResponse request
+	DeleteDestinationRulesNameSubsetsSubsetWithResponse(ctx context.Context, name string, subset string, params *DeleteDestinationRulesNameSubsetsSubsetParams, reqEditors ...RequestEditorFn) (*DeleteDestinationRulesNameSubsetsSubsetResponse, error)
+
+	// GetPlanWithResponse request
+	GetPlanWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPlanResponse, error)
+
+	// GetTopologyNamespaceWithResponse request
+	GetTopologyNamespaceWithResponse(ctx context.Context, namespace string, reqEditors ...RequestEditorFn) (*GetTopologyNamespaceResponse, error)
+
+	// DeleteVirtualServicesWithResponse request
+	DeleteVirtualServicesWithResponse(ctx context.Context, params *DeleteVirtualServicesParams, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesResponse, error)
+
+	// GetVirtualServicesWithResponse request
+	GetVirtualServicesWithResponse(ctx context.Context, params *GetVirtualServicesParams, reqEditors ...RequestEditorFn) (*GetVirtualServicesResponse, error)
+
+	// PostVirtualServicesWithBodyWithResponse request with any body
+	PostVirtualServicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error)
+
+	PostVirtualServicesWithResponse(ctx context.Context, body PostVirtualServicesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error)
+
+	// GetVirtualServicesNameRoutingRulesWithResponse request
+	GetVirtualServicesNameRoutingRulesWithResponse(ctx context.Context, name string, params *GetVirtualServicesNameRoutingRulesParams, reqEditors ...RequestEditorFn) (*GetVirtualServicesNameRoutingRulesResponse, error)
+
+	// PostVirtualServicesNameRoutingRulesWithBodyWithResponse request with any body
+	PostVirtualServicesNameRoutingRulesWithBodyWithResponse(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesNameRoutingRulesResponse, error)
+
+	PostVirtualServicesNameRoutingRulesWithResponse(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, body PostVirtualServicesNameRoutingRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesNameRoutingRulesResponse, error)
+
+	// DeleteVirtualServicesNameRoutingRulesRuleWithResponse request
+	DeleteVirtualServicesNameRoutingRulesRuleWithResponse(ctx context.Context, name string, rule string, params *DeleteVirtualServicesNameRoutingRulesRuleParams, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesNameRoutingRulesRuleResponse, error)
+
+	// PutVirtualServicesNameRoutingRulesRuleWithBodyWithResponse request with any body
+	PutVirtualServicesNameRoutingRulesRuleWithBodyWithResponse(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutVirtualServicesNameRoutingRulesRuleResponse, error)
+
+	PutVirtualServicesNameRoutingRulesRuleWithResponse(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutVirtualServicesNameRoutingRulesRuleResponse, error)
+}
+
+type GetDestinationRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]DestinationRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDestinationRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDestinationRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostDestinationRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DestinationRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PostDestinationRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostDestinationRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostDestinationRulesNameSubsetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DestinationRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PostDestinationRulesNameSubsetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostDestinationRulesNameSubsetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteDestinationRulesNameSubsetsSubsetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DestinationRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteDestinationRulesNameSubsetsSubsetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteDestinationRulesNameSubsetsSubsetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPlanResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPlanResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPlanResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTopologyNamespaceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]TopologyNode
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTopologyNamespaceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTopologyNamespaceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteVirtualServicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VirtualService
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteVirtualServicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteVirtualServicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVirtualServicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]VirtualService
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVirtualServicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVirtualServicesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -351,55 +1335,460 @@ type PostVirtualServicesResponse struct {
 	JSONDefault  *NotOk
 }
 
-// Status returns HTTPResponse.Status
-func (r PostVirtualServicesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// Status returns HTTPResponse.Status
+func (r PostVirtualServicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostVirtualServicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVirtualServicesNameRoutingRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoutingRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVirtualServicesNameRoutingRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVirtualServicesNameRoutingRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostVirtualServicesNameRoutingRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoutingRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PostVirtualServicesNameRoutingRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostVirtualServicesNameRoutingRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteVirtualServicesNameRoutingRulesRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoutingRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteVirtualServicesNameRoutingRulesRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteVirtualServicesNameRoutingRulesRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutVirtualServicesNameRoutingRulesRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RoutingRule
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PutVirtualServicesNameRoutingRulesRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutVirtualServicesNameRoutingRulesRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetDestinationRulesWithResponse request returning *GetDestinationRulesResponse
+func (c *ClientWithResponses) GetDestinationRulesWithResponse(ctx context.Context, params *GetDestinationRulesParams, reqEditors ...RequestEditorFn) (*GetDestinationRulesResponse, error) {
+	rsp, err := c.GetDestinationRules(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDestinationRulesResponse(rsp)
+}
+
+// PostDestinationRulesWithBodyWithResponse request with arbitrary body returning *PostDestinationRulesResponse
+func (c *ClientWithResponses) PostDestinationRulesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDestinationRulesResponse, error) {
+	rsp, err := c.PostDestinationRulesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDestinationRulesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostDestinationRulesWithResponse(ctx context.Context, body PostDestinationRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDestinationRulesResponse, error) {
+	rsp, err := c.PostDestinationRules(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDestinationRulesResponse(rsp)
+}
+
+// PostDestinationRulesNameSubsetsWithBodyWithResponse request with arbitrary body returning *PostDestinationRulesNameSubsetsResponse
+func (c *ClientWithResponses) PostDestinationRulesNameSubsetsWithBodyWithResponse(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostDestinationRulesNameSubsetsResponse, error) {
+	rsp, err := c.PostDestinationRulesNameSubsetsWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDestinationRulesNameSubsetsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostDestinationRulesNameSubsetsWithResponse(ctx context.Context, name string, params *PostDestinationRulesNameSubsetsParams, body PostDestinationRulesNameSubsetsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostDestinationRulesNameSubsetsResponse, error) {
+	rsp, err := c.PostDestinationRulesNameSubsets(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDestinationRulesNameSubsetsResponse(rsp)
+}
+
+// DeleteDestinationRulesNameSubsetsSubsetWithResponse request returning *DeleteDestinationRulesNameSubsetsSubsetResponse
+func (c *ClientWithResponses) DeleteDestinationRulesNameSubsetsSubsetWithResponse(ctx context.Context, name string, subset string, params *DeleteDestinationRulesNameSubsetsSubsetParams, reqEditors ...RequestEditorFn) (*DeleteDestinationRulesNameSubsetsSubsetResponse, error) {
+	rsp, err := c.DeleteDestinationRulesNameSubsetsSubset(ctx, name, subset, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteDestinationRulesNameSubsetsSubsetResponse(rsp)
+}
+
+// GetPlanWithResponse request returning *GetPlanResponse
+func (c *ClientWithResponses) GetPlanWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPlanResponse, error) {
+	rsp, err := c.GetPlan(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPlanResponse(rsp)
+}
+
+// GetTopologyNamespaceWithResponse request returning *GetTopologyNamespaceResponse
+func (c *ClientWithResponses) GetTopologyNamespaceWithResponse(ctx context.Context, namespace string, reqEditors ...RequestEditorFn) (*GetTopologyNamespaceResponse, error) {
+	rsp, err := c.GetTopologyNamespace(ctx, namespace, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTopologyNamespaceResponse(rsp)
+}
+
+// DeleteVirtualServicesWithResponse request returning *DeleteVirtualServicesResponse
+func (c *ClientWithResponses) DeleteVirtualServicesWithResponse(ctx context.Context, params *DeleteVirtualServicesParams, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesResponse, error) {
+	rsp, err := c.DeleteVirtualServices(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteVirtualServicesResponse(rsp)
+}
+
+// GetVirtualServicesWithResponse request returning *GetVirtualServicesResponse
+func (c *ClientWithResponses) GetVirtualServicesWithResponse(ctx context.Context, params *GetVirtualServicesParams, reqEditors ...RequestEditorFn) (*GetVirtualServicesResponse, error) {
+	rsp, err := c.GetVirtualServices(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVirtualServicesResponse(rsp)
+}
+
+// PostVirtualServicesWithBodyWithResponse request with arbitrary body returning *PostVirtualServicesResponse
+func (c *ClientWithResponses) PostVirtualServicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error) {
+	rsp, err := c.PostVirtualServicesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostVirtualServicesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostVirtualServicesWithResponse(ctx context.Context, body PostVirtualServicesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error) {
+	rsp, err := c.PostVirtualServices(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostVirtualServicesResponse(rsp)
+}
+
+// GetVirtualServicesNameRoutingRulesWithResponse request returning *GetVirtualServicesNameRoutingRulesResponse
+func (c *ClientWithResponses) GetVirtualServicesNameRoutingRulesWithResponse(ctx context.Context, name string, params *GetVirtualServicesNameRoutingRulesParams, reqEditors ...RequestEditorFn) (*GetVirtualServicesNameRoutingRulesResponse, error) {
+	rsp, err := c.GetVirtualServicesNameRoutingRules(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVirtualServicesNameRoutingRulesResponse(rsp)
+}
+
+// PostVirtualServicesNameRoutingRulesWithBodyWithResponse request with arbitrary body returning *PostVirtualServicesNameRoutingRulesResponse
+func (c *ClientWithResponses) PostVirtualServicesNameRoutingRulesWithBodyWithResponse(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesNameRoutingRulesResponse, error) {
+	rsp, err := c.PostVirtualServicesNameRoutingRulesWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostVirtualServicesNameRoutingRulesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostVirtualServicesNameRoutingRulesWithResponse(ctx context.Context, name string, params *PostVirtualServicesNameRoutingRulesParams, body PostVirtualServicesNameRoutingRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesNameRoutingRulesResponse, error) {
+	rsp, err := c.PostVirtualServicesNameRoutingRules(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostVirtualServicesNameRoutingRulesResponse(rsp)
+}
+
+// DeleteVirtualServicesNameRoutingRulesRuleWithResponse request returning *DeleteVirtualServicesNameRoutingRulesRuleResponse
+func (c *ClientWithResponses) DeleteVirtualServicesNameRoutingRulesRuleWithResponse(ctx context.Context, name string, rule string, params *DeleteVirtualServicesNameRoutingRulesRuleParams, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesNameRoutingRulesRuleResponse, error) {
+	rsp, err := c.DeleteVirtualServicesNameRoutingRulesRule(ctx, name, rule, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteVirtualServicesNameRoutingRulesRuleResponse(rsp)
+}
+
+// PutVirtualServicesNameRoutingRulesRuleWithBodyWithResponse request with arbitrary body returning *PutVirtualServicesNameRoutingRulesRuleResponse
+func (c *ClientWithResponses) PutVirtualServicesNameRoutingRulesRuleWithBodyWithResponse(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutVirtualServicesNameRoutingRulesRuleResponse, error) {
+	rsp, err := c.PutVirtualServicesNameRoutingRulesRuleWithBody(ctx, name, rule, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutVirtualServicesNameRoutingRulesRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutVirtualServicesNameRoutingRulesRuleWithResponse(ctx context.Context, name string, rule string, params *PutVirtualServicesNameRoutingRulesRuleParams, body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutVirtualServicesNameRoutingRulesRuleResponse, error) {
+	rsp, err := c.PutVirtualServicesNameRoutingRulesRule(ctx, name, rule, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutVirtualServicesNameRoutingRulesRuleResponse(rsp)
+}
+
+// ParseGetDestinationRulesResponse parses an HTTP response from a GetDestinationRulesWithResponse call
+func ParseGetDestinationRulesResponse(rsp *http.Response) (*GetDestinationRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDestinationRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]DestinationRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return http.StatusText(0)
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostVirtualServicesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParsePostDestinationRulesResponse parses an HTTP response from a PostDestinationRulesWithResponse call
+func ParsePostDestinationRulesResponse(rsp *http.Response) (*PostDestinationRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &PostDestinationRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DestinationRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// DeleteVirtualServicesWithResponse request returning *DeleteVirtualServicesResponse
-func (c *ClientWithResponses) DeleteVirtualServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteVirtualServicesResponse, error) {
-	rsp, err := c.DeleteVirtualServices(ctx, reqEditors...)
+// ParsePostDestinationRulesNameSubsetsResponse parses an HTTP response from a PostDestinationRulesNameSubsetsWithResponse call
+func ParsePostDestinationRulesNameSubsetsResponse(rsp *http.Response) (*PostDestinationRulesNameSubsetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteVirtualServicesResponse(rsp)
+
+	response := &PostDestinationRulesNameSubsetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DestinationRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// GetVirtualServicesWithResponse request returning *GetVirtualServicesResponse
-func (c *ClientWithResponses) GetVirtualServicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVirtualServicesResponse, error) {
-	rsp, err := c.GetVirtualServices(ctx, reqEditors...)
+// ParseDeleteDestinationRulesNameSubsetsSubsetResponse parses an HTTP response from a DeleteDestinationRulesNameSubsetsSubsetWithResponse call
+func ParseDeleteDestinationRulesNameSubsetsSubsetResponse(rsp *http.Response) (*DeleteDestinationRulesNameSubsetsSubsetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetVirtualServicesResponse(rsp)
+
+	response := &DeleteDestinationRulesNameSubsetsSubsetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DestinationRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// PostVirtualServicesWithBodyWithResponse request with arbitrary body returning *PostVirtualServicesResponse
-func (c *ClientWithResponses) PostVirtualServicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error) {
-	rsp, err := c.PostVirtualServicesWithBody(ctx, contentType, body, reqEditors...)
+// ParseGetPlanResponse parses an HTTP response from a GetPlanWithResponse call
+func ParseGetPlanResponse(rsp *http.Response) (*GetPlanResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostVirtualServicesResponse(rsp)
+
+	response := &GetPlanResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) PostVirtualServicesWithResponse(ctx context.Context, body PostVirtualServicesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostVirtualServicesResponse, error) {
-	rsp, err := c.PostVirtualServices(ctx, body, reqEditors...)
+// ParseGetTopologyNamespaceResponse parses an HTTP response from a GetTopologyNamespaceWithResponse call
+func ParseGetTopologyNamespaceResponse(rsp *http.Response) (*GetTopologyNamespaceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostVirtualServicesResponse(rsp)
+
+	response := &GetTopologyNamespaceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]TopologyNode
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
 // ParseDeleteVirtualServicesResponse parses an HTTP response from a DeleteVirtualServicesWithResponse call
@@ -500,3 +1889,135 @@ func ParsePostVirtualServicesResponse(rsp *http.Response) (*PostVirtualServicesR
 
 	return response, nil
 }
+
+// ParseGetVirtualServicesNameRoutingRulesResponse parses an HTTP response from a GetVirtualServicesNameRoutingRulesWithResponse call
+func ParseGetVirtualServicesNameRoutingRulesResponse(rsp *http.Response) (*GetVirtualServicesNameRoutingRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVirtualServicesNameRoutingRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoutingRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostVirtualServicesNameRoutingRulesResponse parses an HTTP response from a PostVirtualServicesNameRoutingRulesWithResponse call
+func ParsePostVirtualServicesNameRoutingRulesResponse(rsp *http.Response) (*PostVirtualServicesNameRoutingRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostVirtualServicesNameRoutingRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoutingRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteVirtualServicesNameRoutingRulesRuleResponse parses an HTTP response from a DeleteVirtualServicesNameRoutingRulesRuleWithResponse call
+func ParseDeleteVirtualServicesNameRoutingRulesRuleResponse(rsp *http.Response) (*DeleteVirtualServicesNameRoutingRulesRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteVirtualServicesNameRoutingRulesRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoutingRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutVirtualServicesNameRoutingRulesRuleResponse parses an HTTP response from a PutVirtualServicesNameRoutingRulesRuleWithResponse call
+func ParsePutVirtualServicesNameRoutingRulesRuleResponse(rsp *http.Response) (*PutVirtualServicesNameRoutingRulesRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutVirtualServicesNameRoutingRulesRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RoutingRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
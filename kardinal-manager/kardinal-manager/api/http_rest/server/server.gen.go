@@ -17,21 +17,52 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 	. "kardinal.kontrol/kardinal-manager/api/http_rest/types"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List destination rules
+	// (GET /destination-rules)
+	GetDestinationRules(ctx echo.Context, params GetDestinationRulesParams) error
+	// Create destination rule
+	// (POST /destination-rules)
+	PostDestinationRules(ctx echo.Context) error
+	// Add or replace a subset of an existing destination rule
+	// (POST /destination-rules/{name}/subsets)
+	PostDestinationRulesNameSubsets(ctx echo.Context, name string, params PostDestinationRulesNameSubsetsParams) error
+	// Remove a subset of an existing destination rule
+	// (DELETE /destination-rules/{name}/subsets/{subset})
+	DeleteDestinationRulesNameSubsetsSubset(ctx echo.Context, name string, subset string, params DeleteDestinationRulesNameSubsetsSubsetParams) error
+	// Plan the cluster resources changes
+	// (GET /plan)
+	GetPlan(ctx echo.Context) error
+	// Get the topology of a namespace
+	// (GET /topology/{namespace})
+	GetTopologyNamespace(ctx echo.Context, namespace string) error
 	// Delete virtual service
 	// (DELETE /virtual-services)
-	DeleteVirtualServices(ctx echo.Context) error
+	DeleteVirtualServices(ctx echo.Context, params DeleteVirtualServicesParams) error
 	// List virtual services
 	// (GET /virtual-services)
-	GetVirtualServices(ctx echo.Context) error
-	// Create virtual service
+	GetVirtualServices(ctx echo.Context, params GetVirtualServicesParams) error
+	// Add the HTTP routes to an existing virtual service
 	// (POST /virtual-services)
 	PostVirtualServices(ctx echo.Context) error
+	// List the routing rules of a virtual service
+	// (GET /virtual-services/{name}/routing-rules)
+	GetVirtualServicesNameRoutingRules(ctx echo.Context, name string, params GetVirtualServicesNameRoutingRulesParams) error
+	// Add a routing rule to a virtual service
+	// (POST /virtual-services/{name}/routing-rules)
+	PostVirtualServicesNameRoutingRules(ctx echo.Context, name string, params PostVirtualServicesNameRoutingRulesParams) error
+	// Remove a routing rule from a virtual service
+	// (DELETE /virtual-services/{name}/routing-rules/{rule})
+	DeleteVirtualServicesNameRoutingRulesRule(ctx echo.Context, name string, rule string, params DeleteVirtualServicesNameRoutingRulesRuleParams) error
+	// Replace a routing rule of a virtual service
+	// (PUT /virtual-services/{name}/routing-rules/{rule})
+	PutVirtualServicesNameRoutingRulesRule(ctx echo.Context, name string, rule string, params PutVirtualServicesNameRoutingRulesRuleParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -39,12 +70,138 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetDestinationRules converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDestinationRules(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDestinationRulesParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDestinationRules(ctx, params)
+	return err
+}
+
+// PostDestinationRules converts echo context to params.
+func (w *ServerInterfaceWrapper) PostDestinationRules(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostDestinationRules(ctx)
+	return err
+}
+
+// PostDestinationRulesNameSubsets converts echo context to params.
+func (w *ServerInterfaceWrapper) PostDestinationRulesNameSubsets(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDestinationRulesNameSubsetsParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostDestinationRulesNameSubsets(ctx, name, params)
+	return err
+}
+
+// DeleteDestinationRulesNameSubsetsSubset converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteDestinationRulesNameSubsetsSubset(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "subset" -------------
+	var subset string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subset", ctx.Param("subset"), &subset, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subset: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDestinationRulesNameSubsetsSubsetParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteDestinationRulesNameSubsetsSubset(ctx, name, subset, params)
+	return err
+}
+
+// GetPlan converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPlan(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPlan(ctx)
+	return err
+}
+
+// GetTopologyNamespace converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTopologyNamespace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "namespace" -------------
+	var namespace string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "namespace", ctx.Param("namespace"), &namespace, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTopologyNamespace(ctx, namespace)
+	return err
+}
+
 // DeleteVirtualServices converts echo context to params.
 func (w *ServerInterfaceWrapper) DeleteVirtualServices(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteVirtualServicesParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// ------------- Required query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteVirtualServices(ctx)
+	err = w.Handler.DeleteVirtualServices(ctx, params)
 	return err
 }
 
@@ -52,8 +209,17 @@ func (w *ServerInterfaceWrapper) DeleteVirtualServices(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetVirtualServices(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVirtualServicesParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetVirtualServices(ctx)
+	err = w.Handler.GetVirtualServices(ctx, params)
 	return err
 }
 
@@ -61,126 +227,556 @@ func (w *ServerInterfaceWrapper) GetVirtualServices(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostVirtualServices(ctx echo.Context) error {
 	var err error
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostVirtualServices(ctx)
-	return err
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostVirtualServices(ctx)
+	return err
+}
+
+// GetVirtualServicesNameRoutingRules converts echo context to params.
+func (w *ServerInterfaceWrapper) GetVirtualServicesNameRoutingRules(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVirtualServicesNameRoutingRulesParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetVirtualServicesNameRoutingRules(ctx, name, params)
+	return err
+}
+
+// PostVirtualServicesNameRoutingRules converts echo context to params.
+func (w *ServerInterfaceWrapper) PostVirtualServicesNameRoutingRules(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostVirtualServicesNameRoutingRulesParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostVirtualServicesNameRoutingRules(ctx, name, params)
+	return err
+}
+
+// DeleteVirtualServicesNameRoutingRulesRule converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteVirtualServicesNameRoutingRulesRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "rule" -------------
+	var rule string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rule", ctx.Param("rule"), &rule, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rule: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteVirtualServicesNameRoutingRulesRuleParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteVirtualServicesNameRoutingRulesRule(ctx, name, rule, params)
+	return err
+}
+
+// PutVirtualServicesNameRoutingRulesRule converts echo context to params.
+func (w *ServerInterfaceWrapper) PutVirtualServicesNameRoutingRulesRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "rule" -------------
+	var rule string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rule", ctx.Param("rule"), &rule, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter rule: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutVirtualServicesNameRoutingRulesRuleParams
+	// ------------- Optional query parameter "namespace" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namespace", ctx.QueryParams(), &params.Namespace)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namespace: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutVirtualServicesNameRoutingRulesRule(ctx, name, rule, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/destination-rules", wrapper.GetDestinationRules)
+	router.POST(baseURL+"/destination-rules", wrapper.PostDestinationRules)
+	router.POST(baseURL+"/destination-rules/:name/subsets", wrapper.PostDestinationRulesNameSubsets)
+	router.DELETE(baseURL+"/destination-rules/:name/subsets/:subset", wrapper.DeleteDestinationRulesNameSubsetsSubset)
+	router.GET(baseURL+"/plan", wrapper.GetPlan)
+	router.GET(baseURL+"/topology/:namespace", wrapper.GetTopologyNamespace)
+	router.DELETE(baseURL+"/virtual-services", wrapper.DeleteVirtualServices)
+	router.GET(baseURL+"/virtual-services", wrapper.GetVirtualServices)
+	router.POST(baseURL+"/virtual-services", wrapper.PostVirtualServices)
+	router.GET(baseURL+"/virtual-services/:name/routing-rules", wrapper.GetVirtualServicesNameRoutingRules)
+	router.POST(baseURL+"/virtual-services/:name/routing-rules", wrapper.PostVirtualServicesNameRoutingRules)
+	router.DELETE(baseURL+"/virtual-services/:name/routing-rules/:rule", wrapper.DeleteVirtualServicesNameRoutingRulesRule)
+	router.PUT(baseURL+"/virtual-services/:name/routing-rules/:rule", wrapper.PutVirtualServicesNameRoutingRulesRule)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type GetDestinationRulesRequestObject struct {
+	Params GetDestinationRulesParams
+}
+
+type GetDestinationRulesResponseObject interface {
+	VisitGetDestinationRulesResponse(w http.ResponseWriter) error
+}
+
+type GetDestinationRules200JSONResponse map[string]DestinationRule
+
+func (response GetDestinationRules200JSONResponse) VisitGetDestinationRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDestinationRulesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetDestinationRulesdefaultJSONResponse) VisitGetDestinationRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostDestinationRulesRequestObject struct {
+	Body *PostDestinationRulesJSONRequestBody
+}
+
+type PostDestinationRulesResponseObject interface {
+	VisitPostDestinationRulesResponse(w http.ResponseWriter) error
+}
+
+type PostDestinationRules200JSONResponse DestinationRule
+
+func (response PostDestinationRules200JSONResponse) VisitPostDestinationRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDestinationRulesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostDestinationRulesdefaultJSONResponse) VisitPostDestinationRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostDestinationRulesNameSubsetsRequestObject struct {
+	Name   string `json:"name"`
+	Params PostDestinationRulesNameSubsetsParams
+	Body   *PostDestinationRulesNameSubsetsJSONRequestBody
+}
+
+type PostDestinationRulesNameSubsetsResponseObject interface {
+	VisitPostDestinationRulesNameSubsetsResponse(w http.ResponseWriter) error
+}
+
+type PostDestinationRulesNameSubsets200JSONResponse DestinationRule
+
+func (response PostDestinationRulesNameSubsets200JSONResponse) VisitPostDestinationRulesNameSubsetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDestinationRulesNameSubsetsdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostDestinationRulesNameSubsetsdefaultJSONResponse) VisitPostDestinationRulesNameSubsetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteDestinationRulesNameSubsetsSubsetRequestObject struct {
+	Name   string `json:"name"`
+	Subset string `json:"subset"`
+	Params DeleteDestinationRulesNameSubsetsSubsetParams
+}
+
+type DeleteDestinationRulesNameSubsetsSubsetResponseObject interface {
+	VisitDeleteDestinationRulesNameSubsetsSubsetResponse(w http.ResponseWriter) error
+}
+
+type DeleteDestinationRulesNameSubsetsSubset200JSONResponse DestinationRule
+
+func (response DeleteDestinationRulesNameSubsetsSubset200JSONResponse) VisitDeleteDestinationRulesNameSubsetsSubsetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDestinationRulesNameSubsetsSubsetdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response DeleteDestinationRulesNameSubsetsSubsetdefaultJSONResponse) VisitDeleteDestinationRulesNameSubsetsSubsetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPlanRequestObject struct {
+}
+
+type GetPlanResponseObject interface {
+	VisitGetPlanResponse(w http.ResponseWriter) error
+}
+
+type GetPlan200TextResponse string
+
+func (response GetPlan200TextResponse) VisitGetPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type GetPlandefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetPlandefaultJSONResponse) VisitGetPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTopologyNamespaceRequestObject struct {
+	Namespace string `json:"namespace"`
+}
+
+type GetTopologyNamespaceResponseObject interface {
+	VisitGetTopologyNamespaceResponse(w http.ResponseWriter) error
+}
+
+type GetTopologyNamespace200JSONResponse map[string]TopologyNode
+
+func (response GetTopologyNamespace200JSONResponse) VisitGetTopologyNamespaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTopologyNamespacedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetTopologyNamespacedefaultJSONResponse) VisitGetTopologyNamespaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteVirtualServicesRequestObject struct {
+	Params DeleteVirtualServicesParams
+}
+
+type DeleteVirtualServicesResponseObject interface {
+	VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error
+}
+
+type DeleteVirtualServices200JSONResponse VirtualService
+
+func (response DeleteVirtualServices200JSONResponse) VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteVirtualServicesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response DeleteVirtualServicesdefaultJSONResponse) VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetVirtualServicesRequestObject struct {
+	Params GetVirtualServicesParams
+}
+
+type GetVirtualServicesResponseObject interface {
+	VisitGetVirtualServicesResponse(w http.ResponseWriter) error
+}
+
+type GetVirtualServices200JSONResponse map[string]VirtualService
+
+func (response GetVirtualServices200JSONResponse) VisitGetVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVirtualServicesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetVirtualServicesdefaultJSONResponse) VisitGetVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostVirtualServicesRequestObject struct {
+	Body *PostVirtualServicesJSONRequestBody
+}
+
+type PostVirtualServicesResponseObject interface {
+	VisitPostVirtualServicesResponse(w http.ResponseWriter) error
+}
+
+type PostVirtualServices200JSONResponse VirtualService
+
+func (response PostVirtualServices200JSONResponse) VisitPostVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostVirtualServicesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostVirtualServicesdefaultJSONResponse) VisitPostVirtualServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+type GetVirtualServicesNameRoutingRulesRequestObject struct {
+	Name   string `json:"name"`
+	Params GetVirtualServicesNameRoutingRulesParams
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+type GetVirtualServicesNameRoutingRulesResponseObject interface {
+	VisitGetVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+type GetVirtualServicesNameRoutingRules200JSONResponse []RoutingRule
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+func (response GetVirtualServicesNameRoutingRules200JSONResponse) VisitGetVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	router.DELETE(baseURL+"/virtual-services", wrapper.DeleteVirtualServices)
-	router.GET(baseURL+"/virtual-services", wrapper.GetVirtualServices)
-	router.POST(baseURL+"/virtual-services", wrapper.PostVirtualServices)
+	return json.NewEncoder(w).Encode(response)
+}
 
+type GetVirtualServicesNameRoutingRulesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
 }
 
-type NotOkJSONResponse ResponseInfo
+func (response GetVirtualServicesNameRoutingRulesdefaultJSONResponse) VisitGetVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
 
-type DeleteVirtualServicesRequestObject struct {
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type DeleteVirtualServicesResponseObject interface {
-	VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error
+type PostVirtualServicesNameRoutingRulesRequestObject struct {
+	Name   string `json:"name"`
+	Params PostVirtualServicesNameRoutingRulesParams
+	Body   *PostVirtualServicesNameRoutingRulesJSONRequestBody
 }
 
-type DeleteVirtualServices200JSONResponse VirtualService
+type PostVirtualServicesNameRoutingRulesResponseObject interface {
+	VisitPostVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error
+}
 
-func (response DeleteVirtualServices200JSONResponse) VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error {
+type PostVirtualServicesNameRoutingRules200JSONResponse []RoutingRule
+
+func (response PostVirtualServicesNameRoutingRules200JSONResponse) VisitPostVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteVirtualServicesdefaultJSONResponse struct {
+type PostVirtualServicesNameRoutingRulesdefaultJSONResponse struct {
 	Body       ResponseInfo
 	StatusCode int
 }
 
-func (response DeleteVirtualServicesdefaultJSONResponse) VisitDeleteVirtualServicesResponse(w http.ResponseWriter) error {
+func (response PostVirtualServicesNameRoutingRulesdefaultJSONResponse) VisitPostVirtualServicesNameRoutingRulesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetVirtualServicesRequestObject struct {
+type DeleteVirtualServicesNameRoutingRulesRuleRequestObject struct {
+	Name   string `json:"name"`
+	Rule   string `json:"rule"`
+	Params DeleteVirtualServicesNameRoutingRulesRuleParams
 }
 
-type GetVirtualServicesResponseObject interface {
-	VisitGetVirtualServicesResponse(w http.ResponseWriter) error
+type DeleteVirtualServicesNameRoutingRulesRuleResponseObject interface {
+	VisitDeleteVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error
 }
 
-type GetVirtualServices200JSONResponse map[string]VirtualService
+type DeleteVirtualServicesNameRoutingRulesRule200JSONResponse []RoutingRule
 
-func (response GetVirtualServices200JSONResponse) VisitGetVirtualServicesResponse(w http.ResponseWriter) error {
+func (response DeleteVirtualServicesNameRoutingRulesRule200JSONResponse) VisitDeleteVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetVirtualServicesdefaultJSONResponse struct {
+type DeleteVirtualServicesNameRoutingRulesRuledefaultJSONResponse struct {
 	Body       ResponseInfo
 	StatusCode int
 }
 
-func (response GetVirtualServicesdefaultJSONResponse) VisitGetVirtualServicesResponse(w http.ResponseWriter) error {
+func (response DeleteVirtualServicesNameRoutingRulesRuledefaultJSONResponse) VisitDeleteVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type PostVirtualServicesRequestObject struct {
-	Body *PostVirtualServicesJSONRequestBody
+type PutVirtualServicesNameRoutingRulesRuleRequestObject struct {
+	Name   string `json:"name"`
+	Rule   string `json:"rule"`
+	Params PutVirtualServicesNameRoutingRulesRuleParams
+	Body   *PutVirtualServicesNameRoutingRulesRuleJSONRequestBody
 }
 
-type PostVirtualServicesResponseObject interface {
-	VisitPostVirtualServicesResponse(w http.ResponseWriter) error
+type PutVirtualServicesNameRoutingRulesRuleResponseObject interface {
+	VisitPutVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error
 }
 
-type PostVirtualServices200JSONResponse VirtualService
+type PutVirtualServicesNameRoutingRulesRule200JSONResponse []RoutingRule
 
-func (response PostVirtualServices200JSONResponse) VisitPostVirtualServicesResponse(w http.ResponseWriter) error {
+func (response PutVirtualServicesNameRoutingRulesRule200JSONResponse) VisitPutVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostVirtualServicesdefaultJSONResponse struct {
+type PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse struct {
 	Body       ResponseInfo
 	StatusCode int
 }
 
-func (response PostVirtualServicesdefaultJSONResponse) VisitPostVirtualServicesResponse(w http.ResponseWriter) error {
+func (response PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse) VisitPutVirtualServicesNameRoutingRulesRuleResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
@@ -189,15 +785,45 @@ func (response PostVirtualServicesdefaultJSONResponse) VisitPostVirtualServicesR
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List destination rules
+	// (GET /destination-rules)
+	GetDestinationRules(ctx context.Context, request GetDestinationRulesRequestObject) (GetDestinationRulesResponseObject, error)
+	// Create destination rule
+	// (POST /destination-rules)
+	PostDestinationRules(ctx context.Context, request PostDestinationRulesRequestObject) (PostDestinationRulesResponseObject, error)
+	// Add or replace a subset of an existing destination rule
+	// (POST /destination-rules/{name}/subsets)
+	PostDestinationRulesNameSubsets(ctx context.Context, request PostDestinationRulesNameSubsetsRequestObject) (PostDestinationRulesNameSubsetsResponseObject, error)
+	// Remove a subset of an existing destination rule
+	// (DELETE /destination-rules/{name}/subsets/{subset})
+	DeleteDestinationRulesNameSubsetsSubset(ctx context.Context, request DeleteDestinationRulesNameSubsetsSubsetRequestObject) (DeleteDestinationRulesNameSubsetsSubsetResponseObject, error)
+	// Plan the cluster resources changes
+	// (GET /plan)
+	GetPlan(ctx context.Context, request GetPlanRequestObject) (GetPlanResponseObject, error)
+	// Get the topology of a namespace
+	// (GET /topology/{namespace})
+	GetTopologyNamespace(ctx context.Context, request GetTopologyNamespaceRequestObject) (GetTopologyNamespaceResponseObject, error)
 	// Delete virtual service
 	// (DELETE /virtual-services)
 	DeleteVirtualServices(ctx context.Context, request DeleteVirtualServicesRequestObject) (DeleteVirtualServicesResponseObject, error)
 	// List virtual services
 	// (GET /virtual-services)
 	GetVirtualServices(ctx context.Context, request GetVirtualServicesRequestObject) (GetVirtualServicesResponseObject, error)
-	// Create virtual service
+	// Add the HTTP routes to an existing virtual service
 	// (POST /virtual-services)
 	PostVirtualServices(ctx context.Context, request PostVirtualServicesRequestObject) (PostVirtualServicesResponseObject, error)
+	// List the routing rules of a virtual service
+	// (GET /virtual-services/{name}/routing-rules)
+	GetVirtualServicesNameRoutingRules(ctx context.Context, request GetVirtualServicesNameRoutingRulesRequestObject) (GetVirtualServicesNameRoutingRulesResponseObject, error)
+	// Add a routing rule to a virtual service
+	// (POST /virtual-services/{name}/routing-rules)
+	PostVirtualServicesNameRoutingRules(ctx context.Context, request PostVirtualServicesNameRoutingRulesRequestObject) (PostVirtualServicesNameRoutingRulesResponseObject, error)
+	// Remove a routing rule from a virtual service
+	// (DELETE /virtual-services/{name}/routing-rules/{rule})
+	DeleteVirtualServicesNameRoutingRulesRule(ctx context.Context, request DeleteVirtualServicesNameRoutingRulesRuleRequestObject) (DeleteVirtualServicesNameRoutingRulesRuleResponseObject, error)
+	// Replace a routing rule of a virtual service
+	// (PUT /virtual-services/{name}/routing-rules/{rule})
+	PutVirtualServicesNameRoutingRulesRule(ctx context.Context, request PutVirtualServicesNameRoutingRulesRuleRequestObject) (PutVirtualServicesNameRoutingRulesRuleResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -212,10 +838,173 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// GetDestinationRules operation middleware
+func (sh *strictHandler) GetDestinationRules(ctx echo.Context, params GetDestinationRulesParams) error {
+	var request GetDestinationRulesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDestinationRules(ctx.Request().Context(), request.(GetDestinationRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDestinationRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetDestinationRulesResponseObject); ok {
+		return validResponse.VisitGetDestinationRulesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostDestinationRules operation middleware
+func (sh *strictHandler) PostDestinationRules(ctx echo.Context) error {
+	var request PostDestinationRulesRequestObject
+
+	var body PostDestinationRulesJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDestinationRules(ctx.Request().Context(), request.(PostDestinationRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDestinationRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostDestinationRulesResponseObject); ok {
+		return validResponse.VisitPostDestinationRulesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostDestinationRulesNameSubsets operation middleware
+func (sh *strictHandler) PostDestinationRulesNameSubsets(ctx echo.Context, name string, params PostDestinationRulesNameSubsetsParams) error {
+	var request PostDestinationRulesNameSubsetsRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	var body PostDestinationRulesNameSubsetsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDestinationRulesNameSubsets(ctx.Request().Context(), request.(PostDestinationRulesNameSubsetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDestinationRulesNameSubsets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostDestinationRulesNameSubsetsResponseObject); ok {
+		return validResponse.VisitPostDestinationRulesNameSubsetsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteDestinationRulesNameSubsetsSubset operation middleware
+func (sh *strictHandler) DeleteDestinationRulesNameSubsetsSubset(ctx echo.Context, name string, subset string, params DeleteDestinationRulesNameSubsetsSubsetParams) error {
+	var request DeleteDestinationRulesNameSubsetsSubsetRequestObject
+
+	request.Name = name
+	request.Subset = subset
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDestinationRulesNameSubsetsSubset(ctx.Request().Context(), request.(DeleteDestinationRulesNameSubsetsSubsetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDestinationRulesNameSubsetsSubset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteDestinationRulesNameSubsetsSubsetResponseObject); ok {
+		return validResponse.VisitDeleteDestinationRulesNameSubsetsSubsetResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetPlan operation middleware
+func (sh *strictHandler) GetPlan(ctx echo.Context) error {
+	var request GetPlanRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPlan(ctx.Request().Context(), request.(GetPlanRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPlan")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetPlanResponseObject); ok {
+		return validResponse.VisitGetPlanResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTopologyNamespace operation middleware
+func (sh *strictHandler) GetTopologyNamespace(ctx echo.Context, namespace string) error {
+	var request GetTopologyNamespaceRequestObject
+
+	request.Namespace = namespace
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTopologyNamespace(ctx.Request().Context(), request.(GetTopologyNamespaceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTopologyNamespace")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTopologyNamespaceResponseObject); ok {
+		return validResponse.VisitGetTopologyNamespaceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeleteVirtualServices operation middleware
-func (sh *strictHandler) DeleteVirtualServices(ctx echo.Context) error {
+func (sh *strictHandler) DeleteVirtualServices(ctx echo.Context, params DeleteVirtualServicesParams) error {
 	var request DeleteVirtualServicesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteVirtualServices(ctx.Request().Context(), request.(DeleteVirtualServicesRequestObject))
 	}
@@ -236,9 +1025,11 @@ func (sh *strictHandler) DeleteVirtualServices(ctx echo.Context) error {
 }
 
 // GetVirtualServices operation middleware
-func (sh *strictHandler) GetVirtualServices(ctx echo.Context) error {
+func (sh *strictHandler) GetVirtualServices(ctx echo.Context, params GetVirtualServicesParams) error {
 	var request GetVirtualServicesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetVirtualServices(ctx.Request().Context(), request.(GetVirtualServicesRequestObject))
 	}
@@ -287,20 +1078,162 @@ func (sh *strictHandler) PostVirtualServices(ctx echo.Context) error {
 	return nil
 }
 
+// GetVirtualServicesNameRoutingRules operation middleware
+func (sh *strictHandler) GetVirtualServicesNameRoutingRules(ctx echo.Context, name string, params GetVirtualServicesNameRoutingRulesParams) error {
+	var request GetVirtualServicesNameRoutingRulesRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVirtualServicesNameRoutingRules(ctx.Request().Context(), request.(GetVirtualServicesNameRoutingRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVirtualServicesNameRoutingRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetVirtualServicesNameRoutingRulesResponseObject); ok {
+		return validResponse.VisitGetVirtualServicesNameRoutingRulesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostVirtualServicesNameRoutingRules operation middleware
+func (sh *strictHandler) PostVirtualServicesNameRoutingRules(ctx echo.Context, name string, params PostVirtualServicesNameRoutingRulesParams) error {
+	var request PostVirtualServicesNameRoutingRulesRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	var body PostVirtualServicesNameRoutingRulesJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostVirtualServicesNameRoutingRules(ctx.Request().Context(), request.(PostVirtualServicesNameRoutingRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostVirtualServicesNameRoutingRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostVirtualServicesNameRoutingRulesResponseObject); ok {
+		return validResponse.VisitPostVirtualServicesNameRoutingRulesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteVirtualServicesNameRoutingRulesRule operation middleware
+func (sh *strictHandler) DeleteVirtualServicesNameRoutingRulesRule(ctx echo.Context, name string, rule string, params DeleteVirtualServicesNameRoutingRulesRuleParams) error {
+	var request DeleteVirtualServicesNameRoutingRulesRuleRequestObject
+
+	request.Name = name
+	request.Rule = rule
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteVirtualServicesNameRoutingRulesRule(ctx.Request().Context(), request.(DeleteVirtualServicesNameRoutingRulesRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteVirtualServicesNameRoutingRulesRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteVirtualServicesNameRoutingRulesRuleResponseObject); ok {
+		return validResponse.VisitDeleteVirtualServicesNameRoutingRulesRuleResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutVirtualServicesNameRoutingRulesRule operation middleware
+func (sh *strictHandler) PutVirtualServicesNameRoutingRulesRule(ctx echo.Context, name string, rule string, params PutVirtualServicesNameRoutingRulesRuleParams) error {
+	var request PutVirtualServicesNameRoutingRulesRuleRequestObject
+
+	request.Name = name
+	request.Rule = rule
+	request.Params = params
+
+	var body PutVirtualServicesNameRoutingRulesRuleJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutVirtualServicesNameRoutingRulesRule(ctx.Request().Context(), request.(PutVirtualServicesNameRoutingRulesRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutVirtualServicesNameRoutingRulesRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutVirtualServicesNameRoutingRulesRuleResponseObject); ok {
+		return validResponse.VisitPutVirtualServicesNameRoutingRulesRuleResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9yUz07bQBDGX2U1rcTFiVO4+UYLRRFtQAmlh4rD1h47C/buMjMORZHfvVrbJORP1SLB",
-	"padsdsfffvPbmVlC6irvLFphSJZAyN5ZxvbPxMnFXVikzgpaCUvtfWlSLcbZ+JadDXuczrHSYfWeMIcE",
-	"3sVr1bg75XjaS49t7qBpmggy5JSMD1qQwDeLvzymgplCIkcQQvqPg/bG98kSPDmPJKbzmroMw2/uqNIC",
-	"CdTGytEhRCCPHiEBYwULJGgiqJBZF214f8hCxhbhrNv4t0yuQmxwSXhfG8IMkh+dwPqOqHN2s/Lhft5i",
-	"KuGqDZlkCWjrKiicTqcXU4hgPPl8ARF8P55OxpOzZxJrt9eGpNblDGlhUtylYnW1L80ty23UrsMQZnrY",
-	"m081PZ1d5XWpji/Hij2mJu9LQuWOlMxRnWvKjNWl+qqtLpDUs7qJlJEDVjVjpsQpXYsbFGiRtKBKS4NW",
-	"1Ozk/ICVtplipAXSgE2GqkUZgRgpg9GdS575gggWSNz5HQ0/DEeBl/NotTeQwNFwNDyCCLyWeYsqXnQs",
-	"B9zB5C7tEqUlGKi27scZJHDS7m/SZ4g22+dwNHq15tl66D3tM6vTFJlD+k8uoA3KdV3Kn/RXhuOu2due",
-	"q6tK0+MqTdWTUT2Z8AK64FA5O8xumggKlF1iZyivjEtnmQlHurzcqPmXYNxb82/P9Yth2abKf8PqHe/h",
-	"eul4L9j7Glk+uuzxTUtwPUWEamz+uwb4RKhf2gCtQju0QsT26FzNrKqfWV2oMnY9M09OryGCmkpIYC7i",
-	"OYnjh4eH4V0fMMxwET/9GfRCcZhrzU3zOwAA//+rRL0K0gcAAA==",
+	"H4sIAAAAAAAC/+xaX1PjOBL/Kl26q+LFkOzOvVzeuGNujppbhgrc3sMWtSWsdqxFljz6Q0hR+e5XkuzE",
+	"jpWQLDA7s8wTIZZbre5f//qP8khyVdVKorSGTB6JRlMraTD8c6Hspzv/IVfSorT+I61rwXNquZKj34yS",
+	"/juTl1hR/+mvGgsyIX8ZraWO4lMzmjaiz2WhyHK5zAhDk2tee1lkQv4r8aHG3CID1Fpp4pc0L3vZZ2gs",
+	"l2HnqRPov6q1qlFbHtUtlQkq2kWNZEKM1VzOyDIjkla49YGpaZ5+atytwWgWbrEyTx3wKqz3bzaiqNZ0",
+	"EY6h8bPjGhmZ/BK1yaK2N6u16vY3zMPL/76+vvyJ2ryc4meHxiYOipShDh8pY9xbhIrL3pKdeoYThi3I",
+	"MqGA0/wgEcsth5gqZxNuqsJb+xp1YI2BeXf4V7ca7L1V0LmDtCe9Gbe42WWCrrj9QRvRl3w0Rz4rw6NC",
+	"6YpaMiFc2nc/kpUWXFqcoR5ouxV0veAcKJkrhr3t3Lb9MlKhMXSW9kf8Yj+auPZrN/UPAtZ7ZFGzXQe6",
+	"brZE6Sov4f10+mlKMnJ+8a9PJCP/O51enF986IhYa+udx+UsTTa15kpzu/Cf+zx2XSJoJ9DAnNsSKJR8",
+	"VqKG9g2gGgHvqXDUc13BtbEZjGFeogRujwxIZcGgJdnT/u1gfC9oH4DfbowPDvlJigUoiaAKsCVCwVEw",
+	"A6ZUTjC4xUb/vs3wgeZpRNcaC/6QfKRxhqknKda5WsVMf2NBb1Hs5MstUO3I3kIyKXJPWfNa1Uqo2eKi",
+	"CaW+gpylOQD1Pc/x160E1y64R20aehmehIo786tVPR7cdt40yXFGNpQZbt3ZKHX+n7m2joqr+FaaB80h",
+	"KmaktLY+nNwPSiC7CoT9PO+X8YZU+yE0fX91XTgBp5fnYGrMedHUVVAoHYLqI9WMSyrgJyrpDDV0iq8s",
+	"UoUzyMAqoM6q4xlK1NQi5IKjtHB19vHIAJUMvLNQHxvOEAJlZsRy63mNDDbp6EUyskIWGZ/8cDL2RlE1",
+	"SlpzMiHvTsYn73yYU1sGD4zYOtcdBxL0385iTHp3hyfnjEzIB7QbFZ0JkjSt0Ibq5pcUs6480jJPNLTJ",
+	"4IhhQZ2wR0km5V7AZ4d6QVp/d7ybdWrYTTffZP2q+Mfx+KCa+PeUaBuWIcskrvrWuXJ5jsZ417X6ZsFC",
+	"HZ80iYnL8GBty9tF+IcEocGK2zRcmWIUu4NlKFWqiuoFmZD/cGOH+wVumHmPkiE+bjz9N0XQ0N1D3Wt3",
+	"K7gpkXmlPypptRIhpap71HPNrUUJqjkgPlgwC5lnIQr8V0qigVxjyL0lagzvCiwsUKEkkmwDp5fKpICq",
+	"Yzn6D8UWL9YhDbze5xirHS6fCcaDt98TY61FN/31bET9Mwgeyt0NqWWWoKLRo8f4ctTp69LAO4W4JBZw",
+	"/niGVjFcgBvQWAuaI9sLKhe0wqtmwwG9BVLy5NnnJLLp9V30lH1FLPnyIdH21N9OJLiavU4knDIGSrfo",
+	"A9qCVBVAJeAD9/vNXiNSRo/xwzKGisDYcAxhdx+LPGhqQwjNhQGDknnVrKZFwXNfr4Sgivp7/q21k+te",
+	"qOXq2OeaFsMdNU1k7JDHlFlt5EWZnAqfHGh+B672e/0wHg9C9SycYkewxj+vFrIJMabd8duM/bcUilOs",
+	"1P2LhmAtqOyUykOv5iWVMzThXFVTqc9Ds13ROwydwSLEmF8rnLGovTWU0zkayJ3WKK1YJMunDPzJnTdV",
+	"M7Lo9gtBNDC9ONZODgLpA9pLr/uTELD4YP0x+YbzN8G0n3sp3LlbzK04NnYhEBgvCu8GvEe9aBDvYz/W",
+	"JVkDBc+fkb+gUEKoeTQDhda1zwWGN8U2F0QHdrAQfB7db5vpwOhxFXHLnXBoGNasuLLp0wz4LjwAQQHS",
+	"vARlS9RtxS+osVBx6bxNqAGDKAMQOBU85dvV2KJDBPsxYrt6fzb7Ixqt3ljmWV1W60KQiqHxRj0/ezae",
+	"PqDtC/dMA13ztmBqVzSAajLxcYuTAzP3jjarrfTpjPJ+o1U4IUK3tSXb9kdAX2m3nz1uffmLgnkXaPuG",
+	"PHAW4D3BNv39bKBGDw/ErvE5AKTv+7cNhr4JpPwRdLXp+ecQ1iDkX3cqtLndU9jYPhPqFPuUMU9FJnzn",
+	"s17n4mV13zLO2qPN+D1KUJqhzsCELmQBEr2IcNIT6JZaIVk6ablYEWDLisa/gPM267aYG8J/OCAYYvs1",
+	"+uYURXy5/vkZBNXW7C9NUL579vL9PUCLIKt6ZftB5JVKsm3b3IBxMABPXKHZBs9r7EZI21IrNyt75X4Y",
+	"VHJjkUVTxcquEw3rS0VaWAx3CFWqqNuAoK/tOnedb2BO9QzY73XZ1LFm4m5t/2DoIyNNMi23BVaLjNbD",
+	"wsuwd1Ibemi+38HpToTZagLCT1ymS3/dXCiN6/F+f2yrNFDwvd76rRM4ZaztlsOisH8saZnCALWSy1mc",
+	"RdG4mIYuEzVKG9ev9gmT4Y0hgJIIBeVipfnfxn/fJyG8vWh8+ezXi7+XT31vjwN89qQ9VULyfKWUOXr0",
+	"f3ZOm8MELgZl1KakFriRR4GqNPaieL9+dDPwpnFy98Xmv82k8HtG/dNH02p83AuoQqvqd6RUl2ihL539",
+	"Du7vCepNhVR7K9qLqcOL1GXzq7r0pGn1Y6m2J4tL/fFWj87e/0wy4rQgk/ATNTMZjebz+clds+CE4f2o",
+	"/ee4ETSiNSfLm+X/AQAA//8DAE4DVRKQLwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -10,6 +10,36 @@ const (
 	WARNING ResponseType = "WARNING"
 )
 
+// DestinationRule defines model for DestinationRule.
+type DestinationRule struct {
+	Host      string    `json:"host"`
+	Name      string    `json:"name"`
+	Namespace *string   `json:"namespace,omitempty"`
+	Subsets   *[]Subset `json:"subsets,omitempty"`
+}
+
+// HTTPMatchRequest defines model for HTTPMatchRequest.
+type HTTPMatchRequest struct {
+	Headers *map[string]StringMatch `json:"headers,omitempty"`
+
+	// Uri Only one of the fields should be set
+	Uri *StringMatch `json:"uri,omitempty"`
+}
+
+// HTTPRoute defines model for HTTPRoute.
+type HTTPRoute struct {
+	Match *[]HTTPMatchRequest    `json:"match,omitempty"`
+	Name  *string                `json:"name,omitempty"`
+	Route []HTTPRouteDestination `json:"route"`
+}
+
+// HTTPRouteDestination defines model for HTTPRouteDestination.
+type HTTPRouteDestination struct {
+	Host   string  `json:"host"`
+	Subset *string `json:"subset,omitempty"`
+	Weight *int32  `json:"weight,omitempty"`
+}
+
 // ResponseInfo defines model for ResponseInfo.
 type ResponseInfo struct {
 	Code    uint32       `json:"code"`
@@ -20,13 +50,111 @@ type ResponseInfo struct {
 // ResponseType defines model for ResponseType.
 type ResponseType string
 
+// RoutingRule defines model for RoutingRule.
+type RoutingRule struct {
+	// Priority The rules with a higher priority are evaluated first, 0 when it's not set
+	Priority *int32    `json:"priority,omitempty"`
+	Route    HTTPRoute `json:"route"`
+}
+
+// StringMatch Only one of the fields should be set
+type StringMatch struct {
+	Exact  *string `json:"exact,omitempty"`
+	Prefix *string `json:"prefix,omitempty"`
+	Regex  *string `json:"regex,omitempty"`
+}
+
+// Subset defines model for Subset.
+type Subset struct {
+	Labels *map[string]string `json:"labels,omitempty"`
+	Name   string             `json:"name"`
+}
+
+// TopologyNode defines model for TopologyNode.
+type TopologyNode struct {
+	Id             string   `json:"id"`
+	ServiceName    string   `json:"service_name"`
+	ServiceVersion string   `json:"service_version"`
+	TalksTo        []string `json:"talks_to"`
+}
+
 // VirtualService defines model for VirtualService.
 type VirtualService struct {
-	Name string `json:"name"`
+	Hosts     *[]string    `json:"hosts,omitempty"`
+	Http      *[]HTTPRoute `json:"http,omitempty"`
+	Name      string       `json:"name"`
+	Namespace *string      `json:"namespace,omitempty"`
 }
 
 // NotOk defines model for NotOk.
 type NotOk = ResponseInfo
 
+// GetDestinationRulesParams defines parameters for GetDestinationRules.
+type GetDestinationRulesParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// PostDestinationRulesNameSubsetsParams defines parameters for PostDestinationRulesNameSubsets.
+type PostDestinationRulesNameSubsetsParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// DeleteDestinationRulesNameSubsetsSubsetParams defines parameters for DeleteDestinationRulesNameSubsetsSubset.
+type DeleteDestinationRulesNameSubsetsSubsetParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// DeleteVirtualServicesParams defines parameters for DeleteVirtualServices.
+type DeleteVirtualServicesParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+	Name      string  `form:"name" json:"name"`
+}
+
+// GetVirtualServicesParams defines parameters for GetVirtualServices.
+type GetVirtualServicesParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// GetVirtualServicesNameRoutingRulesParams defines parameters for GetVirtualServicesNameRoutingRules.
+type GetVirtualServicesNameRoutingRulesParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// PostVirtualServicesNameRoutingRulesParams defines parameters for PostVirtualServicesNameRoutingRules.
+type PostVirtualServicesNameRoutingRulesParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// DeleteVirtualServicesNameRoutingRulesRuleParams defines parameters for DeleteVirtualServicesNameRoutingRulesRule.
+type DeleteVirtualServicesNameRoutingRulesRuleParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// PutVirtualServicesNameRoutingRulesRuleParams defines parameters for PutVirtualServicesNameRoutingRulesRule.
+type PutVirtualServicesNameRoutingRulesRuleParams struct {
+	// Namespace The namespace of the objects, 'default' when it's not set
+	Namespace *string `form:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// PostDestinationRulesJSONRequestBody defines body for PostDestinationRules for application/json ContentType.
+type PostDestinationRulesJSONRequestBody = DestinationRule
+
+// PostDestinationRulesNameSubsetsJSONRequestBody defines body for PostDestinationRulesNameSubsets for application/json ContentType.
+type PostDestinationRulesNameSubsetsJSONRequestBody = Subset
+
 // PostVirtualServicesJSONRequestBody defines body for PostVirtualServices for application/json ContentType.
 type PostVirtualServicesJSONRequestBody = VirtualService
+
+// PostVirtualServicesNameRoutingRulesJSONRequestBody defines body for PostVirtualServicesNameRoutingRules for application/json ContentType.
+type PostVirtualServicesNameRoutingRulesJSONRequestBody = RoutingRule
+
+// PutVirtualServicesNameRoutingRulesRuleJSONRequestBody defines body for PutVirtualServicesNameRoutingRulesRule for application/json ContentType.
+type PutVirtualServicesNameRoutingRulesRuleJSONRequestBody = RoutingRule
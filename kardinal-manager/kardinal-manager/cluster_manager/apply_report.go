@@ -0,0 +1,77 @@
+package cluster_manager
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+type ResourceApplyStatus string
+
+const (
+	AppliedResourceApplyStatus   ResourceApplyStatus = "applied"
+	UnchangedResourceApplyStatus ResourceApplyStatus = "unchanged"
+	FailedResourceApplyStatus    ResourceApplyStatus = "failed"
+	// NotReadyResourceApplyStatus is for the workloads applied which didn't finish their rollout, or whose new pods
+	// didn't become ready endpoints of their Services, within the readiness timeout
+	NotReadyResourceApplyStatus ResourceApplyStatus = "not-ready"
+	// HeldBackResourceApplyStatus is for the VirtualServices not applied, or applied without some of their routes,
+	// because they route to not ready Services or subsets
+	HeldBackResourceApplyStatus ResourceApplyStatus = "held-back"
+)
+
+type ResourceApplyResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Status    ResourceApplyStatus
+	// Message explains the failed, not ready and held back statuses
+	Message string
+}
+
+// ApplyReport holds the result of every object ApplyClusterResources went through, in the order they were applied
+type ApplyReport struct {
+	Results []*ResourceApplyResult
+}
+
+func newApplyReport() *ApplyReport {
+	return &ApplyReport{Results: nil}
+}
+
+// String summarizes the report, e.g. "3 applied, 10 unchanged, 0 failed, 1 not-ready, 1 held-back"
+func (report *ApplyReport) String() string {
+	statuses := []ResourceApplyStatus{
+		AppliedResourceApplyStatus,
+		UnchangedResourceApplyStatus,
+		FailedResourceApplyStatus,
+		NotReadyResourceApplyStatus,
+		HeldBackResourceApplyStatus,
+	}
+	return strings.Join(lo.Map(statuses, func(status ResourceApplyStatus, _ int) string {
+		return fmt.Sprintf("%d %s", len(report.GetResultsWithStatus(status)), status)
+	}), ", ")
+}
+
+func (report *ApplyReport) GetResultsWithStatus(status ResourceApplyStatus) []*ResourceApplyResult {
+	return lo.Filter(report.Results, func(result *ResourceApplyResult, _ int) bool { return result.Status == status })
+}
+
+// setResult replaces the previous result of the object, e.g. when an applied workload turns out to be not ready
+func (report *ApplyReport) setResult(kind resourceKind, object metav1.Object, status ResourceApplyStatus, message string) {
+	result, found := lo.Find(report.Results, func(result *ResourceApplyResult) bool {
+		return result.Kind == kind.groupVersionKind.Kind && result.Namespace == object.GetNamespace() && result.Name == object.GetName()
+	})
+	if !found {
+		result = &ResourceApplyResult{
+			Kind:      kind.groupVersionKind.Kind,
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+			Status:    "",
+			Message:   "",
+		}
+		report.Results = append(report.Results, result)
+	}
+	result.Status = status
+	result.Message = message
+}
@@ -0,0 +1,93 @@
+package cluster_manager
+
+import (
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const (
+	// The canary routing rule catches every request, so it's evaluated after the flow routing rules and the rules with
+	// the default priority, but before the default route
+	CanaryRoutingRulePriority int32 = -100
+
+	canaryRoutingRuleNamePrefix = "canary-"
+	maxCanaryPercentage         = 100
+)
+
+// CanaryRouting splits the requests to a host between the prod subset and the canary subset, sending the percentage of
+// them to the canary one
+type CanaryRouting struct {
+	Host         string
+	ProdSubset   string
+	CanarySubset string
+	Percentage   int32
+}
+
+func NewCanaryRouting(host string, prodSubset string, canarySubset string, percentage int32) *CanaryRouting {
+	return &CanaryRouting{
+		Host:         host,
+		ProdSubset:   prodSubset,
+		CanarySubset: canarySubset,
+		Percentage:   percentage,
+	}
+}
+
+// NewCanaryRoutingRule builds the weighted routing rule of the canary, both destinations are kept even with a 0 weight
+// so the weight can be changed step by step
+func NewCanaryRoutingRule(canaryRouting *CanaryRouting) (*istio.HTTPRoute, error) {
+	if canaryRouting.Host == "" {
+		return nil, stacktrace.NewError("The canary routing needs a host")
+	}
+	if canaryRouting.ProdSubset == "" || canaryRouting.CanarySubset == "" {
+		return nil, stacktrace.NewError("The canary routing of host '%s' needs a prod and a canary subset", canaryRouting.Host)
+	}
+	if canaryRouting.Percentage < 0 || canaryRouting.Percentage > maxCanaryPercentage {
+		return nil, stacktrace.NewError("The canary percentage must be between 0 and %d, got %d", maxCanaryPercentage, canaryRouting.Percentage)
+	}
+
+	return &istio.HTTPRoute{
+		Name: getCanaryRoutingRuleName(canaryRouting.CanarySubset),
+		Route: []*istio.HTTPRouteDestination{
+			{
+				Destination: &istio.Destination{
+					Host:   canaryRouting.Host,
+					Subset: canaryRouting.ProdSubset,
+				},
+				Weight: maxCanaryPercentage - canaryRouting.Percentage,
+			},
+			{
+				Destination: &istio.Destination{
+					Host:   canaryRouting.Host,
+					Subset: canaryRouting.CanarySubset,
+				},
+				Weight: canaryRouting.Percentage,
+			},
+		},
+	}, nil
+}
+
+// addCanaryRoutingRule adds the weighted routing rule of the canary to the VirtualService it targets. Kontrol promotes a
+// canary by sending the canary subset as the prod one, and aborts it by leaving it out, either way the rule goes away
+// with the next apply of the VirtualService
+func addCanaryRoutingRule(virtualServices map[string]*v1alpha3.VirtualService, canary types.Canary) error {
+	virtualService, err := getTargetVirtualService(virtualServices, canary.Namespace, canary.VirtualService)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the VirtualService of canary subset '%s'", canary.CanarySubset)
+	}
+
+	routingRule, err := NewCanaryRoutingRule(NewCanaryRouting(canary.Host, canary.ProdSubset, canary.CanarySubset, int32(canary.Percentage)))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred building the routing rule of canary subset '%s'", canary.CanarySubset)
+	}
+
+	if err = setRoutingRule(virtualService, routingRule, CanaryRoutingRulePriority); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the weight of canary subset '%s' to %d%%", canary.CanarySubset, canary.Percentage)
+	}
+	return nil
+}
+
+func getCanaryRoutingRuleName(canarySubset string) string {
+	return canaryRoutingRuleNamePrefix + canarySubset
+}
@@ -0,0 +1,79 @@
+package cluster_manager
+
+import (
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/stretchr/testify/require"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"testing"
+)
+
+func TestNewCanaryRoutingRule_SplitsTheTraffic(t *testing.T) {
+	routingRule, err := NewCanaryRoutingRule(NewCanaryRouting("reviews", "v1", "v2", 10))
+	require.NoError(t, err)
+	require.Equal(t, "canary-v2", routingRule.GetName())
+	require.Empty(t, routingRule.GetMatch())
+
+	destinations := routingRule.GetRoute()
+	require.Len(t, destinations, 2)
+	require.Equal(t, "v1", destinations[0].GetDestination().GetSubset())
+	require.Equal(t, int32(90), destinations[0].GetWeight())
+	require.Equal(t, "v2", destinations[1].GetDestination().GetSubset())
+	require.Equal(t, int32(10), destinations[1].GetWeight())
+
+	_, err = NewCanaryRoutingRule(NewCanaryRouting("reviews", "v1", "v2", 101))
+	require.Error(t, err)
+	_, err = NewCanaryRoutingRule(NewCanaryRouting("reviews", "", "v2", 10))
+	require.Error(t, err)
+}
+
+func TestAddTrafficRoutingRules_AddsTheCanaryAfterTheFlowRoutes(t *testing.T) {
+	virtualService := newReviewsVirtualService()
+	// The flow routes published by Kontrol only match the requests of their flow
+	virtualService.Spec.Http[0].Match = []*istio.HTTPMatchRequest{
+		{Headers: map[string]*istio.StringMatch{DefaultFlowHeaderName: {MatchType: &istio.StringMatch_Exact{Exact: "dev"}}}},
+	}
+	managedObjects := []*managedObject{{kind: virtualServiceKind, object: virtualService}}
+	clusterResources := &types.ClusterResources{
+		Canaries: &[]types.Canary{newTestCanary(subsetTestName, 10)},
+	}
+
+	require.NoError(t, addTrafficRoutingRules(managedObjects, clusterResources))
+
+	desiredVirtualService, ok := managedObjects[0].object.(*v1alpha3.VirtualService)
+	require.True(t, ok)
+	require.Equal(t, []string{"dev-flow", "canary-v2", "canary", "default"}, getRouteNames(desiredVirtualService))
+	require.Equal(t, int32(90), desiredVirtualService.Spec.GetHttp()[1].GetRoute()[0].GetWeight())
+	require.Equal(t, int32(10), desiredVirtualService.Spec.GetHttp()[1].GetRoute()[1].GetWeight())
+	require.Contains(t, desiredVirtualService.GetAnnotations(), routingRulePrioritiesAnnotationKey)
+
+	// The received VirtualService is left as it was
+	require.Equal(t, []string{"dev-flow", "canary", "default"}, getRouteNames(virtualService))
+}
+
+func TestAddTrafficRoutingRules_LeavesOutTheInvalidCanaries(t *testing.T) {
+	managedObjects := []*managedObject{{kind: virtualServiceKind, object: newReviewsVirtualService()}}
+	clusterResources := &types.ClusterResources{
+		Canaries: &[]types.Canary{newTestCanary("ratings", 10), newTestCanary(subsetTestName, 150)},
+	}
+
+	err := addTrafficRoutingRules(managedObjects, clusterResources)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "VirtualService 'ratings' in namespace 'prod' isn't in the cluster resources")
+	require.Contains(t, err.Error(), "The canary percentage must be between 0 and 100, got 150")
+
+	desiredVirtualService, ok := managedObjects[0].object.(*v1alpha3.VirtualService)
+	require.True(t, ok)
+	require.Equal(t, []string{"dev-flow", "canary", "default"}, getRouteNames(desiredVirtualService))
+}
+
+func newTestCanary(vsName string, percentage int) types.Canary {
+	return types.Canary{
+		Namespace:      subsetTestNamespace,
+		VirtualService: vsName,
+		Host:           subsetTestName,
+		ProdSubset:     "v1",
+		CanarySubset:   "v2",
+		Percentage:     percentage,
+	}
+}
@@ -2,16 +2,31 @@ package cluster_manager
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
 	"github.com/kurtosis-tech/stacktrace"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	istio "istio.io/api/networking/v1alpha3"
 	"istio.io/client-go/pkg/apis/networking/v1alpha3"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"kardinal.kontrol/kardinal-manager/metrics"
 	"kardinal.kontrol/kardinal-manager/topology"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -20,9 +35,20 @@ const (
 	deleteOptionsGracePeriodSeconds int64 = 0
 	istioLabel                            = "istio-injection"
 	enabledIstioValue                     = "enabled"
+
+	kardinalManagedByLabelKey       = "dev.kardinal.managed-by"
+	kardinalManagedByLabelValue     = fieldManager
+	kardinalTenantUuidAnnotationKey = "dev.kardinal.tenant-uuid"
+	desiredStateHashAnnotationKey   = "dev.kardinal.desired-state-hash"
+	objectKeySeparator              = "/"
+
+	subresourceSeparator = "/"
 )
 
 var (
+	// cleanUpVerbs are the verbs the clean-up needs on a resource, the discovered resources without them are skipped
+	cleanUpVerbs = []string{"list", "delete"}
+
 	globalListOptions = metav1.ListOptions{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "",
@@ -88,10 +114,44 @@ var (
 type ClusterManager struct {
 	kubernetesClient *kubernetesClient
 	istioClient      *istioClient
+
+	// tenantUuid is stamped in every applied object and only objects with it are considered in the clean-up
+	tenantUuid string
+
+	// forceApplyOwnership makes Kardinal take over the fields owned by other field managers when applying resources
+	// instead of failing with a conflict
+	forceApplyOwnership bool
+
+	// extraResourceKinds are the kinds of the extra resources applied since the manager started, plus the ones of the
+	// managed objects found in the cluster on every full resync, so the objects are cleaned up once their kind is no
+	// longer in the cluster resources, even after a restart or a leader change. Only the sync records them, the plan
+	// resolves the kinds of its extra resources without recording them
+	extraResourceKinds map[schema.GroupKind]resourceKind
+
+	// readinessTimeout bounds the wait for the workloads to be ready before publishing the routes to them, zero
+	// disables the wait
+	readinessTimeout time.Duration
+
+	// notReadyWorkloadKeys are the workloads which were not ready in the last sync, they are waited for again even
+	// when they didn't change
+	notReadyWorkloadKeys []string
+
+	// syncStateMutex guards the extra resource kinds and the not ready workloads, the plan is served by the REST API
+	// while the fetcher syncs
+	syncStateMutex sync.Mutex
 }
 
-func NewClusterManager(kubernetesClient *kubernetesClient, istioClient *istioClient) *ClusterManager {
-	return &ClusterManager{kubernetesClient: kubernetesClient, istioClient: istioClient}
+func NewClusterManager(kubernetesClient *kubernetesClient, istioClient *istioClient, tenantUuid string, forceApplyOwnership bool, readinessTimeout time.Duration) *ClusterManager {
+	return &ClusterManager{
+		kubernetesClient:     kubernetesClient,
+		istioClient:          istioClient,
+		tenantUuid:           tenantUuid,
+		forceApplyOwnership:  forceApplyOwnership,
+		extraResourceKinds:   map[schema.GroupKind]resourceKind{},
+		readinessTimeout:     readinessTimeout,
+		notReadyWorkloadKeys: nil,
+		syncStateMutex:       sync.Mutex{},
+	}
 }
 
 func (manager *ClusterManager) GetVirtualServices(ctx context.Context, namespace string) ([]*v1alpha3.VirtualService, error) {
@@ -114,6 +174,15 @@ func (manager *ClusterManager) GetVirtualService(ctx context.Context, namespace
 	return virtualService, nil
 }
 
+func (manager *ClusterManager) DeleteVirtualService(ctx context.Context, namespace string, name string) error {
+	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(namespace)
+
+	if err := virtServiceClient.Delete(ctx, name, globalDeleteOptions); err != nil {
+		return stacktrace.Propagate(err, "An error occurred deleting virtual service '%s' from IstIo client", name)
+	}
+	return nil
+}
+
 func (manager *ClusterManager) GetDestinationRules(ctx context.Context, namespace string) ([]*v1alpha3.DestinationRule, error) {
 	destRuleClient := manager.istioClient.clientSet.NetworkingV1alpha3().DestinationRules(namespace)
 
@@ -134,19 +203,11 @@ func (manager *ClusterManager) GetDestinationRule(ctx context.Context, namespace
 	return destinationRule, nil
 }
 
-// how to expose API to configure ordering of routing rule? https://istio.io/latest/docs/concepts/traffic-management/#routing-rule-precedence
-func (manager *ClusterManager) AddRoutingRule(ctx context.Context, namespace string, vsName string, routingRule *istio.HTTPRoute) error {
-	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(namespace)
+func (manager *ClusterManager) CreateDestinationRule(ctx context.Context, destinationRule *v1alpha3.DestinationRule) error {
+	destRuleClient := manager.istioClient.clientSet.NetworkingV1alpha3().DestinationRules(destinationRule.Namespace)
 
-	vs, err := virtServiceClient.Get(ctx, vsName, globalGetOptions)
-	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred retrieving virtual service '%s'", vsName)
-	}
-	// always prepend routing rules due to routing rule precedence
-	vs.Spec.Http = append([]*istio.HTTPRoute{routingRule}, vs.Spec.Http...)
-	_, err = virtServiceClient.Update(ctx, vs, metav1.UpdateOptions{})
-	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred updating virtual service '%s' with routing rule: %v", vsName, routingRule)
+	if _, err := destRuleClient.Create(ctx, destinationRule, globalCreateOptions); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating destination rule '%s'", destinationRule.Name)
 	}
 	return nil
 }
@@ -159,364 +220,768 @@ func (manager *ClusterManager) AddSubset(ctx context.Context, namespace string,
 		return stacktrace.Propagate(err, "An error occurred retrieving destination rule '%s'", drName)
 	}
 	// if there already exists a subset for the same, just update it
-	shouldAddNewSubset := true
-	for _, s := range dr.Spec.Subsets {
-		if s.Name == subset.Name {
-			s = subset
-			shouldAddNewSubset = false
-		}
-	}
-	if shouldAddNewSubset {
+	_, subsetIndex, found := lo.FindIndexOf(dr.Spec.Subsets, func(s *istio.Subset) bool {
+		return s.GetName() == subset.Name
+	})
+	if found {
+		dr.Spec.Subsets[subsetIndex] = subset
+	} else {
 		dr.Spec.Subsets = append(dr.Spec.Subsets, subset)
 	}
-	_, err = destRuleClient.Update(ctx, dr, metav1.UpdateOptions{})
+	_, err = destRuleClient.Update(ctx, dr, globalUpdateOptions)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred updating destination rule '%s' with subset: %v", drName, subset)
 	}
 	return nil
 }
 
+// RemoveSubset first prunes the virtual service routes sending traffic to the subset, so nothing is routed to a subset
+// that no longer exists, and then removes it from the destination rule. Removing a subset that isn't there is a no-op
+func (manager *ClusterManager) RemoveSubset(ctx context.Context, namespace string, drName string, subsetName string) error {
+	destRuleClient := manager.istioClient.clientSet.NetworkingV1alpha3().DestinationRules(namespace)
+
+	dr, err := destRuleClient.Get(ctx, drName, globalGetOptions)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred retrieving destination rule '%s'", drName)
+	}
+
+	if err = manager.pruneSubsetRoutes(ctx, namespace, dr.Spec.GetHost(), subsetName); err != nil {
+		return stacktrace.Propagate(err, "An error occurred pruning the routes to subset '%s' of destination rule '%s'", subsetName, drName)
+	}
+
+	_, subsetIndex, found := lo.FindIndexOf(dr.Spec.Subsets, func(s *istio.Subset) bool {
+		return s.GetName() == subsetName
+	})
+	if !found {
+		logrus.Debugf("Destination rule '%s' has no subset '%s', nothing to remove", drName, subsetName)
+		return nil
+	}
+	dr.Spec.Subsets = append(dr.Spec.Subsets[:subsetIndex], dr.Spec.Subsets[subsetIndex+1:]...)
+	_, err = destRuleClient.Update(ctx, dr, globalUpdateOptions)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing subset '%s' from destination rule '%s'", subsetName, drName)
+	}
+	return nil
+}
+
+func (manager *ClusterManager) pruneSubsetRoutes(ctx context.Context, namespace string, host string, subsetName string) error {
+	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(namespace)
+
+	virtualServices, err := virtServiceClient.List(ctx, globalListOptions)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred retrieving virtual services.")
+	}
+
+	for _, vs := range virtualServices.Items {
+		httpRoutes, wasPruned := removeSubsetDestinations(vs.Spec.Http, namespace, host, subsetName)
+		if !wasPruned {
+			continue
+		}
+		vs.Spec.Http = httpRoutes
+		if _, err = virtServiceClient.Update(ctx, vs, globalUpdateOptions); err != nil {
+			return stacktrace.Propagate(err, "An error occurred pruning the routes of virtual service '%s'", vs.Name)
+		}
+		logrus.Infof("Pruned the routes to subset '%s' of host '%s' from virtual service '%s'", subsetName, host, vs.Name)
+	}
+	return nil
+}
+
 func (manager *ClusterManager) GetTopologyForNameSpace(namespace string) (map[string]*topology.Node, error) {
 	return manager.istioClient.topologyManager.FetchTopology(namespace)
 }
 
-func (manager *ClusterManager) ApplyClusterResources(ctx context.Context, clusterResources *types.ClusterResources) error {
+// ApplyClusterResources uses server-side apply, so Kardinal only owns the fields it sets and leaves alone the ones
+// managed by other controllers (e.g. the replicas set by an HPA) or kubectl users. The VirtualServices are applied
+// last, once the workloads they route to are ready, and their routes to the workloads which didn't get ready within the
+// readiness timeout are held back. The returned report has the result of every object, even when an error is returned.
+// A full resync applies the objects whose desired state didn't change too, correcting the drift in the fields Kardinal owns
+func (manager *ClusterManager) ApplyClusterResources(ctx context.Context, clusterResources *types.ClusterResources, isFullResync bool) (*ApplyReport, error) {
+	report := newApplyReport()
 
 	if !isValid(clusterResources) {
 		logrus.Debugf("the received cluster resources is not valid, nothing to apply.")
-		return nil
+		return report, nil
+	}
+
+	// Errors are collected instead of returned right away so one failing resource doesn't prevent the rest from being applied
+	var applyErrors []error
+
+	managedObjects, err := manager.getManagedObjects(clusterResources)
+	if err != nil {
+		applyErrors = append(applyErrors, stacktrace.Propagate(err, "An error occurred getting the kinds of the extra resources, they won't be applied"))
 	}
+	manager.recordExtraResourceKinds(getResourceKinds(managedObjects))
 
-	allNSs := [][]string{
-		lo.Uniq(lo.Map(*clusterResources.Services, func(item corev1.Service, _ int) string { return item.Namespace })),
-		lo.Uniq(lo.Map(*clusterResources.Deployments, func(item appsv1.Deployment, _ int) string { return item.Namespace })),
-		lo.Uniq(lo.Map(*clusterResources.VirtualServices, func(item v1alpha3.VirtualService, _ int) string { return item.Namespace })),
-		lo.Uniq(lo.Map(*clusterResources.DestinationRules, func(item v1alpha3.DestinationRule, _ int) string { return item.Namespace })),
-		{clusterResources.Gateway.Namespace},
+	if err = addTrafficRoutingRules(managedObjects, clusterResources); err != nil {
+		applyErrors = append(applyErrors, stacktrace.Propagate(err, "An error occurred adding the traffic routing rules to the VirtualServices, the invalid ones were left out"))
 	}
 
-	uniqueNamespaces := lo.Uniq(lo.Flatten(allNSs))
+	objectsToApply := getObjectsToApply(managedObjects)
+	routingObjects := lo.Filter(objectsToApply, func(managedObj *managedObject, _ int) bool { return managedObj.kind == virtualServiceKind })
+	otherObjects := lo.Filter(objectsToApply, func(managedObj *managedObject, _ int) bool { return managedObj.kind != virtualServiceKind })
 
-	for _, namespace := range uniqueNamespaces {
-		if err := manager.ensureNamespace(ctx, namespace); err != nil {
-			return stacktrace.Propagate(err, "An error occurred while creating or updating cluster namespace '%s'", namespace)
+	for _, managedObj := range otherObjects {
+		if err := manager.applyObjectAndRecordResult(ctx, managedObj, isFullResync, report); err != nil {
+			applyErrors = append(applyErrors, err)
 		}
 	}
 
-	for _, service := range *clusterResources.Services {
-		if err := manager.createOrUpdateService(ctx, &service); err != nil {
-			return stacktrace.Propagate(err, "An error occurred while creating or updating service '%s'", service.GetName())
-		}
+	notReadyDestinations := manager.waitForReadiness(ctx, otherObjects, isFullResync, report)
+	if len(notReadyDestinations) > 0 {
+		applyErrors = append(applyErrors, stacktrace.NewError("Services %v are not ready, the routes to them were held back", notReadyDestinations))
 	}
 
-	for _, deployment := range *clusterResources.Deployments {
-		if err := manager.createOrUpdateDeployment(ctx, &deployment); err != nil {
-			return stacktrace.Propagate(err, "An error occurred while creating or updating deployment '%s'", deployment.GetName())
+	for _, managedObj := range routingObjects {
+		objectToApply := managedObj
+		var heldBackDestinations []notReadyDestination
+		if virtualService, ok := managedObj.object.(*v1alpha3.VirtualService); ok {
+			prunedVirtualService, routedNotReadyDestinations, isHeldBack := holdBackNotReadyRoutes(virtualService, notReadyDestinations)
+			if isHeldBack {
+				logrus.Warnf("Holding back VirtualService '%s' in namespace '%s' because it routes to the not ready Services %v", virtualService.Name, virtualService.Namespace, routedNotReadyDestinations)
+				report.setResult(managedObj.kind, managedObj.object, HeldBackResourceApplyStatus, fmt.Sprintf("it routes to the not ready Services %s", joinNotReadyDestinations(routedNotReadyDestinations)))
+				continue
+			}
+			objectToApply = &managedObject{kind: managedObj.kind, object: prunedVirtualService}
+			heldBackDestinations = routedNotReadyDestinations
+		}
+		if err := manager.applyObjectAndRecordResult(ctx, objectToApply, isFullResync, report); err != nil {
+			applyErrors = append(applyErrors, err)
+			continue
+		}
+		// The rest of the routes are applied, but the VirtualService is still reported so the routes left out are visible
+		if len(heldBackDestinations) > 0 {
+			logrus.Warnf("Holding back the routes of VirtualService '%s' in namespace '%s' to the not ready Services %v", managedObj.object.GetName(), managedObj.object.GetNamespace(), heldBackDestinations)
+			report.setResult(managedObj.kind, managedObj.object, HeldBackResourceApplyStatus, fmt.Sprintf("its routes to the not ready Services %s were held back, the rest were applied", joinNotReadyDestinations(heldBackDestinations)))
 		}
 	}
 
-	for _, virtualService := range *clusterResources.VirtualServices {
-		if err := manager.createOrUpdateVirtualService(ctx, &virtualService); err != nil {
-			return stacktrace.Propagate(err, "An error occurred while creating or updating virtual service '%s'", virtualService.GetName())
-		}
+	if len(applyErrors) > 0 {
+		return report, stacktrace.Propagate(errors.Join(applyErrors...), "%d errors occurred while applying the cluster resources", len(applyErrors))
 	}
 
-	for _, destinationRule := range *clusterResources.DestinationRules {
-		if err := manager.createOrUpdateDestinationRule(ctx, &destinationRule); err != nil {
-			return stacktrace.Propagate(err, "An error occurred while creating or updating destination rule '%s'", destinationRule.GetName())
+	return report, nil
+}
+
+func (manager *ClusterManager) applyObjectAndRecordResult(ctx context.Context, managedObj *managedObject, isFullResync bool, report *ApplyReport) error {
+	status, err := manager.applyObject(ctx, managedObj.kind, managedObj.object, isFullResync)
+	if err != nil {
+		metrics.SyncErrorsTotal.Inc(managedObj.kind.groupVersionKind.Kind)
+		// Only the root cause goes in the report, the stack trace is only useful in the manager logs
+		message := stacktrace.RootCause(err).Error()
+		if isForbiddenExtraResource(managedObj.kind, err) {
+			message = fmt.Sprintf("%s: %s", getForbiddenExtraResourceHint(managedObj.kind), message)
 		}
+		report.setResult(managedObj.kind, managedObj.object, FailedResourceApplyStatus, message)
+		return stacktrace.Propagate(err, "An error occurred while applying %s '%s'", managedObj.kind.groupVersionKind.Kind, managedObj.object.GetName())
 	}
+	report.setResult(managedObj.kind, managedObj.object, status, "")
+	return nil
+}
 
-	if err := manager.createOrUpdateGateway(ctx, clusterResources.Gateway); err != nil {
-		return stacktrace.Propagate(err, "An error occurred while creating or updating the cluster gateway")
-	}
+// isForbiddenExtraResource is true when the manager ClusterRole doesn't grant access to the kind of an extra resource,
+// it only includes the managed kinds and the extra resources given when deploying the manager
+func isForbiddenExtraResource(kind resourceKind, err error) bool {
+	return apierrors.IsForbidden(stacktrace.RootCause(err)) && !isManagedKind(kind)
+}
 
-	return nil
+func getForbiddenExtraResourceHint(kind resourceKind) string {
+	return fmt.Sprintf("the kardinal-manager ClusterRole doesn't grant access to '%s', redeploy the manager with `kardinal manager deploy --extra-resource %s`", kind.getRBACResourceName(), kind.getRBACResourceName())
 }
 
-func (manager *ClusterManager) CleanUpClusterResources(ctx context.Context, clusterResources *types.ClusterResources) error {
+// CleanUpClusterResources only removes objects carrying the Kardinal ownership label and this manager's tenant annotation,
+// so workloads sharing a namespace with Kardinal ones are never touched. A full resync looks for the managed objects of
+// every kind in the cluster first, so the extra resources applied before a restart or by a previous leader are cleaned up too
+func (manager *ClusterManager) CleanUpClusterResources(ctx context.Context, clusterResources *types.ClusterResources, isFullResync bool) error {
 
 	if !isValid(clusterResources) {
 		logrus.Debugf("the received cluster resources is not valid, nothing to clean up.")
 		return nil
 	}
 
-	// Clean up services
-	servicesByNS := lo.GroupBy(*clusterResources.Services, func(item corev1.Service) string {
-		return item.Namespace
-	})
-	for namespace, services := range servicesByNS {
-		if err := manager.cleanUpServicesInNamespace(ctx, namespace, services); err != nil {
-			return stacktrace.Propagate(err, "An error occurred cleaning up services '%+v' in namespace '%s'", services, namespace)
-		}
-	}
+	var cleanUpErrors []error
 
-	// Clean up deployments
-	deploymentsByNS := lo.GroupBy(*clusterResources.Deployments, func(item appsv1.Deployment) string { return item.Namespace })
-	for namespace, deployments := range deploymentsByNS {
-		if err := manager.cleanUpDeploymentsInNamespace(ctx, namespace, deployments); err != nil {
-			return stacktrace.Propagate(err, "An error occurred cleaning up deployments '%+v' in namespace '%s'", deployments, namespace)
-		}
+	managedObjects, err := manager.getManagedObjects(clusterResources)
+	areExtraResourceKindsResolved := err == nil
+	if err != nil {
+		cleanUpErrors = append(cleanUpErrors, stacktrace.Propagate(err, "An error occurred getting the kinds of the extra resources, they won't be cleaned up"))
 	}
+	manager.recordExtraResourceKinds(getResourceKinds(managedObjects))
 
-	// Clean up virtual services
-	virtualServicesByNS := lo.GroupBy(*clusterResources.VirtualServices, func(item v1alpha3.VirtualService) string { return item.Namespace })
-	for namespace, virtualServices := range virtualServicesByNS {
-		if err := manager.cleanUpVirtualServicesInNamespace(ctx, namespace, virtualServices); err != nil {
-			return stacktrace.Propagate(err, "An error occurred cleaning up virtual services '%+v' in namespace '%s'", virtualServices, namespace)
+	if isFullResync {
+		discoveredKinds, err := manager.discoverExtraResourceKinds(ctx)
+		if err != nil {
+			cleanUpErrors = append(cleanUpErrors, stacktrace.Propagate(err, "An error occurred looking for the managed objects of the extra resource kinds, some of them might not be cleaned up"))
 		}
+		manager.recordExtraResourceKinds(discoveredKinds)
 	}
 
-	// Clean up destination rules
-	destinationRulesByNS := lo.GroupBy(*clusterResources.DestinationRules, func(item v1alpha3.DestinationRule) string {
-		return item.Namespace
-	})
-	for namespace, destinationRules := range destinationRulesByNS {
-		if err := manager.cleanUpDestinationRulesInNamespace(ctx, namespace, destinationRules); err != nil {
-			return stacktrace.Propagate(err, "An error occurred cleaning up destination rules '%+v' in namespace '%s'", destinationRules, namespace)
+	objectKeysToKeepByKind := getObjectKeysByKind(managedObjects)
+
+	for _, kind := range manager.getKindsToCleanUp(getResourceKinds(managedObjects), areExtraResourceKindsResolved) {
+		if err := manager.cleanUpManagedObjects(ctx, kind, objectKeysToKeepByKind[kind.groupVersionKind.GroupKind()]); err != nil {
+			cleanUpErrors = append(cleanUpErrors, stacktrace.Propagate(err, "An error occurred cleaning up %s objects", kind.groupVersionKind.Kind))
 		}
 	}
 
-	// Clean up gateway
-	gatewaysByNs := map[string][]v1alpha3.Gateway{
-		clusterResources.Gateway.GetNamespace(): {*clusterResources.Gateway},
-	}
-	for namespace, gateways := range gatewaysByNs {
-		if err := manager.cleanUpGatewaysInNamespace(ctx, namespace, gateways); err != nil {
-			return stacktrace.Propagate(err, "An error occurred cleaning up gateways '%+v' in namespace '%s'", gateways, namespace)
-		}
+	if len(cleanUpErrors) > 0 {
+		return stacktrace.Propagate(errors.Join(cleanUpErrors...), "%d errors occurred while cleaning up the cluster resources", len(cleanUpErrors))
 	}
 
 	return nil
 }
 
-func (manager *ClusterManager) ensureNamespace(ctx context.Context, name string) error {
-
-	existingNamespace, err := manager.kubernetesClient.clientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
-	if err == nil && existingNamespace != nil {
-		value, found := existingNamespace.Labels[istioLabel]
-		if !found || value != enabledIstioValue {
-			existingNamespace.Labels[istioLabel] = enabledIstioValue
-			manager.kubernetesClient.clientSet.CoreV1().Namespaces().Update(ctx, existingNamespace, globalUpdateOptions)
-		}
-	} else {
-		newNamespace := corev1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: name,
-				Labels: map[string]string{
-					istioLabel: enabledIstioValue,
-				},
-			},
-		}
-		_, err = manager.kubernetesClient.clientSet.CoreV1().Namespaces().Create(ctx, &newNamespace, globalCreateOptions)
+// getManagedObjects returns the objects in the cluster resources, in the order they are applied, already stamped with
+// the Kardinal ownership metadata, the extra resources whose kind can't be resolved are left out and reported in the error
+func (manager *ClusterManager) getManagedObjects(clusterResources *types.ClusterResources) ([]*managedObject, error) {
+	var managedObjects []*managedObject
+
+	// Dependencies go first, e.g. the ServiceAccounts, ConfigMaps, Secrets and PVCs used by the workloads
+	managedObjects = appendManagedObjects(managedObjects, serviceAccountKind, clusterResources.ServiceAccounts)
+	managedObjects = appendManagedObjects(managedObjects, configMapKind, clusterResources.ConfigMaps)
+	managedObjects = appendManagedObjects(managedObjects, secretKind, clusterResources.Secrets)
+	managedObjects = appendManagedObjects(managedObjects, persistentVolumeClaimKind, clusterResources.PersistentVolumeClaims)
+	managedObjects = appendManagedObjects(managedObjects, serviceKind, clusterResources.Services)
+	managedObjects = appendManagedObjects(managedObjects, deploymentKind, clusterResources.Deployments)
+	managedObjects = appendManagedObjects(managedObjects, statefulSetKind, clusterResources.StatefulSets)
+	managedObjects = appendManagedObjects(managedObjects, jobKind, clusterResources.Jobs)
+	managedObjects = appendManagedObjects(managedObjects, cronJobKind, clusterResources.CronJobs)
+	// The DestinationRules go before the VirtualServices, so the subsets exist by the time the routes to them are published
+	managedObjects = appendManagedObjects(managedObjects, destinationRuleKind, clusterResources.DestinationRules)
+	managedObjects = appendManagedObjects(managedObjects, gatewayKind, clusterResources.Gateways)
+
+	// The single gateway is still accepted from the Kontrol versions which don't send the list yet
+	gatewayKeys := getObjectKeysByKind(managedObjects)[gatewayKind.groupVersionKind.GroupKind()]
+	if clusterResources.Gateway != nil && !lo.Contains(gatewayKeys, getObjectKey(clusterResources.Gateway.GetNamespace(), clusterResources.Gateway.GetName())) {
+		managedObjects = append(managedObjects, &managedObject{kind: gatewayKind, object: clusterResources.Gateway})
+	}
+
+	// The extra resources go after the objects they usually reference, e.g. an Argo Rollout referencing a Service
+	var extraResourceErrors []error
+	extraResources := lo.FromPtr(clusterResources.ExtraResources)
+	for index := range extraResources {
+		extraResource := &extraResources[index]
+		kind, err := manager.getExtraResourceKind(extraResource.GroupVersionKind())
 		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create Namespace: %s", name)
+			extraResourceErrors = append(extraResourceErrors, stacktrace.Propagate(err, "An error occurred getting the kind of extra resource '%s'", extraResource.GetName()))
+			continue
 		}
+		managedObjects = append(managedObjects, &managedObject{kind: kind, object: extraResource})
 	}
 
-	return nil
+	// The VirtualServices go last, they publish the routes to everything else
+	managedObjects = appendManagedObjects(managedObjects, virtualServiceKind, clusterResources.VirtualServices)
+
+	for _, managedObj := range managedObjects {
+		manager.stampOwnership(managedObj.object)
+	}
+
+	if len(extraResourceErrors) > 0 {
+		return managedObjects, errors.Join(extraResourceErrors...)
+	}
+
+	return managedObjects, nil
 }
 
-func (manager *ClusterManager) createOrUpdateService(ctx context.Context, service *corev1.Service) error {
-	serviceClient := manager.kubernetesClient.clientSet.CoreV1().Services(service.Namespace)
-	existingService, err := serviceClient.Get(ctx, service.Name, metav1.GetOptions{})
+// getExtraResourceKind resolves the resource and the scope of the kind through the discovery API
+func (manager *ClusterManager) getExtraResourceKind(groupVersionKind schema.GroupVersionKind) (resourceKind, error) {
+	if groupVersionKind.Kind == "" || groupVersionKind.Version == "" {
+		return resourceKind{}, stacktrace.NewError("The extra resource doesn't set its apiVersion and kind")
+	}
+
+	restMapping, err := manager.kubernetesClient.discoveryMapper.RESTMapping(groupVersionKind.GroupKind(), groupVersionKind.Version)
 	if err != nil {
-		// Resource does not exist, create new one
-		_, err = serviceClient.Create(ctx, service, globalCreateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create service: %s", service.GetName())
-		}
-	} else {
-		// Update the resource version to the latest before updating
-		service.ResourceVersion = existingService.ResourceVersion
-		_, err = serviceClient.Update(ctx, service, globalUpdateOptions)
+		// The discovery information is cached, so it doesn't include the CRDs installed after it was fetched
+		manager.kubernetesClient.discoveryMapper.Reset()
+		restMapping, err = manager.kubernetesClient.discoveryMapper.RESTMapping(groupVersionKind.GroupKind(), groupVersionKind.Version)
 		if err != nil {
-			return stacktrace.Propagate(err, "Failed to update service: %s", service.GetName())
+			return resourceKind{}, stacktrace.Propagate(err, "An error occurred getting the REST mapping of '%s'", groupVersionKind)
 		}
 	}
 
-	return nil
+	kind := newResourceKind(
+		groupVersionKind.Group,
+		groupVersionKind.Version,
+		groupVersionKind.Kind,
+		restMapping.Resource.Resource,
+		restMapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	)
+
+	return kind, nil
+}
+
+// recordExtraResourceKinds keeps the kinds of the extra resources, so their objects are considered in the following
+// clean-ups even once the kind is no longer in the cluster resources
+func (manager *ClusterManager) recordExtraResourceKinds(kinds []resourceKind) {
+	manager.syncStateMutex.Lock()
+	defer manager.syncStateMutex.Unlock()
+
+	for _, kind := range kinds {
+		if !isManagedKind(kind) {
+			manager.extraResourceKinds[kind.groupVersionKind.GroupKind()] = kind
+		}
+	}
 }
 
-func (manager *ClusterManager) createOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
-	deploymentClient := manager.kubernetesClient.clientSet.AppsV1().Deployments(deployment.Namespace)
-	existingDeployment, err := deploymentClient.Get(ctx, deployment.Name, metav1.GetOptions{})
+// discoverExtraResourceKinds returns the kinds, outside the managed ones, with objects owned by this manager's tenant in
+// the cluster. Every listable and deletable resource is listed with the Kardinal ownership label selector, the ones the
+// manager isn't allowed to list can't have been applied by it either, so they are skipped
+func (manager *ClusterManager) discoverExtraResourceKinds(ctx context.Context) ([]resourceKind, error) {
+	resourceLists, err := manager.kubernetesClient.clientSet.Discovery().ServerPreferredResources()
 	if err != nil {
-		_, err = deploymentClient.Create(ctx, deployment, globalCreateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create deployment: %s", deployment.GetName())
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the resources served by the API server")
 		}
-	} else {
-		deployment.ResourceVersion = existingDeployment.ResourceVersion
-		_, err = deploymentClient.Update(ctx, deployment, globalUpdateOptions)
+		// The aggregated APIs which are down don't prevent looking for the objects of the rest of the resources
+		logrus.Warnf("Some API groups couldn't be discovered, their managed objects won't be looked for: %s", err)
+	}
+
+	var discoveredKinds []resourceKind
+	var discoveryErrors []error
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
 		if err != nil {
-			return stacktrace.Propagate(err, "Failed to update deployment: %s", deployment.GetName())
+			discoveryErrors = append(discoveryErrors, stacktrace.Propagate(err, "An error occurred parsing the group version '%s'", resourceList.GroupVersion))
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			// The subresources, like the pods status, are not objects of their own
+			if strings.Contains(apiResource.Name, subresourceSeparator) || !lo.Every(apiResource.Verbs, cleanUpVerbs) {
+				continue
+			}
+			kind := newResourceKind(groupVersion.Group, groupVersion.Version, apiResource.Kind, apiResource.Name, apiResource.Namespaced)
+			if isManagedKind(kind) || kind.groupVersionKind.GroupKind() == namespaceKind.groupVersionKind.GroupKind() {
+				continue
+			}
+
+			managedObjects, err := manager.getObjectsToCleanUp(ctx, kind, nil)
+			if err != nil {
+				if apierrors.IsForbidden(stacktrace.RootCause(err)) {
+					logrus.Debugf("The manager isn't allowed to list the %s objects, it can't have applied any", kind.groupVersionKind.Kind)
+					continue
+				}
+				discoveryErrors = append(discoveryErrors, stacktrace.Propagate(err, "An error occurred looking for the managed %s objects", kind.groupVersionKind.Kind))
+				continue
+			}
+			if len(managedObjects) > 0 {
+				discoveredKinds = append(discoveredKinds, kind)
+			}
 		}
 	}
 
-	return nil
+	if len(discoveryErrors) > 0 {
+		return discoveredKinds, errors.Join(discoveryErrors...)
+	}
+
+	return discoveredKinds, nil
 }
 
-func (manager *ClusterManager) createOrUpdateVirtualService(ctx context.Context, virtualService *v1alpha3.VirtualService) error {
+// getKindsToCleanUp adds the recorded extra resource kinds and the given ones to the managed kinds, only when all the
+// extra resources kinds were resolved, otherwise the objects of the kinds which couldn't be resolved would be deleted
+func (manager *ClusterManager) getKindsToCleanUp(extraResourceKinds []resourceKind, includeExtraResourceKinds bool) []resourceKind {
+	kindsToCleanUp := append([]resourceKind{}, managedKinds...)
+	if !includeExtraResourceKinds {
+		return kindsToCleanUp
+	}
 
-	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(virtualService.GetNamespace())
+	manager.syncStateMutex.Lock()
+	extraResourceKinds = append(lo.Values(manager.extraResourceKinds), extraResourceKinds...)
+	manager.syncStateMutex.Unlock()
 
-	existingVirtService, err := virtServiceClient.Get(ctx, virtualService.Name, metav1.GetOptions{})
-	if err != nil {
-		_, err = virtServiceClient.Create(ctx, virtualService, globalCreateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create virtual service: %s", virtualService.GetName())
-		}
-	} else {
-		virtualService.ResourceVersion = existingVirtService.ResourceVersion
-		_, err = virtServiceClient.Update(ctx, virtualService, globalUpdateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to update virtual service: %s", virtualService.GetName())
+	for _, kind := range extraResourceKinds {
+		isKindToCleanUp := lo.ContainsBy(kindsToCleanUp, func(kindToCleanUp resourceKind) bool {
+			return kindToCleanUp.groupVersionKind.GroupKind() == kind.groupVersionKind.GroupKind()
+		})
+		if !isKindToCleanUp {
+			kindsToCleanUp = append(kindsToCleanUp, kind)
 		}
 	}
 
-	return nil
+	return kindsToCleanUp
 }
 
-func (manager *ClusterManager) createOrUpdateDestinationRule(ctx context.Context, destinationRule *v1alpha3.DestinationRule) error {
+// applyObject server-side applies the object, if another field manager owns any of the fields set by Kardinal the API
+// server returns a conflict error unless the manager was configured to force the ownership of those fields. The objects
+// whose desired state didn't change are skipped, unless it's a full resync
+func (manager *ClusterManager) applyObject(ctx context.Context, kind resourceKind, object metav1.Object, isFullResync bool) (ResourceApplyStatus, error) {
+	desiredObject, err := toUnstructured(kind, object)
+	if err != nil {
+		return FailedResourceApplyStatus, stacktrace.Propagate(err, "An error occurred converting %s '%s' to an unstructured object", kind.groupVersionKind.Kind, object.GetName())
+	}
 
-	destRuleClient := manager.istioClient.clientSet.NetworkingV1alpha3().DestinationRules(destinationRule.GetNamespace())
+	liveObject, err := manager.getLiveObject(ctx, kind, desiredObject)
+	if err != nil {
+		return FailedResourceApplyStatus, stacktrace.Propagate(err, "An error occurred getting the live %s '%s'", kind.groupVersionKind.Kind, object.GetName())
+	}
+
+	if !isFullResync && isUpToDate(liveObject, desiredObject) {
+		logrus.Debugf("%s '%s' in namespace '%s' is up to date, skipping the apply", kind.groupVersionKind.Kind, object.GetName(), object.GetNamespace())
+		return UnchangedResourceApplyStatus, nil
+	}
 
-	existingDestRule, err := destRuleClient.Get(ctx, destinationRule.Name, metav1.GetOptions{})
+	appliedObject, err := manager.serverSideApply(ctx, kind, desiredObject, manager.getApplyOptions())
 	if err != nil {
-		_, err = destRuleClient.Create(ctx, destinationRule, globalCreateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create destination rule: %s", destinationRule.GetName())
-		}
+		return FailedResourceApplyStatus, stacktrace.Propagate(err, "An error occurred applying %s '%s'", kind.groupVersionKind.Kind, object.GetName())
+	}
+
+	if isUnchangedByApply(liveObject, appliedObject) {
+		return UnchangedResourceApplyStatus, nil
+	}
+
+	if liveObject == nil {
+		metrics.ObjectsCreatedTotal.Inc(kind.groupVersionKind.Kind)
 	} else {
-		destinationRule.ResourceVersion = existingDestRule.ResourceVersion
-		_, err = destRuleClient.Update(ctx, destinationRule, globalUpdateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to update destination rule: %s", destinationRule.GetName())
+		metrics.ObjectsUpdatedTotal.Inc(kind.groupVersionKind.Kind)
+	}
+
+	return AppliedResourceApplyStatus, nil
+}
+
+func (manager *ClusterManager) serverSideApply(ctx context.Context, kind resourceKind, desiredObject *unstructured.Unstructured, applyOptions metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+	resourceInterface := manager.getResourceInterface(kind, desiredObject.GetNamespace())
+	appliedObject, err := resourceInterface.Apply(ctx, desiredObject.GetName(), desiredObject, applyOptions)
+	// The routing rules and subsets are updated with this same field manager, but as an update rather than an apply, so
+	// the API server tracks them as another manager. Those fields are ours, so they are taken back without forcing the
+	// fields of the rest of the managers
+	if err != nil && !applyOptions.Force && isConflictWithOwnUpdates(err) {
+		logrus.Debugf("Applying %s '%s' in namespace '%s' conflicts with the fields updated by the '%s' field manager, taking them over", kind.groupVersionKind.Kind, desiredObject.GetName(), desiredObject.GetNamespace(), fieldManager)
+		applyOptions.Force = true
+		appliedObject, err = resourceInterface.Apply(ctx, desiredObject.GetName(), desiredObject, applyOptions)
+	}
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return nil, stacktrace.Propagate(err, "Applying %s '%s' in namespace '%s' conflicts with fields owned by another field manager, set the force apply ownership option to take them over", kind.groupVersionKind.Kind, desiredObject.GetName(), desiredObject.GetNamespace())
 		}
+		return nil, stacktrace.Propagate(err, "Failed to apply %s '%s' in namespace '%s'", kind.groupVersionKind.Kind, desiredObject.GetName(), desiredObject.GetNamespace())
 	}
 
-	return nil
+	return appliedObject, nil
 }
 
-func (manager *ClusterManager) createOrUpdateGateway(ctx context.Context, gateway *v1alpha3.Gateway) error {
+// isConflictWithOwnUpdates tells whether every field of the apply conflict is owned by this field manager's updates
+func isConflictWithOwnUpdates(err error) bool {
+	if !apierrors.IsConflict(err) {
+		return false
+	}
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil || len(statusErr.Status().Details.Causes) == 0 {
+		return false
+	}
+	ownUpdatesConflictPrefix := fmt.Sprintf("conflict with %q using ", fieldManager)
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict || !strings.HasPrefix(cause.Message, ownUpdatesConflictPrefix) {
+			return false
+		}
+	}
+	return true
+}
 
-	gatewayClient := manager.istioClient.clientSet.NetworkingV1alpha3().Gateways(gateway.GetNamespace())
-	existingGateway, err := gatewayClient.Get(ctx, gateway.Name, metav1.GetOptions{})
+// getLiveObject returns nil if the object doesn't exist in the cluster
+func (manager *ClusterManager) getLiveObject(ctx context.Context, kind resourceKind, desiredObject *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	liveObject, err := manager.getResourceInterface(kind, desiredObject.GetNamespace()).Get(ctx, desiredObject.GetName(), globalGetOptions)
 	if err != nil {
-		_, err = gatewayClient.Create(ctx, gateway, globalCreateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to create gateway: %s", gateway.GetName())
-		}
-	} else {
-		gateway.ResourceVersion = existingGateway.ResourceVersion
-		_, err = gatewayClient.Update(ctx, gateway, globalUpdateOptions)
-		if err != nil {
-			return stacktrace.Propagate(err, "Failed to update gateway: %s", gateway.GetName())
+		if apierrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, stacktrace.Propagate(err, "Failed to get %s '%s' in namespace '%s'", kind.groupVersionKind.Kind, desiredObject.GetName(), desiredObject.GetNamespace())
 	}
 
-	return nil
+	return liveObject, nil
 }
 
-func (manager *ClusterManager) cleanUpServicesInNamespace(ctx context.Context, namespace string, servicesToKeep []corev1.Service) error {
-	serviceClient := manager.kubernetesClient.clientSet.CoreV1().Services(namespace)
-	allServices, err := serviceClient.List(ctx, globalListOptions)
+func (manager *ClusterManager) getResourceInterface(kind resourceKind, namespace string) dynamic.ResourceInterface {
+	if kind.isNamespaced {
+		return manager.kubernetesClient.dynamicClient.Resource(kind.groupVersionResource).Namespace(namespace)
+	}
+	return manager.kubernetesClient.dynamicClient.Resource(kind.groupVersionResource)
+}
+
+func (manager *ClusterManager) getApplyOptions() metav1.ApplyOptions {
+	return metav1.ApplyOptions{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "",
+			APIVersion: "",
+		},
+		DryRun: nil,
+		Force:  manager.forceApplyOwnership,
+		// We need every object to have this field manager so that the Kurtosis objects can all seamlessly modify Kubernetes resources
+		FieldManager: fieldManager,
+	}
+}
+
+// cleanUpManagedObjects deletes, in every namespace, the objects of the kind owned by this manager's tenant which are not in the keep list
+func (manager *ClusterManager) cleanUpManagedObjects(ctx context.Context, kind resourceKind, objectKeysToKeep []string) error {
+	objectsToDelete, err := manager.getObjectsToCleanUp(ctx, kind, objectKeysToKeep)
 	if err != nil {
-		return stacktrace.Propagate(err, "Failed to list services in namespace %s", namespace)
+		if isForbiddenExtraResource(kind, err) {
+			return stacktrace.Propagate(err, "Failed to get the %s objects to clean up, %s", kind.groupVersionKind.Kind, getForbiddenExtraResourceHint(kind))
+		}
+		return stacktrace.Propagate(err, "Failed to get the %s objects to clean up", kind.groupVersionKind.Kind)
 	}
-	for _, service := range allServices.Items {
-		_, exists := lo.Find(servicesToKeep, func(item corev1.Service) bool { return item.Name == service.Name })
-		if !exists {
-			err = serviceClient.Delete(ctx, service.Name, globalDeleteOptions)
-			if err != nil {
-				return stacktrace.Propagate(err, "Failed to delete service %s", service.GetName())
-			}
+
+	var deleteErrors []error
+	for _, objectToDelete := range objectsToDelete {
+		if err = manager.getResourceInterface(kind, objectToDelete.GetNamespace()).Delete(ctx, objectToDelete.GetName(), globalDeleteOptions); err != nil {
+			metrics.SyncErrorsTotal.Inc(kind.groupVersionKind.Kind)
+			deleteErrors = append(deleteErrors, stacktrace.Propagate(err, "Failed to delete %s '%s' in namespace '%s'", kind.groupVersionKind.Kind, objectToDelete.GetName(), objectToDelete.GetNamespace()))
+			continue
 		}
+		metrics.ObjectsDeletedTotal.Inc(kind.groupVersionKind.Kind)
+	}
+
+	if len(deleteErrors) > 0 {
+		return errors.Join(deleteErrors...)
 	}
+
 	return nil
 }
 
-func (manager *ClusterManager) cleanUpDeploymentsInNamespace(ctx context.Context, namespace string, deploymentsToKeep []appsv1.Deployment) error {
-	deploymentClient := manager.kubernetesClient.clientSet.AppsV1().Deployments(namespace)
-	allDeployments, err := deploymentClient.List(ctx, globalListOptions)
+func (manager *ClusterManager) getObjectsToCleanUp(ctx context.Context, kind resourceKind, objectKeysToKeep []string) ([]*unstructured.Unstructured, error) {
+	liveObjects, err := manager.kubernetesClient.dynamicClient.Resource(kind.groupVersionResource).List(ctx, manager.getManagedObjectsListOptions())
 	if err != nil {
-		return stacktrace.Propagate(err, "Failed to list deployments in namespace %s", namespace)
+		return nil, stacktrace.Propagate(err, "Failed to list %s objects managed by Kardinal", kind.groupVersionKind.Kind)
 	}
-	for _, deployment := range allDeployments.Items {
-		_, exists := lo.Find(deploymentsToKeep, func(item appsv1.Deployment) bool { return item.Name == deployment.Name })
-		if !exists {
-			err = deploymentClient.Delete(ctx, deployment.Name, globalDeleteOptions)
-			if err != nil {
-				return stacktrace.Propagate(err, "Failed to delete deployment %s", deployment.GetName())
-			}
+
+	var objectsToCleanUp []*unstructured.Unstructured
+	for index := range liveObjects.Items {
+		liveObject := &liveObjects.Items[index]
+		if !manager.isOwnedByTenant(liveObject) {
+			continue
 		}
+		if lo.Contains(objectKeysToKeep, getObjectKey(liveObject.GetNamespace(), liveObject.GetName())) {
+			continue
+		}
+		objectsToCleanUp = append(objectsToCleanUp, liveObject)
 	}
-	return nil
+
+	return objectsToCleanUp, nil
 }
 
-func (manager *ClusterManager) cleanUpVirtualServicesInNamespace(ctx context.Context, namespace string, virtualServicesToKeep []v1alpha3.VirtualService) error {
+func (manager *ClusterManager) getManagedObjectsListOptions() metav1.ListOptions {
+	listOptions := globalListOptions
+	listOptions.LabelSelector = labels.SelectorFromSet(map[string]string{
+		kardinalManagedByLabelKey: kardinalManagedByLabelValue,
+	}).String()
+	return listOptions
+}
 
-	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(namespace)
-	allVirtServices, err := virtServiceClient.List(ctx, globalListOptions)
-	if err != nil {
-		return stacktrace.Propagate(err, "Failed to list virtual services in namespace %s", namespace)
+// isOwnedByTenant is needed on top of the label selector because several tenants can share the same cluster
+func (manager *ClusterManager) isOwnedByTenant(object metav1.Object) bool {
+	tenantUuid, found := object.GetAnnotations()[kardinalTenantUuidAnnotationKey]
+	return found && tenantUuid == manager.tenantUuid
+}
+
+// stampOwnership marks the object as owned by Kardinal and this manager's tenant, which is what scopes the clean-up
+func (manager *ClusterManager) stampOwnership(object metav1.Object) {
+	objectLabels := object.GetLabels()
+	if objectLabels == nil {
+		objectLabels = map[string]string{}
 	}
-	for _, virtService := range allVirtServices.Items {
-		_, exists := lo.Find(virtualServicesToKeep, func(item v1alpha3.VirtualService) bool { return item.Name == virtService.Name })
-		if !exists {
-			err = virtServiceClient.Delete(ctx, virtService.Name, globalDeleteOptions)
-			if err != nil {
-				return stacktrace.Propagate(err, "Failed to delete virtual service %s", virtService.GetName())
-			}
-		}
+	objectLabels[kardinalManagedByLabelKey] = kardinalManagedByLabelValue
+	object.SetLabels(objectLabels)
+
+	objectAnnotations := object.GetAnnotations()
+	if objectAnnotations == nil {
+		objectAnnotations = map[string]string{}
 	}
+	objectAnnotations[kardinalTenantUuidAnnotationKey] = manager.tenantUuid
+	object.SetAnnotations(objectAnnotations)
+}
 
-	return nil
+func getObjectKey(namespace string, name string) string {
+	return namespace + objectKeySeparator + name
+}
+
+func appendManagedObjects[T any, PT interface {
+	*T
+	metav1.Object
+}](managedObjects []*managedObject, kind resourceKind, objects *[]T) []*managedObject {
+	objectsValue := lo.FromPtr(objects)
+	for index := range objectsValue {
+		var object PT = &objectsValue[index]
+		managedObjects = append(managedObjects, &managedObject{kind: kind, object: object})
+	}
+	return managedObjects
 }
 
-func (manager *ClusterManager) cleanUpDestinationRulesInNamespace(ctx context.Context, namespace string, destinationRulesToKeep []v1alpha3.DestinationRule) error {
+func getResourceKinds(managedObjects []*managedObject) []resourceKind {
+	return lo.Map(managedObjects, func(managedObj *managedObject, _ int) resourceKind { return managedObj.kind })
+}
 
-	destRuleClient := manager.istioClient.clientSet.NetworkingV1alpha3().DestinationRules(namespace)
-	allDestRules, err := destRuleClient.List(ctx, globalListOptions)
+// getObjectKeysByKind ignores the version, so objects applied with a version are kept when listed with another one
+func getObjectKeysByKind(managedObjects []*managedObject) map[schema.GroupKind][]string {
+	objectKeysByKind := map[schema.GroupKind][]string{}
+	for _, managedObj := range managedObjects {
+		groupKind := managedObj.kind.groupVersionKind.GroupKind()
+		objectKeysByKind[groupKind] = append(objectKeysByKind[groupKind], getObjectKey(managedObj.object.GetNamespace(), managedObj.object.GetName()))
+	}
+	return objectKeysByKind
+}
+
+// getManagedObjectsNamespaces leaves out the empty namespace of the cluster scoped extra resources
+func getManagedObjectsNamespaces(managedObjects []*managedObject) []string {
+	return lo.Compact(lo.Uniq(lo.Map(managedObjects, func(managedObj *managedObject, _ int) string { return managedObj.object.GetNamespace() })))
+}
+
+// getObjectsToApply puts the namespaces of the managed objects in front of them
+func getObjectsToApply(managedObjects []*managedObject) []*managedObject {
+	objectsToApply := lo.Map(getManagedObjectsNamespaces(managedObjects), func(namespace string, _ int) *managedObject {
+		// Applying only the Istio label means any other label or annotation in an existing namespace is preserved
+		return &managedObject{kind: namespaceKind, object: newIstioInjectedNamespace(namespace)}
+	})
+	return append(objectsToApply, managedObjects...)
+}
+
+func newIstioInjectedNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				istioLabel: enabledIstioValue,
+			},
+		},
+	}
+}
+
+// toUnstructured goes through JSON instead of the reflection based converter because the Istio specs are protobuf
+// messages with custom JSON marshalling (e.g. oneof fields)
+func toUnstructured(kind resourceKind, object metav1.Object) (*unstructured.Unstructured, error) {
+	objectBytes, err := json.Marshal(object)
 	if err != nil {
-		return stacktrace.Propagate(err, "Failed to list destination rules in namespace %s", namespace)
+		return nil, stacktrace.Propagate(err, "An error occurred marshalling object '%s'", object.GetName())
 	}
-	for _, destRule := range allDestRules.Items {
-		_, exists := lo.Find(destinationRulesToKeep, func(item v1alpha3.DestinationRule) bool { return item.Name == destRule.Name })
-		if !exists {
-			err = destRuleClient.Delete(ctx, destRule.Name, globalDeleteOptions)
-			if err != nil {
-				return stacktrace.Propagate(err, "Failed to delete destination rule %s", destRule.GetName())
-			}
-		}
+
+	unstructuredObject := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err = json.Unmarshal(objectBytes, &unstructuredObject.Object); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred unmarshalling object '%s'", object.GetName())
 	}
 
-	return nil
-}
+	// Kontrol doesn't always set the type meta, and server-side apply requires it
+	unstructuredObject.SetGroupVersionKind(kind.groupVersionKind)
 
-func (manager *ClusterManager) cleanUpGatewaysInNamespace(ctx context.Context, namespace string, gatewaysToKeep []v1alpha3.Gateway) error {
+	// Server-side apply rejects server populated fields like managedFields and the status is owned by the controllers
+	removeServerPopulatedFields(unstructuredObject)
 
-	gatewayClient := manager.istioClient.clientSet.NetworkingV1alpha3().Gateways(namespace)
-	allGateways, err := gatewayClient.List(ctx, globalListOptions)
+	desiredStateHash, err := getDesiredStateHash(unstructuredObject)
 	if err != nil {
-		return stacktrace.Propagate(err, "Failed to list gateways in namespace %s", namespace)
+		return nil, stacktrace.Propagate(err, "An error occurred computing the desired state hash of object '%s'", object.GetName())
 	}
-	for _, gateway := range allGateways.Items {
-		_, exists := lo.Find(gatewaysToKeep, func(item v1alpha3.Gateway) bool { return item.Name == gateway.Name })
-		if !exists {
-			err = gatewayClient.Delete(ctx, gateway.Name, globalDeleteOptions)
-			if err != nil {
-				return stacktrace.Propagate(err, "Failed to delete gateway %s", gateway.GetName())
-			}
-		}
+	objectAnnotations := unstructuredObject.GetAnnotations()
+	if objectAnnotations == nil {
+		objectAnnotations = map[string]string{}
 	}
+	objectAnnotations[desiredStateHashAnnotationKey] = desiredStateHash
+	unstructuredObject.SetAnnotations(objectAnnotations)
 
-	return nil
+	return unstructuredObject, nil
+}
+
+// getDesiredStateHash has to be called before the hash annotation is set, so it's not part of the hashed content
+func getDesiredStateHash(unstructuredObject *unstructured.Unstructured) (string, error) {
+	// The JSON encoder sorts the map keys so the same desired state always produces the same hash
+	objectBytes, err := json.Marshal(unstructuredObject.Object)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred marshalling object '%s'", unstructuredObject.GetName())
+	}
+
+	hash := sha256.Sum256(objectBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// isUpToDate compares the desired state hashes, so objects whose desired state didn't change since the last apply
+// are not written again. The drift in the fields Kardinal owns is only corrected by the full resyncs, which skip this
+// check, and the drift in the rest of the fields is intentionally not corrected
+func isUpToDate(liveObject *unstructured.Unstructured, desiredObject *unstructured.Unstructured) bool {
+	if liveObject == nil {
+		return false
+	}
+	liveHash, found := liveObject.GetAnnotations()[desiredStateHashAnnotationKey]
+	return found && liveHash == desiredObject.GetAnnotations()[desiredStateHashAnnotationKey]
+}
+
+// isUnchangedByApply tells whether the apply was a no-op, the API server only bumps the resource version when it
+// changes the object
+func isUnchangedByApply(liveObject *unstructured.Unstructured, appliedObject *unstructured.Unstructured) bool {
+	return liveObject != nil && appliedObject != nil && liveObject.GetResourceVersion() == appliedObject.GetResourceVersion()
+}
+
+func removeServerPopulatedFields(unstructuredObject *unstructured.Unstructured) {
+	unstructuredObject.SetResourceVersion("")
+	unstructuredObject.SetUID("")
+	unstructuredObject.SetGeneration(0)
+	unstructuredObject.SetManagedFields(nil)
+	unstructured.RemoveNestedField(unstructuredObject.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(unstructuredObject.Object, "status")
 }
 
 func int64Ptr(i int64) *int64 { return &i }
 
+// removeSubsetDestinations removes the destinations and the mirrors pointing to the subset of the host, and the routes
+// left without destinations. The weights of the destinations left in a route are scaled back up to 100
+func removeSubsetDestinations(httpRoutes []*istio.HTTPRoute, namespace string, host string, subsetName string) ([]*istio.HTTPRoute, bool) {
+	fullyQualifiedHost := getFullyQualifiedHost(host, namespace)
+	wasPruned := false
+
+	var prunedHTTPRoutes []*istio.HTTPRoute
+	for _, httpRoute := range httpRoutes {
+		if mirror := httpRoute.GetMirror(); mirror != nil && mirror.GetSubset() == subsetName &&
+			getFullyQualifiedHost(mirror.GetHost(), namespace) == fullyQualifiedHost {
+			httpRoute.Mirror = nil
+			httpRoute.MirrorPercentage = nil
+			wasPruned = true
+		}
+
+		destinations := lo.Filter(httpRoute.GetRoute(), func(destination *istio.HTTPRouteDestination, _ int) bool {
+			return destination.GetDestination().GetSubset() != subsetName ||
+				getFullyQualifiedHost(destination.GetDestination().GetHost(), namespace) != fullyQualifiedHost
+		})
+		if len(destinations) == len(httpRoute.GetRoute()) {
+			prunedHTTPRoutes = append(prunedHTTPRoutes, httpRoute)
+			continue
+		}
+
+		wasPruned = true
+		if len(destinations) == 0 {
+			continue
+		}
+		rescaleWeights(destinations)
+		httpRoute.Route = destinations
+		prunedHTTPRoutes = append(prunedHTTPRoutes, httpRoute)
+	}
+	return prunedHTTPRoutes, wasPruned
+}
+
+func rescaleWeights(destinations []*istio.HTTPRouteDestination) {
+	totalWeight := lo.SumBy(destinations, func(destination *istio.HTTPRouteDestination) int32 {
+		return destination.GetWeight()
+	})
+	if totalWeight == 0 {
+		return
+	}
+
+	remainingWeight := int32(100)
+	for index, destination := range destinations {
+		if index == len(destinations)-1 {
+			destination.Weight = remainingWeight
+			continue
+		}
+		destination.Weight = destination.GetWeight() * 100 / totalWeight
+		remainingWeight -= destination.Weight
+	}
+}
+
+// getFullyQualifiedHost expands the short service names, which Istio resolves in the namespace of the rule
+func getFullyQualifiedHost(host string, namespace string) string {
+	switch strings.Count(host, ".") {
+	case 0:
+		return fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace)
+	case 1:
+		return fmt.Sprintf("%s.svc.cluster.local", host)
+	default:
+		return host
+	}
+}
+
 func isValid(clusterResources *types.ClusterResources) bool {
 	if clusterResources == nil {
 		logrus.Debugf("cluster resources is nil.")
@@ -524,10 +989,19 @@ func isValid(clusterResources *types.ClusterResources) bool {
 	}
 
 	if clusterResources.Gateway == nil &&
+		clusterResources.Gateways == nil &&
 		clusterResources.Deployments == nil &&
 		clusterResources.DestinationRules == nil &&
 		clusterResources.Services == nil &&
-		clusterResources.VirtualServices == nil {
+		clusterResources.VirtualServices == nil &&
+		clusterResources.ConfigMaps == nil &&
+		clusterResources.Secrets == nil &&
+		clusterResources.ServiceAccounts == nil &&
+		clusterResources.PersistentVolumeClaims == nil &&
+		clusterResources.StatefulSets == nil &&
+		clusterResources.Jobs == nil &&
+		clusterResources.CronJobs == nil &&
+		clusterResources.ExtraResources == nil {
 		logrus.Debugf("cluster resources is empty.")
 		return false
 	}
@@ -12,9 +12,10 @@ import (
 	"k8s.io/client-go/util/homedir"
 	"kardinal.kontrol/kardinal-manager/topology"
 	"path/filepath"
+	"time"
 )
 
-func CreateClusterManager() (*ClusterManager, error) {
+func CreateClusterManager(tenantUuid string, forceApplyOwnership bool, readinessTimeout time.Duration) (*ClusterManager, error) {
 	kubernetesClientObj, err := createKubernetesClient()
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred while creating the Kubernetes client")
@@ -25,12 +26,25 @@ func CreateClusterManager() (*ClusterManager, error) {
 		return nil, stacktrace.Propagate(err, "An error occurred while creating the Istio client")
 	}
 
-	return NewClusterManager(kubernetesClientObj, istioClientObj), nil
+	return NewClusterManager(kubernetesClientObj, istioClientObj, tenantUuid, forceApplyOwnership, readinessTimeout), nil
 }
 
-func createKubernetesClient() (*kubernetesClient, error) {
-	var config *rest.Config
+// CreateKubernetesClientSet is for the components which talk to the cluster on their own, like the leader election
+func CreateKubernetesClientSet() (kubernetes.Interface, error) {
+	config, err := getKubernetesConfig()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the kubernetes client config")
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred while creating kubernetes client using config '%+v'", config)
+	}
 
+	return clientSet, nil
+}
+
+func getKubernetesConfig() (*rest.Config, error) {
 	// Load in-cluster configuration
 	config, err := rest.InClusterConfig()
 	if err != nil {
@@ -43,6 +57,15 @@ func createKubernetesClient() (*kubernetesClient, error) {
 		}
 	}
 
+	return config, nil
+}
+
+func createKubernetesClient() (*kubernetesClient, error) {
+	config, err := getKubernetesConfig()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the kubernetes client config")
+	}
+
 	clientSet, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred while creating kubernetes client using config '%+v'", config)
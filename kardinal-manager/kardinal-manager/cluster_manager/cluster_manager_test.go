@@ -2,13 +2,28 @@ package cluster_manager
 
 import (
 	"context"
+	"errors"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"testing"
 )
 
 const (
-	defaultNamespace = "default"
+	defaultNamespace         = "default"
+	doNotForceApplyOwnership = false
+	testTenantUuid           = "00000000-0000-0000-0000-000000000000"
+	noReadinessTimeout       = 0
+	doNotFullResync          = false
+	fullResync               = true
 )
 
 func TestClusterManager_GetVirtualServices(t *testing.T) {
@@ -69,6 +84,7 @@ func TestClusterManagerWorkflows(t *testing.T) {
 
 	// add a routing rule that splits traffic between v1 and v2
 	splitTraffic5050Rule := &istio.HTTPRoute{
+		Name: "split-traffic-50-50",
 		Route: []*istio.HTTPRouteDestination{
 			{
 				Destination: &istio.Destination{
@@ -89,13 +105,237 @@ func TestClusterManagerWorkflows(t *testing.T) {
 		},
 	}
 	// can consider adjusting the AddRoutingRule api to only take in params we care about to make the api easier to use but again for now, KISS till we know more about use cases
-	err = clusterManager.AddRoutingRule(ctx, defaultNamespace, "reviews", splitTraffic5050Rule)
+	err = clusterManager.AddRoutingRule(ctx, defaultNamespace, "reviews", splitTraffic5050Rule, 0)
+}
+
+func TestToUnstructured_KeepsIstioSpecAndRemovesServerPopulatedFields(t *testing.T) {
+	virtualService := &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "reviews",
+			Namespace:       defaultNamespace,
+			ResourceVersion: "1234",
+			UID:             "a-uid",
+		},
+		Spec: istio.VirtualService{
+			Hosts: []string{"reviews"},
+			Http: []*istio.HTTPRoute{
+				{
+					Match: []*istio.HTTPMatchRequest{
+						{
+							Headers: map[string]*istio.StringMatch{
+								"x-kardinal-flow": {MatchType: &istio.StringMatch_Exact{Exact: "dev"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	unstructuredObject, err := toUnstructured(virtualServiceKind, virtualService)
+	require.NoError(t, err)
+
+	require.Equal(t, virtualServiceKind.groupVersionKind, unstructuredObject.GroupVersionKind())
+	require.Equal(t, "reviews", unstructuredObject.GetName())
+	require.Empty(t, unstructuredObject.GetResourceVersion())
+	require.Empty(t, unstructuredObject.GetUID())
+
+	_, found, err := unstructured.NestedFieldNoCopy(unstructuredObject.Object, "metadata", "creationTimestamp")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, found, err = unstructured.NestedFieldNoCopy(unstructuredObject.Object, "status")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	routes, found, err := unstructured.NestedSlice(unstructuredObject.Object, "spec", "http")
+	require.NoError(t, err)
+	require.True(t, found)
+	headerMatch, found, err := unstructured.NestedString(routes[0].(map[string]interface{})["match"].([]interface{})[0].(map[string]interface{}), "headers", "x-kardinal-flow", "exact")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "dev", headerMatch)
+}
+
+func TestIsConflictWithOwnUpdates_OnlyWhenEveryFieldIsOwnedByTheManagerUpdates(t *testing.T) {
+	ownUpdateConflict := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "kardinal-manager" using networking.istio.io/v1alpha3`,
+		Field:   ".spec.http",
+	}
+	otherManagerConflict := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "kubectl-edit" using networking.istio.io/v1alpha3`,
+		Field:   ".spec.hosts",
+	}
+
+	require.True(t, isConflictWithOwnUpdates(apierrors.NewApplyConflict([]metav1.StatusCause{ownUpdateConflict}, "Apply failed with 1 conflict")))
+	require.False(t, isConflictWithOwnUpdates(apierrors.NewApplyConflict([]metav1.StatusCause{ownUpdateConflict, otherManagerConflict}, "Apply failed with 2 conflicts")))
+	require.False(t, isConflictWithOwnUpdates(apierrors.NewApplyConflict(nil, "Apply failed")))
+	require.False(t, isConflictWithOwnUpdates(apierrors.NewBadRequest("invalid virtual service")))
+}
+
+func TestIsUpToDate_OnlyWhenTheDesiredStateHashMatches(t *testing.T) {
+	desiredObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	require.False(t, isUpToDate(nil, desiredObject))
+
+	liveObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	liveObject.SetResourceVersion("1234")
+	require.True(t, isUpToDate(liveObject, desiredObject))
+
+	changedDesiredObject := getUnstructuredServiceForTesting(t, "reviews", 9081)
+	require.NotEqual(t, desiredObject.GetAnnotations()[desiredStateHashAnnotationKey], changedDesiredObject.GetAnnotations()[desiredStateHashAnnotationKey])
+	require.False(t, isUpToDate(liveObject, changedDesiredObject))
+
+	liveObject.SetAnnotations(nil)
+	require.False(t, isUpToDate(liveObject, desiredObject))
+}
+
+func TestIsUnchangedByApply_OnlyWhenTheResourceVersionDidNotChange(t *testing.T) {
+	appliedObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	appliedObject.SetResourceVersion("1234")
+	require.False(t, isUnchangedByApply(nil, appliedObject))
+
+	liveObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	liveObject.SetResourceVersion("1234")
+	require.True(t, isUnchangedByApply(liveObject, appliedObject))
+
+	appliedObject.SetResourceVersion("1235")
+	require.False(t, isUnchangedByApply(liveObject, appliedObject))
+}
+
+func TestGetKindsToCleanUp_IncludesExtraResourceKindsOnlyWhenResolved(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+	manager.extraResourceKinds[rolloutKind.groupVersionKind.GroupKind()] = rolloutKind
+	// Extra resources of a kind already managed by Kardinal are cleaned up only once
+	manager.extraResourceKinds[serviceKind.groupVersionKind.GroupKind()] = serviceKind
+
+	require.Equal(t, managedKinds, manager.getKindsToCleanUp(nil, false))
+	require.Equal(t, append(append([]resourceKind{}, managedKinds...), rolloutKind), manager.getKindsToCleanUp(nil, true))
+
+	// The given extra resource kinds are cleaned up even before being recorded
+	analysisTemplateKind := newResourceKind("argoproj.io", "v1alpha1", "AnalysisTemplate", "analysistemplates", isNamespaced)
+	require.Equal(t, managedKinds, manager.getKindsToCleanUp([]resourceKind{analysisTemplateKind}, false))
+	require.Equal(t, append(append([]resourceKind{}, managedKinds...), rolloutKind, analysisTemplateKind), manager.getKindsToCleanUp([]resourceKind{analysisTemplateKind}, true))
+}
+
+func TestRecordExtraResourceKinds_OnlyRecordsTheKindsOutsideTheManagedOnes(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+
+	manager.recordExtraResourceKinds([]resourceKind{rolloutKind, serviceKind})
+
+	require.Equal(t, map[schema.GroupKind]resourceKind{rolloutKind.groupVersionKind.GroupKind(): rolloutKind}, manager.extraResourceKinds)
+}
+
+func TestDiscoverExtraResourceKinds_OnlyFindsTheKindsWithObjectsOwnedByTheTenant(t *testing.T) {
+	ctx := context.Background()
+
+	manager := newFakeAPIServerForTesting(t, newManagedRolloutForTesting("reviews", testTenantUuid)).newClusterManager(t)
+	discoveredKinds, err := manager.discoverExtraResourceKinds(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []resourceKind{rolloutKind}, discoveredKinds)
+
+	manager = newFakeAPIServerForTesting(t, newManagedRolloutForTesting("reviews", "another-tenant")).newClusterManager(t)
+	discoveredKinds, err = manager.discoverExtraResourceKinds(ctx)
+	require.NoError(t, err)
+	require.Empty(t, discoveredKinds)
+}
+
+func TestCleanUpClusterResources_CleansUpTheExtraResourcesAppliedBeforeARestartOnFullResync(t *testing.T) {
+	ctx := context.Background()
+	fakeServer := newFakeAPIServerForTesting(t, newManagedRolloutForTesting("ratings", testTenantUuid))
+	clusterResources := &types.ClusterResources{
+		Services: &[]corev1.Service{*newServiceForTesting("reviews", defaultNamespace, map[string]string{"app": "reviews"})},
+	}
+
+	// A manager which just started doesn't know the kinds of the extra resources applied before
+	manager := fakeServer.newClusterManager(t)
+	require.NoError(t, manager.CleanUpClusterResources(ctx, clusterResources, doNotFullResync))
+	require.Empty(t, fakeServer.getDeletedPaths())
+
+	require.NoError(t, manager.CleanUpClusterResources(ctx, clusterResources, fullResync))
+	require.Equal(t, []string{"/apis/argoproj.io/v1alpha1/namespaces/default/rollouts/ratings"}, fakeServer.getDeletedPaths())
+}
+
+func TestIsForbiddenExtraResource_OnlyForTheKindsOutsideTheClusterRole(t *testing.T) {
+	forbiddenErr := stacktrace.Propagate(apierrors.NewForbidden(rolloutKind.groupVersionResource.GroupResource(), "reviews", errors.New("no RBAC policy matched")), "wrapped")
+
+	require.True(t, isForbiddenExtraResource(rolloutKind, forbiddenErr))
+	require.False(t, isForbiddenExtraResource(rolloutKind, stacktrace.NewError("connection refused")))
+	// The ClusterRole always grants access to the managed kinds, even when they are sent as extra resources
+	require.False(t, isForbiddenExtraResource(serviceKind, forbiddenErr))
+
+	require.Equal(t, "rollouts.argoproj.io", rolloutKind.getRBACResourceName())
+	require.Equal(t, "services", serviceKind.getRBACResourceName())
+	require.Contains(t, getForbiddenExtraResourceHint(rolloutKind), "kardinal manager deploy --extra-resource rollouts.argoproj.io")
+}
+
+func TestGetManagedObjects_MergesTheDeprecatedGatewayWithTheGateways(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+
+	clusterResources := &types.ClusterResources{
+		Gateway: &v1alpha3.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: defaultNamespace}},
+		Gateways: &[]v1alpha3.Gateway{
+			{ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: defaultNamespace}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: defaultNamespace}},
+		},
+	}
+
+	managedObjects, err := manager.getManagedObjects(clusterResources)
+	require.NoError(t, err)
+	require.Equal(t, []string{"default/public", "default/grpc"}, getObjectKeysByKind(managedObjects)[gatewayKind.groupVersionKind.GroupKind()])
+
+	clusterResources.Gateway = &v1alpha3.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: defaultNamespace}}
+
+	managedObjects, err = manager.getManagedObjects(clusterResources)
+	require.NoError(t, err)
+	require.Equal(t, []string{"default/public", "default/grpc", "default/internal"}, getObjectKeysByKind(managedObjects)[gatewayKind.groupVersionKind.GroupKind()])
+	for _, managedObj := range managedObjects {
+		require.True(t, manager.isOwnedByTenant(managedObj.object))
+	}
+}
+
+func TestGetManagedObjects_PublishesTheVirtualServicesLast(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+
+	clusterResources := &types.ClusterResources{
+		VirtualServices:  &[]v1alpha3.VirtualService{{ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: defaultNamespace}}},
+		DestinationRules: &[]v1alpha3.DestinationRule{{ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: defaultNamespace}}},
+		Services:         &[]corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: defaultNamespace}}},
+	}
+
+	managedObjects, err := manager.getManagedObjects(clusterResources)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Service", "DestinationRule", "VirtualService"}, lo.Map(managedObjects, func(managedObj *managedObject, _ int) string {
+		return managedObj.kind.groupVersionKind.Kind
+	}))
+}
+
+func TestStampOwnership_OnlyObjectsOfTheSameTenantAreOwned(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+	otherTenantManager := NewClusterManager(nil, nil, "11111111-1111-1111-1111-111111111111", doNotForceApplyOwnership, noReadinessTimeout)
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews",
+			Namespace: defaultNamespace,
+			Labels:    map[string]string{"app": "reviews"},
+		},
+	}
+	require.False(t, manager.isOwnedByTenant(service))
+
+	manager.stampOwnership(service)
+
+	require.Equal(t, "reviews", service.GetLabels()["app"])
+	require.Equal(t, kardinalManagedByLabelValue, service.GetLabels()[kardinalManagedByLabelKey])
+	require.True(t, manager.isOwnedByTenant(service))
+	require.False(t, otherTenantManager.isOwnedByTenant(service))
 }
 
 // Note: test will only work if kubeconfig is available locally and a cluster is running
 // these code is meant for local iteration for now and less for unit testing
 func getClusterManagerForTesting(t *testing.T) (*ClusterManager, error) {
-	clusterManager, err := CreateClusterManager()
+	clusterManager, err := CreateClusterManager(testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
 	require.NoError(t, err)
 	return clusterManager, nil
 }
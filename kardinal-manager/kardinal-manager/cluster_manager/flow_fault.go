@@ -0,0 +1,100 @@
+package cluster_manager
+
+import (
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/durationpb"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"net/http"
+	"time"
+)
+
+const (
+	maxFaultPercentage = 100
+
+	// The abort HTTP status range accepted by the Kontrol APIs
+	minFaultAbortHTTPStatus = http.StatusBadRequest
+	maxFaultAbortHTTPStatus = 599
+)
+
+// FlowFault delays, aborts or both the percentage of the requests of a flow. The delay is skipped when it's 0 and the
+// abort when its HTTP status is 0
+type FlowFault struct {
+	Delay           time.Duration
+	AbortHTTPStatus int32
+	Percentage      float64
+}
+
+func NewFlowFault(delay time.Duration, abortHTTPStatus int32, percentage float64) *FlowFault {
+	return &FlowFault{
+		Delay:           delay,
+		AbortHTTPStatus: abortHTTPStatus,
+		Percentage:      percentage,
+	}
+}
+
+func NewHTTPFaultInjection(flowFault *FlowFault) (*istio.HTTPFaultInjection, error) {
+	if flowFault.Delay == 0 && flowFault.AbortHTTPStatus == 0 {
+		return nil, stacktrace.NewError("The fault needs a delay, an abort HTTP status or both")
+	}
+	if flowFault.Delay < 0 {
+		return nil, stacktrace.NewError("The fault delay can't be negative, got %s", flowFault.Delay)
+	}
+	if flowFault.AbortHTTPStatus != 0 && (flowFault.AbortHTTPStatus < minFaultAbortHTTPStatus || flowFault.AbortHTTPStatus > maxFaultAbortHTTPStatus) {
+		return nil, stacktrace.NewError("The fault abort HTTP status must be between %d and %d, got %d", minFaultAbortHTTPStatus, maxFaultAbortHTTPStatus, flowFault.AbortHTTPStatus)
+	}
+	if flowFault.Percentage < 0 || flowFault.Percentage > maxFaultPercentage {
+		return nil, stacktrace.NewError("The fault percentage must be between 0 and %d, got %v", maxFaultPercentage, flowFault.Percentage)
+	}
+
+	faultInjection := &istio.HTTPFaultInjection{}
+	if flowFault.Delay > 0 {
+		faultInjection.Delay = &istio.HTTPFaultInjection_Delay{
+			HttpDelayType: &istio.HTTPFaultInjection_Delay_FixedDelay{FixedDelay: durationpb.New(flowFault.Delay)},
+			Percentage:    &istio.Percent{Value: flowFault.Percentage},
+		}
+	}
+	if flowFault.AbortHTTPStatus != 0 {
+		faultInjection.Abort = &istio.HTTPFaultInjection_Abort{
+			ErrorType:  &istio.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: flowFault.AbortHTTPStatus},
+			Percentage: &istio.Percent{Value: flowFault.Percentage},
+		}
+	}
+	return faultInjection, nil
+}
+
+// addFlowFault injects the fault into the routing rule of the flow in the VirtualService it targets, so only the
+// requests of the flow are affected. Kontrol removes the fault by leaving it out
+func addFlowFault(virtualServices map[string]*v1alpha3.VirtualService, flowFault types.FlowFault) error {
+	virtualService, err := getTargetVirtualService(virtualServices, flowFault.Namespace, flowFault.VirtualService)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the VirtualService of the fault of flow '%s'", flowFault.FlowId)
+	}
+
+	var delay time.Duration
+	if flowFault.Delay != nil {
+		if delay, err = time.ParseDuration(*flowFault.Delay); err != nil {
+			return stacktrace.Propagate(err, "An error occurred parsing the fault delay '%s' of flow '%s'", *flowFault.Delay, flowFault.FlowId)
+		}
+	}
+	abortHTTPStatus := int32(lo.FromPtr(flowFault.AbortHttpStatus))
+	percentage := float64(lo.FromPtrOr(flowFault.Percentage, maxFaultPercentage))
+
+	faultInjection, err := NewHTTPFaultInjection(NewFlowFault(delay, abortHTTPStatus, percentage))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred building the fault injection of flow '%s'", flowFault.FlowId)
+	}
+
+	ruleName := getFlowRoutingRuleName(flowFault.FlowId)
+	routingRule, found := lo.Find(virtualService.Spec.GetHttp(), func(httpRoute *istio.HTTPRoute) bool {
+		return httpRoute.GetName() == ruleName
+	})
+	if !found {
+		return stacktrace.Propagate(apierrors.NewNotFound(routingRuleResource, ruleName), "VirtualService '%s' doesn't have the routing rule of flow '%s'", virtualService.GetName(), flowFault.FlowId)
+	}
+	routingRule.Fault = faultInjection
+	return nil
+}
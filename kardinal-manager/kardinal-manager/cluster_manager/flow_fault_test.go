@@ -0,0 +1,79 @@
+package cluster_manager
+
+import (
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPFaultInjection_DelaysAndAbortsThePercentage(t *testing.T) {
+	faultInjection, err := NewHTTPFaultInjection(NewFlowFault(2*time.Second, 503, 10))
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, faultInjection.GetDelay().GetFixedDelay().AsDuration())
+	require.Equal(t, float64(10), faultInjection.GetDelay().GetPercentage().GetValue())
+	require.Equal(t, int32(503), faultInjection.GetAbort().GetHttpStatus())
+	require.Equal(t, float64(10), faultInjection.GetAbort().GetPercentage().GetValue())
+
+	faultInjection, err = NewHTTPFaultInjection(NewFlowFault(0, 503, 100))
+	require.NoError(t, err)
+	require.Nil(t, faultInjection.GetDelay())
+
+	_, err = NewHTTPFaultInjection(NewFlowFault(0, 0, 100))
+	require.Error(t, err)
+	_, err = NewHTTPFaultInjection(NewFlowFault(0, 42, 100))
+	require.Error(t, err)
+	// An abort only injects error responses
+	_, err = NewHTTPFaultInjection(NewFlowFault(0, 200, 100))
+	require.Error(t, err)
+	_, err = NewHTTPFaultInjection(NewFlowFault(0, 302, 100))
+	require.Error(t, err)
+	_, err = NewHTTPFaultInjection(NewFlowFault(0, 600, 100))
+	require.Error(t, err)
+	_, err = NewHTTPFaultInjection(NewFlowFault(time.Second, 0, 150))
+	require.Error(t, err)
+}
+
+func TestAddTrafficRoutingRules_OnlyInjectsTheFaultIntoTheRoutingRuleOfTheFlow(t *testing.T) {
+	virtualService := newReviewsVirtualService()
+	for _, flowID := range []string{"dev-1", "dev-2"} {
+		flowRoutingRule, err := NewFlowRoutingRule(NewFlowRouting(flowID, subsetTestName, "dev"))
+		require.NoError(t, err)
+		require.NoError(t, setRoutingRule(virtualService, flowRoutingRule, FlowRoutingRulePriority))
+	}
+	managedObjects := []*managedObject{{kind: virtualServiceKind, object: virtualService}}
+	clusterResources := &types.ClusterResources{
+		FlowFaults: &[]types.FlowFault{
+			{Namespace: subsetTestNamespace, VirtualService: subsetTestName, FlowId: "dev-1", Delay: lo.ToPtr("2s"), AbortHttpStatus: lo.ToPtr(599)},
+		},
+	}
+
+	require.NoError(t, addTrafficRoutingRules(managedObjects, clusterResources))
+
+	desiredVirtualService, ok := managedObjects[0].object.(*v1alpha3.VirtualService)
+	require.True(t, ok)
+	for _, httpRoute := range desiredVirtualService.Spec.GetHttp() {
+		if httpRoute.GetName() == "flow-dev-1" {
+			require.Equal(t, 2*time.Second, httpRoute.GetFault().GetDelay().GetFixedDelay().AsDuration())
+			require.Equal(t, int32(599), httpRoute.GetFault().GetAbort().GetHttpStatus())
+			require.Equal(t, float64(100), httpRoute.GetFault().GetAbort().GetPercentage().GetValue())
+		} else {
+			require.Nil(t, httpRoute.GetFault(), "route '%s' shouldn't have a fault", httpRoute.GetName())
+		}
+	}
+}
+
+func TestAddTrafficRoutingRules_FailsWhenTheFlowDoesNotExist(t *testing.T) {
+	managedObjects := []*managedObject{{kind: virtualServiceKind, object: newReviewsVirtualService()}}
+	clusterResources := &types.ClusterResources{
+		FlowFaults: &[]types.FlowFault{
+			{Namespace: subsetTestNamespace, VirtualService: subsetTestName, FlowId: "missing", AbortHttpStatus: lo.ToPtr(503)},
+		},
+	}
+
+	err := addTrafficRoutingRules(managedObjects, clusterResources)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "VirtualService 'reviews' doesn't have the routing rule of flow 'missing'")
+}
@@ -0,0 +1,155 @@
+package cluster_manager
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/stacktrace"
+	istio "istio.io/api/networking/v1alpha3"
+	"math"
+	"regexp"
+)
+
+const (
+	DefaultFlowHeaderName = "x-kardinal-flow"
+
+	// The flow routing rules beat any rule with the default priority, and the default route is evaluated after every
+	// other routing rule
+	FlowRoutingRulePriority  int32 = 100
+	defaultRouteRulePriority int32 = math.MinInt32
+
+	flowRoutingRuleNamePrefix = "flow-"
+	defaultRouteRuleName      = "kardinal-default-route"
+	cookieHeaderName          = "cookie"
+)
+
+// FlowRouting sends the requests of a flow to a subset of a service. A request belongs to the flow when it carries the
+// flow ID in any of the header, cookie or query parameter set, the ones left empty aren't matched
+type FlowRouting struct {
+	FlowID string
+	Host   string
+	Subset string
+
+	HeaderName     string
+	CookieName     string
+	QueryParamName string
+}
+
+// NewFlowRouting recognizes the requests of the flow by the default flow header
+func NewFlowRouting(flowID string, host string, subset string) *FlowRouting {
+	return &FlowRouting{
+		FlowID:         flowID,
+		Host:           host,
+		Subset:         subset,
+		HeaderName:     DefaultFlowHeaderName,
+		CookieName:     "",
+		QueryParamName: "",
+	}
+}
+
+// NewFlowRoutingRule builds the routing rule of the flow, with one match per way of recognizing its requests since
+// Istio ORs the matches of a route
+func NewFlowRoutingRule(flowRouting *FlowRouting) (*istio.HTTPRoute, error) {
+	if flowRouting.FlowID == "" {
+		return nil, stacktrace.NewError("The flow routing needs a flow ID")
+	}
+	if flowRouting.Host == "" {
+		return nil, stacktrace.NewError("The routing of flow '%s' needs a host", flowRouting.FlowID)
+	}
+
+	var matches []*istio.HTTPMatchRequest
+	if flowRouting.HeaderName != "" {
+		matches = append(matches, &istio.HTTPMatchRequest{
+			Headers: map[string]*istio.StringMatch{
+				flowRouting.HeaderName: {MatchType: &istio.StringMatch_Exact{Exact: flowRouting.FlowID}},
+			},
+		})
+	}
+	if flowRouting.CookieName != "" {
+		matches = append(matches, &istio.HTTPMatchRequest{
+			Headers: map[string]*istio.StringMatch{
+				cookieHeaderName: {MatchType: &istio.StringMatch_Regex{Regex: getCookieRegex(flowRouting.CookieName, flowRouting.FlowID)}},
+			},
+		})
+	}
+	if flowRouting.QueryParamName != "" {
+		matches = append(matches, &istio.HTTPMatchRequest{
+			QueryParams: map[string]*istio.StringMatch{
+				flowRouting.QueryParamName: {MatchType: &istio.StringMatch_Exact{Exact: flowRouting.FlowID}},
+			},
+		})
+	}
+	if len(matches) == 0 {
+		return nil, stacktrace.NewError("The routing of flow '%s' needs a header, a cookie or a query parameter to match", flowRouting.FlowID)
+	}
+
+	return &istio.HTTPRoute{
+		Name:  getFlowRoutingRuleName(flowRouting.FlowID),
+		Match: matches,
+		Route: []*istio.HTTPRouteDestination{
+			{
+				Destination: &istio.Destination{
+					Host:   flowRouting.Host,
+					Subset: flowRouting.Subset,
+				},
+			},
+		},
+	}, nil
+}
+
+// AddFlowRoutingRule adds the routing rule of the flow with FlowRoutingRulePriority, so it's evaluated before the
+// default route
+func (manager *ClusterManager) AddFlowRoutingRule(ctx context.Context, namespace string, vsName string, flowRouting *FlowRouting) error {
+	routingRule, err := NewFlowRoutingRule(flowRouting)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred building the routing rule of flow '%s'", flowRouting.FlowID)
+	}
+	if err = manager.AddRoutingRule(ctx, namespace, vsName, routingRule, FlowRoutingRulePriority); err != nil {
+		return stacktrace.Propagate(err, "An error occurred adding the routing rule of flow '%s'", flowRouting.FlowID)
+	}
+	return nil
+}
+
+func (manager *ClusterManager) RemoveFlowRoutingRule(ctx context.Context, namespace string, vsName string, flowID string) error {
+	if err := manager.RemoveRoutingRule(ctx, namespace, vsName, getFlowRoutingRuleName(flowID)); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing the routing rule of flow '%s'", flowID)
+	}
+	return nil
+}
+
+// SetDefaultRoute sends the requests which don't belong to any flow to the subset, usually the prod one. It's added
+// as the last routing rule, or replaces the current default route
+func (manager *ClusterManager) SetDefaultRoute(ctx context.Context, namespace string, vsName string, host string, subset string) error {
+	defaultRoute := newDefaultRoute(host, subset)
+
+	err := manager.updateRoutingRules(ctx, namespace, vsName, func(httpRoutes []*istio.HTTPRoute, priorities map[string]int32) ([]*istio.HTTPRoute, bool, error) {
+		httpRoutes, _ = removeRoutingRule(httpRoutes, priorities, defaultRouteRuleName)
+		return insertRoutingRule(httpRoutes, priorities, defaultRoute, defaultRouteRulePriority), true, nil
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the default route of virtual service '%s' to subset '%s'", vsName, subset)
+	}
+	return nil
+}
+
+func newDefaultRoute(host string, subset string) *istio.HTTPRoute {
+	return &istio.HTTPRoute{
+		Name: defaultRouteRuleName,
+		Route: []*istio.HTTPRouteDestination{
+			{
+				Destination: &istio.Destination{
+					Host:   host,
+					Subset: subset,
+				},
+			},
+		},
+	}
+}
+
+func getFlowRoutingRuleName(flowID string) string {
+	return flowRoutingRuleNamePrefix + flowID
+}
+
+// getCookieRegex matches the cookie header carrying the cookie with the value among any other cookies
+func getCookieRegex(cookieName string, value string) string {
+	return fmt.Sprintf(`^(.*;\s*)?%s=%s(;.*)?$`, regexp.QuoteMeta(cookieName), regexp.QuoteMeta(value))
+}
@@ -0,0 +1,62 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestNewFlowRoutingRule_MatchesTheHeaderTheCookieAndTheQueryParam(t *testing.T) {
+	flowRouting := NewFlowRouting("dev-123", "reviews", "dev-123")
+	flowRouting.CookieName = "kardinal-flow"
+	flowRouting.QueryParamName = "flow"
+
+	routingRule, err := NewFlowRoutingRule(flowRouting)
+	require.NoError(t, err)
+	require.Equal(t, "flow-dev-123", routingRule.GetName())
+	require.Equal(t, "dev-123", routingRule.GetRoute()[0].GetDestination().GetSubset())
+
+	matches := routingRule.GetMatch()
+	require.Len(t, matches, 3)
+	require.Equal(t, "dev-123", matches[0].GetHeaders()[DefaultFlowHeaderName].GetExact())
+	require.Equal(t, "dev-123", matches[2].GetQueryParams()["flow"].GetExact())
+
+	cookieRegex := regexp.MustCompile(matches[1].GetHeaders()[cookieHeaderName].GetRegex())
+	require.True(t, cookieRegex.MatchString("kardinal-flow=dev-123"))
+	require.True(t, cookieRegex.MatchString("session=abc; kardinal-flow=dev-123; theme=dark"))
+	require.False(t, cookieRegex.MatchString("kardinal-flow=dev-1234"))
+	require.False(t, cookieRegex.MatchString("other-kardinal-flow=dev-123"))
+}
+
+func TestNewFlowRoutingRule_RejectsInvalidFlowRoutings(t *testing.T) {
+	_, err := NewFlowRoutingRule(NewFlowRouting("", "reviews", "dev"))
+	require.Error(t, err)
+
+	_, err = NewFlowRoutingRule(NewFlowRouting("dev", "", "dev"))
+	require.Error(t, err)
+
+	_, err = NewFlowRoutingRule(&FlowRouting{FlowID: "dev", Host: "reviews", Subset: "dev"})
+	require.Error(t, err)
+}
+
+func TestAddFlowRoutingRule_BeatsTheDefaultRoute(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	require.NoError(t, clusterManager.SetDefaultRoute(ctx, subsetTestNamespace, subsetTestName, subsetTestName, "v1"))
+	require.NoError(t, clusterManager.AddFlowRoutingRule(ctx, subsetTestNamespace, subsetTestName, NewFlowRouting("dev", subsetTestName, "dev")))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("canary-v2"), 0))
+	// Setting the default route again replaces it
+	require.NoError(t, clusterManager.SetDefaultRoute(ctx, subsetTestNamespace, subsetTestName, subsetTestName, "v2"))
+
+	// The default route goes after the routes published by Kontrol, which aren't managed, so it doesn't shadow them
+	require.Equal(t, []string{"flow-dev", "canary-v2", "dev-flow", "canary", "default", "kardinal-default-route"}, getTestRouteNames(t, clusterManager))
+
+	virtualService, err := clusterManager.GetVirtualService(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	require.Equal(t, "v2", virtualService.Spec.GetHttp()[5].GetRoute()[0].GetDestination().GetSubset())
+
+	require.NoError(t, clusterManager.RemoveFlowRoutingRule(ctx, subsetTestNamespace, subsetTestName, "dev"))
+	require.Equal(t, []string{"canary-v2", "dev-flow", "canary", "default", "kardinal-default-route"}, getTestRouteNames(t, clusterManager))
+}
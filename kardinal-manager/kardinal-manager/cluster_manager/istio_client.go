@@ -30,11 +30,11 @@ import (
 //   - updating destination rules
 
 type istioClient struct {
-	clientSet *versioned.Clientset
+	clientSet versioned.Interface
 
 	topologyManager *topology.Manager
 }
 
-func newIstioClient(clientSet *versioned.Clientset, topologyManager *topology.Manager) *istioClient {
+func newIstioClient(clientSet versioned.Interface, topologyManager *topology.Manager) *istioClient {
 	return &istioClient{clientSet: clientSet, topologyManager: topologyManager}
 }
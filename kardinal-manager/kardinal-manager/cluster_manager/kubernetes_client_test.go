@@ -0,0 +1,220 @@
+package cluster_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	fakeAPIServerCoreGroupVersion = "v1"
+	fakeAPIServerResourceVersion  = "1"
+
+	noClientRateLimit = -1
+)
+
+var (
+	// rolloutKind stands for the custom resources sent as extra resources
+	rolloutKind = newResourceKind("argoproj.io", "v1alpha1", "Rollout", "rollouts", isNamespaced)
+
+	fakeAPIServerKinds = append(append([]resourceKind{}, managedKinds...), namespaceKind, rolloutKind)
+)
+
+// fakeAPIServer serves the discovery of the managed kinds and the Argo Rollouts, answers the server-side applies with
+// the applied object, lists the live objects it's given and records the deletions, every other object is not found
+type fakeAPIServer struct {
+	server *httptest.Server
+
+	mutex        sync.Mutex
+	liveObjects  []*unstructured.Unstructured
+	deletedPaths []string
+}
+
+func newFakeAPIServerForTesting(t *testing.T, liveObjects ...*unstructured.Unstructured) *fakeAPIServer {
+	fakeServer := &fakeAPIServer{
+		server:       nil,
+		mutex:        sync.Mutex{},
+		liveObjects:  liveObjects,
+		deletedPaths: nil,
+	}
+	fakeServer.server = httptest.NewServer(http.HandlerFunc(fakeServer.serveHTTP))
+	t.Cleanup(fakeServer.server.Close)
+	return fakeServer
+}
+
+// newClusterManager returns a manager talking to the fake API server, without the Istio client nor the readiness wait
+func (fakeServer *fakeAPIServer) newClusterManager(t *testing.T) *ClusterManager {
+	// The client-side rate limiting would only slow the tests down
+	config := &rest.Config{Host: fakeServer.server.URL, QPS: noClientRateLimit}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	require.NoError(t, err)
+
+	discoveryMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientSet.Discovery()))
+
+	return NewClusterManager(newKubernetesClient(config, clientSet, dynamicClient, discoveryMapper), nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+}
+
+func (fakeServer *fakeAPIServer) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch request.URL.Path {
+	case "/api":
+		writeJSONForTesting(writer, http.StatusOK, &metav1.APIVersions{Versions: []string{fakeAPIServerCoreGroupVersion}})
+		return
+	case "/apis":
+		writeJSONForTesting(writer, http.StatusOK, getFakeAPIServerGroups())
+		return
+	}
+
+	groupVersion, pathSegments, found := splitFakeAPIServerPath(request.URL.Path)
+	if !found {
+		writeJSONForTesting(writer, http.StatusNotFound, newNotFoundStatusForTesting(request.URL.Path))
+		return
+	}
+	if len(pathSegments) == 0 {
+		writeJSONForTesting(writer, http.StatusOK, getFakeAPIServerResources(groupVersion))
+		return
+	}
+
+	// The namespaced paths look like namespaces/<namespace>/<resource>[/<name>], the namespaces themselves are cluster scoped
+	if pathSegments[0] == namespaceKind.groupVersionResource.Resource && len(pathSegments) > 2 {
+		pathSegments = pathSegments[2:]
+	}
+	resource := pathSegments[0]
+	isCollection := len(pathSegments) == 1
+
+	switch {
+	case request.Method == http.MethodGet && isCollection:
+		writeJSONForTesting(writer, http.StatusOK, fakeServer.listLiveObjects(groupVersion, resource))
+	case request.Method == http.MethodGet:
+		writeJSONForTesting(writer, http.StatusNotFound, newNotFoundStatusForTesting(request.URL.Path))
+	case request.Method == http.MethodPatch:
+		appliedObject := map[string]interface{}{}
+		if err := json.NewDecoder(request.Body).Decode(&appliedObject); err != nil {
+			writeJSONForTesting(writer, http.StatusBadRequest, &metav1.Status{Status: metav1.StatusFailure, Message: err.Error(), Code: http.StatusBadRequest})
+			return
+		}
+		object := &unstructured.Unstructured{Object: appliedObject}
+		object.SetResourceVersion(fakeAPIServerResourceVersion)
+		writeJSONForTesting(writer, http.StatusOK, object.Object)
+	case request.Method == http.MethodDelete:
+		fakeServer.mutex.Lock()
+		fakeServer.deletedPaths = append(fakeServer.deletedPaths, request.URL.Path)
+		fakeServer.mutex.Unlock()
+		writeJSONForTesting(writer, http.StatusOK, &metav1.Status{Status: metav1.StatusSuccess, Code: http.StatusOK})
+	default:
+		writeJSONForTesting(writer, http.StatusMethodNotAllowed, &metav1.Status{Status: metav1.StatusFailure, Code: http.StatusMethodNotAllowed})
+	}
+}
+
+func (fakeServer *fakeAPIServer) listLiveObjects(groupVersion string, resource string) *unstructured.UnstructuredList {
+	fakeServer.mutex.Lock()
+	defer fakeServer.mutex.Unlock()
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": groupVersion, "kind": "List"}}
+	for _, kind := range fakeAPIServerKinds {
+		if kind.groupVersionKind.GroupVersion().String() != groupVersion || kind.groupVersionResource.Resource != resource {
+			continue
+		}
+		for _, liveObject := range fakeServer.liveObjects {
+			if liveObject.GroupVersionKind() == kind.groupVersionKind {
+				list.Items = append(list.Items, *liveObject.DeepCopy())
+			}
+		}
+	}
+	return list
+}
+
+func (fakeServer *fakeAPIServer) getDeletedPaths() []string {
+	fakeServer.mutex.Lock()
+	defer fakeServer.mutex.Unlock()
+	return append([]string{}, fakeServer.deletedPaths...)
+}
+
+// newManagedRolloutForTesting returns a Rollout applied by the manager of the tenant
+func newManagedRolloutForTesting(name string, tenantUuid string) *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(rolloutKind.groupVersionKind)
+	rollout.SetName(name)
+	rollout.SetNamespace(defaultNamespace)
+	rollout.SetLabels(map[string]string{kardinalManagedByLabelKey: kardinalManagedByLabelValue})
+	rollout.SetAnnotations(map[string]string{kardinalTenantUuidAnnotationKey: tenantUuid})
+	return rollout
+}
+
+// splitFakeAPIServerPath returns the group version and the rest of the path segments of the /api/v1 and /apis/<group>/<version> paths
+func splitFakeAPIServerPath(path string) (string, []string, bool) {
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(pathSegments) >= 2 && pathSegments[0] == "api":
+		return pathSegments[1], pathSegments[2:], true
+	case len(pathSegments) >= 3 && pathSegments[0] == "apis":
+		return fmt.Sprintf("%s/%s", pathSegments[1], pathSegments[2]), pathSegments[3:], true
+	default:
+		return "", nil, false
+	}
+}
+
+func getFakeAPIServerGroups() *metav1.APIGroupList {
+	groupList := &metav1.APIGroupList{}
+	for _, kind := range fakeAPIServerKinds {
+		groupVersion := kind.groupVersionKind.GroupVersion()
+		if groupVersion.Group == "" {
+			continue
+		}
+		isGroupListed := false
+		for _, group := range groupList.Groups {
+			isGroupListed = isGroupListed || group.Name == groupVersion.Group
+		}
+		if isGroupListed {
+			continue
+		}
+		groupVersionForDiscovery := metav1.GroupVersionForDiscovery{GroupVersion: groupVersion.String(), Version: groupVersion.Version}
+		groupList.Groups = append(groupList.Groups, metav1.APIGroup{
+			Name:             groupVersion.Group,
+			Versions:         []metav1.GroupVersionForDiscovery{groupVersionForDiscovery},
+			PreferredVersion: groupVersionForDiscovery,
+		})
+	}
+	return groupList
+}
+
+func getFakeAPIServerResources(groupVersion string) *metav1.APIResourceList {
+	resourceList := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, kind := range fakeAPIServerKinds {
+		if kind.groupVersionKind.GroupVersion().String() != groupVersion {
+			continue
+		}
+		resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{
+			Name:       kind.groupVersionResource.Resource,
+			Namespaced: kind.isNamespaced,
+			Kind:       kind.groupVersionKind.Kind,
+			Verbs:      metav1.Verbs{"get", "list", "patch", "delete"},
+		})
+	}
+	return resourceList
+}
+
+func newNotFoundStatusForTesting(path string) *metav1.Status {
+	return &metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Message: fmt.Sprintf("%s not found", path), Code: http.StatusNotFound}
+}
+
+func writeJSONForTesting(writer http.ResponseWriter, statusCode int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	_ = json.NewEncoder(writer).Encode(body)
+}
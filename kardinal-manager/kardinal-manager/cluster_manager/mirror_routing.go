@@ -0,0 +1,86 @@
+package cluster_manager
+
+import (
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const (
+	// The mirror routing rule catches every request like the canary one, a service is either canaried or mirrored
+	MirrorRoutingRulePriority = CanaryRoutingRulePriority
+
+	mirrorRoutingRuleNamePrefix = "mirror-"
+	maxMirrorPercentage         = 100
+)
+
+// MirrorRouting sends the requests to a host to the prod subset, and a copy of the percentage of them to the mirror
+// subset. The responses of the mirror subset are discarded
+type MirrorRouting struct {
+	Host         string
+	ProdSubset   string
+	MirrorSubset string
+	Percentage   float64
+}
+
+func NewMirrorRouting(host string, prodSubset string, mirrorSubset string, percentage float64) *MirrorRouting {
+	return &MirrorRouting{
+		Host:         host,
+		ProdSubset:   prodSubset,
+		MirrorSubset: mirrorSubset,
+		Percentage:   percentage,
+	}
+}
+
+func NewMirrorRoutingRule(mirrorRouting *MirrorRouting) (*istio.HTTPRoute, error) {
+	if mirrorRouting.Host == "" {
+		return nil, stacktrace.NewError("The mirror routing needs a host")
+	}
+	if mirrorRouting.ProdSubset == "" || mirrorRouting.MirrorSubset == "" {
+		return nil, stacktrace.NewError("The mirror routing of host '%s' needs a prod and a mirror subset", mirrorRouting.Host)
+	}
+	if mirrorRouting.Percentage < 0 || mirrorRouting.Percentage > maxMirrorPercentage {
+		return nil, stacktrace.NewError("The mirror percentage must be between 0 and %d, got %v", maxMirrorPercentage, mirrorRouting.Percentage)
+	}
+
+	return &istio.HTTPRoute{
+		Name: getMirrorRoutingRuleName(mirrorRouting.MirrorSubset),
+		Route: []*istio.HTTPRouteDestination{
+			{
+				Destination: &istio.Destination{
+					Host:   mirrorRouting.Host,
+					Subset: mirrorRouting.ProdSubset,
+				},
+			},
+		},
+		Mirror: &istio.Destination{
+			Host:   mirrorRouting.Host,
+			Subset: mirrorRouting.MirrorSubset,
+		},
+		MirrorPercentage: &istio.Percent{Value: mirrorRouting.Percentage},
+	}, nil
+}
+
+// addMirrorRoutingRule adds the routing rule of the mirror to the VirtualService it targets, Kontrol stops a mirror by
+// leaving it out
+func addMirrorRoutingRule(virtualServices map[string]*v1alpha3.VirtualService, mirror types.Mirror) error {
+	virtualService, err := getTargetVirtualService(virtualServices, mirror.Namespace, mirror.VirtualService)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the VirtualService of mirror subset '%s'", mirror.MirrorSubset)
+	}
+
+	routingRule, err := NewMirrorRoutingRule(NewMirrorRouting(mirror.Host, mirror.ProdSubset, mirror.MirrorSubset, float64(mirror.Percentage)))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred building the routing rule of mirror subset '%s'", mirror.MirrorSubset)
+	}
+
+	if err = setRoutingRule(virtualService, routingRule, MirrorRoutingRulePriority); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the routing rule of mirror subset '%s'", mirror.MirrorSubset)
+	}
+	return nil
+}
+
+func getMirrorRoutingRuleName(mirrorSubset string) string {
+	return mirrorRoutingRuleNamePrefix + mirrorSubset
+}
@@ -0,0 +1,59 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/stretchr/testify/require"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"testing"
+)
+
+func TestNewMirrorRoutingRule_MirrorsThePercentageOfTheTraffic(t *testing.T) {
+	routingRule, err := NewMirrorRoutingRule(NewMirrorRouting("reviews", "v1", "dev", 20))
+	require.NoError(t, err)
+	require.Equal(t, "mirror-dev", routingRule.GetName())
+	require.Len(t, routingRule.GetRoute(), 1)
+	require.Equal(t, "v1", routingRule.GetRoute()[0].GetDestination().GetSubset())
+	require.Equal(t, "dev", routingRule.GetMirror().GetSubset())
+	require.Equal(t, float64(20), routingRule.GetMirrorPercentage().GetValue())
+
+	_, err = NewMirrorRoutingRule(NewMirrorRouting("reviews", "v1", "dev", 100.5))
+	require.Error(t, err)
+	_, err = NewMirrorRoutingRule(NewMirrorRouting("reviews", "v1", "", 20))
+	require.Error(t, err)
+}
+
+func TestAddTrafficRoutingRules_AddsTheMirrorBeforeTheCatchAllRoutes(t *testing.T) {
+	managedObjects := []*managedObject{{kind: virtualServiceKind, object: newReviewsVirtualService()}}
+	clusterResources := &types.ClusterResources{
+		Mirrors: &[]types.Mirror{
+			{Namespace: subsetTestNamespace, VirtualService: subsetTestName, Host: subsetTestName, ProdSubset: "v1", MirrorSubset: "dev", Percentage: 50},
+		},
+	}
+
+	require.NoError(t, addTrafficRoutingRules(managedObjects, clusterResources))
+
+	desiredVirtualService, ok := managedObjects[0].object.(*v1alpha3.VirtualService)
+	require.True(t, ok)
+	require.Equal(t, []string{"mirror-dev", "dev-flow", "canary", "default"}, getRouteNames(desiredVirtualService))
+	require.Equal(t, float64(50), desiredVirtualService.Spec.GetHttp()[0].GetMirrorPercentage().GetValue())
+}
+
+func TestRemoveSubset_RemovesTheMirrorsToTheSubset(t *testing.T) {
+	ctx := context.Background()
+	virtualService := newReviewsVirtualService()
+	mirrorRoutingRule, err := NewMirrorRoutingRule(NewMirrorRouting(subsetTestName, "v1", "dev", 10))
+	require.NoError(t, err)
+	require.NoError(t, setRoutingRule(virtualService, mirrorRoutingRule, MirrorRoutingRulePriority))
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), virtualService)
+
+	require.NoError(t, clusterManager.RemoveSubset(ctx, subsetTestNamespace, subsetTestName, "dev"))
+
+	virtualService, err = clusterManager.GetVirtualService(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	mirrorRoute := virtualService.Spec.GetHttp()[0]
+	require.Equal(t, "mirror-dev", mirrorRoute.GetName())
+	require.Nil(t, mirrorRoute.GetMirror())
+	require.Nil(t, mirrorRoute.GetMirrorPercentage())
+	require.Equal(t, "v1", mirrorRoute.GetRoute()[0].GetDestination().GetSubset())
+}
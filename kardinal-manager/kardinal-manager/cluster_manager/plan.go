@@ -0,0 +1,261 @@
+package cluster_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+const (
+	diffContextLines  = 3
+	liveDiffPrefix    = "live"
+	desiredDiffPrefix = "desired"
+
+	redactedSecretValue = "***"
+)
+
+type ResourceChangeAction string
+
+const (
+	CreateResourceChangeAction ResourceChangeAction = "create"
+	UpdateResourceChangeAction ResourceChangeAction = "update"
+	DeleteResourceChangeAction ResourceChangeAction = "delete"
+)
+
+type ResourceChange struct {
+	Action    ResourceChangeAction
+	Kind      string
+	Namespace string
+	Name      string
+	// Diff is a unified diff between the live and the desired object in YAML, like the one printed by kubectl diff
+	Diff string
+}
+
+// Plan holds the changes ApplyClusterResources and CleanUpClusterResources would make in the cluster
+type Plan struct {
+	Changes []*ResourceChange
+}
+
+func (plan *Plan) HasChanges() bool {
+	return len(plan.Changes) > 0
+}
+
+func (plan *Plan) String() string {
+	if !plan.HasChanges() {
+		return "No changes, the cluster is up to date with the cluster resources\n"
+	}
+
+	changesByAction := map[ResourceChangeAction]int{}
+	planBuilder := strings.Builder{}
+	for _, change := range plan.Changes {
+		changesByAction[change.Action]++
+		planBuilder.WriteString(fmt.Sprintf("%s %s %s\n", change.Action, change.Kind, strings.Join(lo.Compact([]string{change.Namespace, change.Name}), objectKeySeparator)))
+		planBuilder.WriteString(change.Diff)
+	}
+	planBuilder.WriteString(fmt.Sprintf(
+		"Plan: %d to create, %d to update, %d to delete\n",
+		changesByAction[CreateResourceChangeAction],
+		changesByAction[UpdateResourceChangeAction],
+		changesByAction[DeleteResourceChangeAction],
+	))
+
+	return planBuilder.String()
+}
+
+// PlanClusterResources computes the changes that applying and cleaning up the cluster resources would make, the
+// updates are computed with a server-side apply dry-run so defaulted fields and other field managers are accounted for
+func (manager *ClusterManager) PlanClusterResources(ctx context.Context, clusterResources *types.ClusterResources) (*Plan, error) {
+	plan := &Plan{Changes: nil}
+
+	if !isValid(clusterResources) {
+		return plan, nil
+	}
+
+	var planErrors []error
+
+	managedObjects, err := manager.getManagedObjects(clusterResources)
+	areExtraResourceKindsResolved := err == nil
+	if err != nil {
+		planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred getting the kinds of the extra resources"))
+	}
+
+	if err = addTrafficRoutingRules(managedObjects, clusterResources); err != nil {
+		planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred adding the traffic routing rules to the VirtualServices, the invalid ones were left out"))
+	}
+
+	for _, objectToApply := range getObjectsToApply(managedObjects) {
+		change, err := manager.planObjectApply(ctx, objectToApply.kind, objectToApply.object)
+		if err != nil {
+			planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred planning the apply of %s '%s'", objectToApply.kind.groupVersionKind.Kind, objectToApply.object.GetName()))
+			continue
+		}
+		if change != nil {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	objectKeysToKeepByKind := getObjectKeysByKind(managedObjects)
+
+	// The extra resource kinds are looked for on every plan, so the standbys and the managers which just started plan
+	// the clean-up of the extra resources applied before too, without recording them
+	discoveredKinds, err := manager.discoverExtraResourceKinds(ctx)
+	if err != nil {
+		planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred looking for the managed objects of the extra resource kinds"))
+	}
+
+	extraResourceKinds := append(getResourceKinds(managedObjects), discoveredKinds...)
+	for _, kind := range manager.getKindsToCleanUp(extraResourceKinds, areExtraResourceKindsResolved) {
+		objectsToCleanUp, err := manager.getObjectsToCleanUp(ctx, kind, objectKeysToKeepByKind[kind.groupVersionKind.GroupKind()])
+		if err != nil {
+			planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred planning the clean up of %s objects", kind.groupVersionKind.Kind))
+			continue
+		}
+		for _, objectToCleanUp := range objectsToCleanUp {
+			change, err := newResourceChange(DeleteResourceChangeAction, kind, objectToCleanUp, nil)
+			if err != nil {
+				planErrors = append(planErrors, stacktrace.Propagate(err, "An error occurred planning the deletion of %s '%s'", kind.groupVersionKind.Kind, objectToCleanUp.GetName()))
+				continue
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if len(planErrors) > 0 {
+		return nil, stacktrace.Propagate(errors.Join(planErrors...), "%d errors occurred while planning the cluster resources changes", len(planErrors))
+	}
+
+	return plan, nil
+}
+
+// planObjectApply returns nil when applying the object wouldn't change the live object
+func (manager *ClusterManager) planObjectApply(ctx context.Context, kind resourceKind, object metav1.Object) (*ResourceChange, error) {
+	desiredObject, err := toUnstructured(kind, object)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred converting %s '%s' to an unstructured object", kind.groupVersionKind.Kind, object.GetName())
+	}
+
+	liveObject, err := manager.getLiveObject(ctx, kind, desiredObject)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the live %s '%s'", kind.groupVersionKind.Kind, object.GetName())
+	}
+
+	if liveObject == nil {
+		// The dry-run can't be used here because the namespace might not exist yet either
+		return newResourceChange(CreateResourceChangeAction, kind, nil, desiredObject)
+	}
+
+	if isUpToDate(liveObject, desiredObject) {
+		return nil, nil
+	}
+
+	appliedObject, err := manager.serverSideApply(ctx, kind, desiredObject, manager.getDryRunApplyOptions())
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred dry-running the apply of %s '%s'", kind.groupVersionKind.Kind, object.GetName())
+	}
+
+	change, err := newResourceChange(UpdateResourceChangeAction, kind, liveObject, appliedObject)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred computing the changes in %s '%s'", kind.groupVersionKind.Kind, object.GetName())
+	}
+	if change.Diff == "" {
+		return nil, nil
+	}
+
+	return change, nil
+}
+
+func (manager *ClusterManager) getDryRunApplyOptions() metav1.ApplyOptions {
+	applyOptions := manager.getApplyOptions()
+	applyOptions.DryRun = []string{metav1.DryRunAll}
+	return applyOptions
+}
+
+// newResourceChange accepts a nil live object for creations and a nil desired object for deletions
+func newResourceChange(action ResourceChangeAction, kind resourceKind, liveObject *unstructured.Unstructured, desiredObject *unstructured.Unstructured) (*ResourceChange, error) {
+	changedObject := lo.Ternary(desiredObject != nil, desiredObject, liveObject)
+
+	liveObjectYaml, err := toComparableYaml(liveObject)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred converting the live object to YAML")
+	}
+
+	desiredObjectYaml, err := toComparableYaml(desiredObject)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred converting the desired object to YAML")
+	}
+
+	// Cluster scoped objects, like namespaces, don't have a namespace in the path
+	objectPath := strings.Join(lo.Compact([]string{kind.groupVersionKind.Kind, changedObject.GetNamespace(), changedObject.GetName()}), objectKeySeparator)
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitYamlLines(liveObjectYaml),
+		B:        splitYamlLines(desiredObjectYaml),
+		FromFile: liveDiffPrefix + objectKeySeparator + objectPath,
+		FromDate: "",
+		ToFile:   desiredDiffPrefix + objectKeySeparator + objectPath,
+		ToDate:   "",
+		Eol:      "",
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred computing the diff of %s", objectPath)
+	}
+
+	return &ResourceChange{
+		Action:    action,
+		Kind:      kind.groupVersionKind.Kind,
+		Namespace: changedObject.GetNamespace(),
+		Name:      changedObject.GetName(),
+		Diff:      diff,
+	}, nil
+}
+
+// toComparableYaml leaves out the fields always changing in the API server, so they don't show up in the diffs
+func toComparableYaml(object *unstructured.Unstructured) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+
+	comparableObject := object.DeepCopy()
+	removeServerPopulatedFields(comparableObject)
+	if object.GetKind() == secretKind.groupVersionKind.Kind {
+		redactSecretData(comparableObject)
+	}
+
+	objectYaml, err := yaml.Marshal(comparableObject.Object)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred marshalling %s '%s' to YAML", object.GetKind(), object.GetName())
+	}
+
+	return string(objectYaml), nil
+}
+
+// redactSecretData keeps the Secret keys, so added and removed keys still show up in the diffs, but not their values
+func redactSecretData(secret *unstructured.Unstructured) {
+	for _, dataField := range []string{"data", "stringData"} {
+		data, found, err := unstructured.NestedMap(secret.Object, dataField)
+		if err != nil || !found {
+			continue
+		}
+		for key := range data {
+			data[key] = redactedSecretValue
+		}
+		secret.Object[dataField] = data
+	}
+}
+
+func splitYamlLines(objectYaml string) []string {
+	// difflib.SplitLines would return a single empty line for the missing side of creations and deletions
+	if objectYaml == "" {
+		return nil
+	}
+	return difflib.SplitLines(objectYaml)
+}
@@ -0,0 +1,170 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewResourceChange_UpdateDiffIgnoresServerPopulatedFields(t *testing.T) {
+	liveObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	liveObject.SetResourceVersion("1234")
+	liveObject.SetUID("a-uid")
+
+	desiredObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+
+	change, err := newResourceChange(UpdateResourceChangeAction, serviceKind, liveObject, desiredObject)
+	require.NoError(t, err)
+	require.Empty(t, change.Diff)
+
+	desiredObject = getUnstructuredServiceForTesting(t, "reviews", 9081)
+
+	change, err = newResourceChange(UpdateResourceChangeAction, serviceKind, liveObject, desiredObject)
+	require.NoError(t, err)
+	require.Contains(t, change.Diff, "--- live/Service/default/reviews")
+	require.Contains(t, change.Diff, "+++ desired/Service/default/reviews")
+	require.Contains(t, change.Diff, "-  - port: 9080")
+	require.Contains(t, change.Diff, "+  - port: 9081")
+}
+
+func TestPlan_String(t *testing.T) {
+	createdObject := getUnstructuredServiceForTesting(t, "reviews", 9080)
+	deletedObject := getUnstructuredServiceForTesting(t, "ratings", 9080)
+
+	createChange, err := newResourceChange(CreateResourceChangeAction, serviceKind, nil, createdObject)
+	require.NoError(t, err)
+	deleteChange, err := newResourceChange(DeleteResourceChangeAction, serviceKind, deletedObject, nil)
+	require.NoError(t, err)
+
+	plan := &Plan{Changes: []*ResourceChange{createChange, deleteChange}}
+	require.True(t, plan.HasChanges())
+
+	planString := plan.String()
+	require.Contains(t, planString, "create Service default/reviews\n")
+	require.Contains(t, planString, "delete Service default/ratings\n")
+	require.Contains(t, planString, "+  name: reviews")
+	require.Contains(t, planString, "-  name: ratings")
+	require.True(t, strings.HasSuffix(planString, "Plan: 1 to create, 0 to update, 1 to delete\n"))
+
+	require.False(t, (&Plan{Changes: nil}).HasChanges())
+}
+
+func TestNewResourceChange_RedactsSecretValues(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-credentials",
+			Namespace: defaultNamespace,
+		},
+		Data:       map[string][]byte{"password": []byte("a-password")},
+		StringData: map[string]string{"user": "a-user"},
+	}
+	desiredObject, err := toUnstructured(secretKind, secret)
+	require.NoError(t, err)
+
+	change, err := newResourceChange(CreateResourceChangeAction, secretKind, nil, desiredObject)
+	require.NoError(t, err)
+	require.Contains(t, change.Diff, "+  password: '***'")
+	require.Contains(t, change.Diff, "+  user: '***'")
+	require.NotContains(t, change.Diff, "a-user")
+	require.NotContains(t, change.Diff, "YS1wYXNzd29yZA==")
+}
+
+func TestPlanClusterResources_DoesNotChangeTheSyncStateWhileApplying(t *testing.T) {
+	ctx := context.Background()
+	manager := newFakeAPIServerForTesting(t).newClusterManager(t)
+
+	const concurrentSyncs = 5
+	plans := make(chan *Plan, concurrentSyncs)
+	var waitGroup sync.WaitGroup
+	for syncIndex := 0; syncIndex < concurrentSyncs; syncIndex++ {
+		waitGroup.Add(2)
+		isFullResync := syncIndex == 0
+		// Every sync gets its own cluster resources, the way the fetcher and the plan requests do
+		go func() {
+			defer waitGroup.Done()
+			plan, err := manager.PlanClusterResources(ctx, getClusterResourcesWithRolloutForTesting())
+			if err != nil {
+				t.Errorf("planning the cluster resources failed: %v", err)
+				return
+			}
+			plans <- plan
+		}()
+		go func() {
+			defer waitGroup.Done()
+			if _, err := manager.ApplyClusterResources(ctx, getClusterResourcesWithRolloutForTesting(), isFullResync); err != nil {
+				t.Errorf("applying the cluster resources failed: %v", err)
+			}
+			if err := manager.CleanUpClusterResources(ctx, getClusterResourcesWithRolloutForTesting(), isFullResync); err != nil {
+				t.Errorf("cleaning up the cluster resources failed: %v", err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+	close(plans)
+
+	for plan := range plans {
+		require.Contains(t, plan.String(), "create Rollout default/reviews\n")
+	}
+	require.Contains(t, manager.extraResourceKinds, rolloutKind.groupVersionKind.GroupKind())
+}
+
+func TestPlanClusterResources_DoesNotRecordTheExtraResourceKinds(t *testing.T) {
+	manager := newFakeAPIServerForTesting(t).newClusterManager(t)
+
+	_, err := manager.PlanClusterResources(context.Background(), getClusterResourcesWithRolloutForTesting())
+	require.NoError(t, err)
+	require.Empty(t, manager.extraResourceKinds)
+}
+
+func TestPlanClusterResources_PlansTheDeletionOfTheExtraResourcesFoundInTheCluster(t *testing.T) {
+	manager := newFakeAPIServerForTesting(t, newManagedRolloutForTesting("ratings", testTenantUuid)).newClusterManager(t)
+
+	plan, err := manager.PlanClusterResources(context.Background(), getClusterResourcesWithRolloutForTesting())
+	require.NoError(t, err)
+	require.Contains(t, plan.String(), "create Rollout default/reviews\n")
+	require.Contains(t, plan.String(), "delete Rollout default/ratings\n")
+	require.Empty(t, manager.extraResourceKinds)
+}
+
+func getClusterResourcesWithRolloutForTesting() *types.ClusterResources {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(rolloutKind.groupVersionKind)
+	rollout.SetName("reviews")
+	rollout.SetNamespace(defaultNamespace)
+
+	service := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews",
+			Namespace: defaultNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 9080}},
+		},
+	}
+
+	return &types.ClusterResources{
+		Services:       &[]corev1.Service{service},
+		ExtraResources: &[]unstructured.Unstructured{*rollout},
+	}
+}
+
+func getUnstructuredServiceForTesting(t *testing.T, name string, port int32) *unstructured.Unstructured {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: port}},
+		},
+	}
+	unstructuredService, err := toUnstructured(serviceKind, service)
+	require.NoError(t, err)
+	return unstructuredService
+}
@@ -0,0 +1,462 @@
+package cluster_manager
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"strings"
+	"time"
+)
+
+const (
+	readinessPollInterval = time.Second * 2
+	// isImmediatePoll makes the readiness checks run once more after the deadline, so the objects which were already
+	// ready are not reported as not ready because a previous one used up the whole timeout
+	isImmediatePoll = true
+
+	defaultReplicas                          int32 = 1
+	deploymentProgressDeadlineExceededReason       = "ProgressDeadlineExceeded"
+	deploymentRevisionAnnotationKey                = "deployment.kubernetes.io/revision"
+	podKind                                        = "Pod"
+	hostSeparator                                  = "."
+	wholeService                                   = ""
+)
+
+// notReadyDestination is a subset of a Service whose pods are not ready, or the whole Service when none of its pods are
+type notReadyDestination struct {
+	namespace   string
+	serviceName string
+	subsetName  string
+}
+
+func (destination notReadyDestination) getServiceKey() string {
+	return getObjectKey(destination.namespace, destination.serviceName)
+}
+
+func (destination notReadyDestination) String() string {
+	if destination.subsetName == wholeService {
+		return destination.getServiceKey()
+	}
+	return fmt.Sprintf("%s subset %s", destination.getServiceKey(), destination.subsetName)
+}
+
+// waitForReadiness waits, with a single deadline for all of them, for the Deployments and StatefulSets to finish their
+// rollout and then for the Services backed by them to have ready endpoints of their new pods. The workloads unchanged
+// since they were last ready are skipped, unless it's a full resync. It returns the Service subsets whose workloads are
+// not ready, so the routes to them can be held back
+func (manager *ClusterManager) waitForReadiness(ctx context.Context, managedObjects []*managedObject, isFullResync bool, report *ApplyReport) []notReadyDestination {
+	if manager.readinessTimeout <= 0 {
+		return nil
+	}
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, manager.readinessTimeout)
+	defer cancel()
+
+	workloads := lo.Filter(managedObjects, func(managedObj *managedObject, _ int) bool {
+		return managedObj.kind == deploymentKind || managedObj.kind == statefulSetKind
+	})
+	services := lo.Filter(managedObjects, func(managedObj *managedObject, _ int) bool { return managedObj.kind == serviceKind })
+	destinationRules := lo.Filter(managedObjects, func(managedObj *managedObject, _ int) bool { return managedObj.kind == destinationRuleKind })
+
+	manager.syncStateMutex.Lock()
+	previousNotReadyWorkloadKeys := manager.notReadyWorkloadKeys
+	manager.syncStateMutex.Unlock()
+
+	var notReadyWorkloads []*managedObject
+	for _, workload := range workloads {
+		// Nothing routes to the workloads without Services
+		backingServices := getServicesBackedByWorkload(workload, services)
+		if len(backingServices) == 0 {
+			continue
+		}
+
+		if !isFullResync && hasResultStatus(report, workload, UnchangedResourceApplyStatus) && !lo.Contains(previousNotReadyWorkloadKeys, getWorkloadKey(workload)) {
+			continue
+		}
+
+		// The live object of a workload which failed to apply is the previous version, so its rollout says nothing
+		if hasResultStatus(report, workload, FailedResourceApplyStatus) {
+			notReadyWorkloads = append(notReadyWorkloads, workload)
+			continue
+		}
+
+		err := wait.PollUntilContextCancel(deadlineCtx, readinessPollInterval, isImmediatePoll, func(_ context.Context) (bool, error) {
+			// The parent context is used for the requests, so the last check still works once the deadline is exceeded
+			return manager.isWorkloadRolledOut(ctx, workload)
+		})
+		if err != nil {
+			message := fmt.Sprintf("%s '%s' didn't finish its rollout within %s: %s", workload.kind.groupVersionKind.Kind, workload.object.GetName(), manager.readinessTimeout, err)
+			logrus.Warnf("%s in namespace '%s'", message, workload.object.GetNamespace())
+			report.setResult(workload.kind, workload.object, NotReadyResourceApplyStatus, message)
+			notReadyWorkloads = append(notReadyWorkloads, workload)
+			continue
+		}
+
+		// The old pods keep the Service endpoints ready during the rollout, so only the endpoints of the new ones count
+		for _, service := range backingServices {
+			err = wait.PollUntilContextCancel(deadlineCtx, readinessPollInterval, isImmediatePoll, func(_ context.Context) (bool, error) {
+				return manager.hasReadyEndpointsOfNewPods(ctx, service, workload)
+			})
+			if err != nil {
+				message := fmt.Sprintf("Service '%s' didn't have ready endpoints of the new pods of %s '%s' within %s: %s", service.object.GetName(), workload.kind.groupVersionKind.Kind, workload.object.GetName(), manager.readinessTimeout, err)
+				logrus.Warnf("%s in namespace '%s'", message, workload.object.GetNamespace())
+				report.setResult(workload.kind, workload.object, NotReadyResourceApplyStatus, message)
+				notReadyWorkloads = append(notReadyWorkloads, workload)
+				break
+			}
+		}
+	}
+
+	manager.syncStateMutex.Lock()
+	manager.notReadyWorkloadKeys = lo.Map(notReadyWorkloads, func(workload *managedObject, _ int) string { return getWorkloadKey(workload) })
+	manager.syncStateMutex.Unlock()
+
+	return getNotReadyDestinations(notReadyWorkloads, workloads, services, destinationRules)
+}
+
+// isWorkloadRolledOut returns an error, which stops the wait, only when the rollout can't complete anymore
+func (manager *ClusterManager) isWorkloadRolledOut(ctx context.Context, workload *managedObject) (bool, error) {
+	namespace := workload.object.GetNamespace()
+	name := workload.object.GetName()
+
+	switch workload.kind {
+	case deploymentKind:
+		deployment, err := manager.kubernetesClient.clientSet.AppsV1().Deployments(namespace).Get(ctx, name, globalGetOptions)
+		if err != nil {
+			logrus.Debugf("An error occurred getting Deployment '%s' in namespace '%s' to check its rollout, retrying. Error was:\n%s", name, namespace, err)
+			return false, nil
+		}
+		return isDeploymentRolledOut(deployment)
+	case statefulSetKind:
+		statefulSet, err := manager.kubernetesClient.clientSet.AppsV1().StatefulSets(namespace).Get(ctx, name, globalGetOptions)
+		if err != nil {
+			logrus.Debugf("An error occurred getting StatefulSet '%s' in namespace '%s' to check its rollout, retrying. Error was:\n%s", name, namespace, err)
+			return false, nil
+		}
+		return isStatefulSetRolledOut(statefulSet), nil
+	default:
+		return true, nil
+	}
+}
+
+func (manager *ClusterManager) hasReadyEndpointsOfNewPods(ctx context.Context, service *managedObject, workload *managedObject) (bool, error) {
+	newPodNames, err := manager.getNewPodNames(ctx, workload)
+	if err != nil {
+		logrus.Debugf("An error occurred getting the new pods of %s '%s' in namespace '%s', retrying. Error was:\n%s", workload.kind.groupVersionKind.Kind, workload.object.GetName(), workload.object.GetNamespace(), err)
+		return false, nil
+	}
+
+	listOptions := globalListOptions
+	listOptions.LabelSelector = labels.SelectorFromSet(map[string]string{
+		discoveryv1.LabelServiceName: service.object.GetName(),
+	}).String()
+
+	endpointSlices, err := manager.kubernetesClient.clientSet.DiscoveryV1().EndpointSlices(service.object.GetNamespace()).List(ctx, listOptions)
+	if err != nil {
+		logrus.Debugf("An error occurred listing the endpoints of Service '%s' in namespace '%s', retrying. Error was:\n%s", service.object.GetName(), service.object.GetNamespace(), err)
+		return false, nil
+	}
+
+	return isAnyEndpointOfPodsReady(endpointSlices.Items, newPodNames), nil
+}
+
+// getNewPodNames returns the pods of the current revision of the workload, the ones of its latest ReplicaSet for a
+// Deployment and the ones of its update revision for a StatefulSet
+func (manager *ClusterManager) getNewPodNames(ctx context.Context, workload *managedObject) ([]string, error) {
+	namespace := workload.object.GetNamespace()
+	name := workload.object.GetName()
+	appsClient := manager.kubernetesClient.clientSet.AppsV1()
+
+	var podSelector labels.Selector
+	var revisionLabelKey string
+	var revision string
+	switch workload.kind {
+	case deploymentKind:
+		deployment, err := appsClient.Deployments(namespace).Get(ctx, name, globalGetOptions)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting Deployment '%s' in namespace '%s'", name, namespace)
+		}
+		if podSelector, err = metav1.LabelSelectorAsSelector(deployment.Spec.Selector); err != nil {
+			return nil, stacktrace.Propagate(err, "Deployment '%s' in namespace '%s' has an invalid selector", name, namespace)
+		}
+		listOptions := globalListOptions
+		listOptions.LabelSelector = podSelector.String()
+		replicaSets, err := appsClient.ReplicaSets(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred listing the ReplicaSets of Deployment '%s' in namespace '%s'", name, namespace)
+		}
+		newReplicaSet, found := lo.Find(replicaSets.Items, func(replicaSet appsv1.ReplicaSet) bool {
+			return metav1.IsControlledBy(&replicaSet, deployment) && replicaSet.Annotations[deploymentRevisionAnnotationKey] == deployment.Annotations[deploymentRevisionAnnotationKey]
+		})
+		if !found {
+			return nil, stacktrace.NewError("Deployment '%s' in namespace '%s' doesn't have the ReplicaSet of its revision yet", name, namespace)
+		}
+		revisionLabelKey = appsv1.DefaultDeploymentUniqueLabelKey
+		revision = newReplicaSet.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	case statefulSetKind:
+		statefulSet, err := appsClient.StatefulSets(namespace).Get(ctx, name, globalGetOptions)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting StatefulSet '%s' in namespace '%s'", name, namespace)
+		}
+		if podSelector, err = metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector); err != nil {
+			return nil, stacktrace.Propagate(err, "StatefulSet '%s' in namespace '%s' has an invalid selector", name, namespace)
+		}
+		revisionLabelKey = appsv1.ControllerRevisionHashLabelKey
+		revision = statefulSet.Status.UpdateRevision
+	default:
+		return nil, nil
+	}
+
+	revisionRequirement, err := labels.NewRequirement(revisionLabelKey, selection.Equals, []string{revision})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the selector of the pods of revision '%s' of %s '%s'", revision, workload.kind.groupVersionKind.Kind, name)
+	}
+	listOptions := globalListOptions
+	listOptions.LabelSelector = podSelector.Add(*revisionRequirement).String()
+	pods, err := manager.kubernetesClient.clientSet.CoreV1().Pods(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred listing the pods of %s '%s' in namespace '%s'", workload.kind.groupVersionKind.Kind, name, namespace)
+	}
+
+	return lo.Map(pods.Items, func(pod corev1.Pod, _ int) string { return pod.Name }), nil
+}
+
+// isDeploymentRolledOut follows the same rules as kubectl rollout status
+func isDeploymentRolledOut(deployment *appsv1.Deployment) (bool, error) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, nil
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == deploymentProgressDeadlineExceededReason {
+			return false, fmt.Errorf("deployment '%s' exceeded its progress deadline", deployment.Name)
+		}
+	}
+
+	replicas := lo.FromPtrOr(deployment.Spec.Replicas, defaultReplicas)
+	if deployment.Status.UpdatedReplicas < replicas {
+		return false, nil
+	}
+	// The old replicas are still terminating
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return false, nil
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// isStatefulSetRolledOut follows the same rules as kubectl rollout status
+func isStatefulSetRolledOut(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false
+	}
+
+	replicas := lo.FromPtrOr(statefulSet.Spec.Replicas, defaultReplicas)
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return false
+	}
+
+	// The pods of an OnDelete StatefulSet are only updated when they are deleted, so there is no rollout to wait for
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return true
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && lo.FromPtr(rollingUpdate.Partition) > 0 {
+		return statefulSet.Status.UpdatedReplicas >= replicas-*rollingUpdate.Partition
+	}
+
+	return statefulSet.Status.UpdateRevision == statefulSet.Status.CurrentRevision
+}
+
+// isAnyEndpointOfPodsReady treats a missing ready condition as ready, as the EndpointSlice API asks consumers to
+func isAnyEndpointOfPodsReady(endpointSlices []discoveryv1.EndpointSlice, podNames []string) bool {
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != podKind || !lo.Contains(podNames, endpoint.TargetRef.Name) {
+				continue
+			}
+			if lo.FromPtrOr(endpoint.Conditions.Ready, true) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getServicesBackedByWorkload leaves out the workloads scaled to zero, since their Services never get ready endpoints
+func getServicesBackedByWorkload(workload *managedObject, services []*managedObject) []*managedObject {
+	podTemplateLabels, replicas := getPodTemplateLabelsAndReplicas(workload)
+	if podTemplateLabels == nil || (replicas != nil && *replicas == 0) {
+		return nil
+	}
+
+	return lo.Filter(services, func(managedObj *managedObject, _ int) bool {
+		service, ok := managedObj.object.(*corev1.Service)
+		if !ok || service.Namespace != workload.object.GetNamespace() || len(service.Spec.Selector) == 0 {
+			return false
+		}
+		return labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(podTemplateLabels))
+	})
+}
+
+func getPodTemplateLabelsAndReplicas(workload *managedObject) (map[string]string, *int32) {
+	switch workloadObject := workload.object.(type) {
+	case *appsv1.Deployment:
+		return workloadObject.Spec.Template.Labels, workloadObject.Spec.Replicas
+	case *appsv1.StatefulSet:
+		return workloadObject.Spec.Template.Labels, workloadObject.Spec.Replicas
+	default:
+		return nil, nil
+	}
+}
+
+// getNotReadyDestinations returns the subsets of the Services whose pods are all in not ready workloads, and the whole
+// Services when all the workloads backing them are not ready
+func getNotReadyDestinations(notReadyWorkloads []*managedObject, workloads []*managedObject, services []*managedObject, destinationRules []*managedObject) []notReadyDestination {
+	var notReadyDestinations []notReadyDestination
+	for _, service := range services {
+		backingWorkloads := lo.Filter(workloads, func(workload *managedObject, _ int) bool {
+			return len(getServicesBackedByWorkload(workload, []*managedObject{service})) > 0
+		})
+		if len(lo.Intersect(backingWorkloads, notReadyWorkloads)) == 0 {
+			continue
+		}
+
+		destination := notReadyDestination{namespace: service.object.GetNamespace(), serviceName: service.object.GetName(), subsetName: wholeService}
+		if lo.Every(notReadyWorkloads, backingWorkloads) {
+			notReadyDestinations = append(notReadyDestinations, destination)
+			continue
+		}
+
+		serviceHost := getFullyQualifiedHost(service.object.GetName(), service.object.GetNamespace())
+		for _, managedObj := range destinationRules {
+			destinationRule, ok := managedObj.object.(*v1alpha3.DestinationRule)
+			if !ok || getFullyQualifiedHost(destinationRule.Spec.GetHost(), destinationRule.Namespace) != serviceHost {
+				continue
+			}
+			for _, subset := range destinationRule.Spec.GetSubsets() {
+				subsetWorkloads := lo.Filter(backingWorkloads, func(workload *managedObject, _ int) bool {
+					podTemplateLabels, _ := getPodTemplateLabelsAndReplicas(workload)
+					return labels.SelectorFromSet(subset.GetLabels()).Matches(labels.Set(podTemplateLabels))
+				})
+				if len(subsetWorkloads) > 0 && lo.Every(notReadyWorkloads, subsetWorkloads) {
+					destination.subsetName = subset.GetName()
+					notReadyDestinations = append(notReadyDestinations, destination)
+				}
+			}
+		}
+	}
+	return notReadyDestinations
+}
+
+// holdBackNotReadyRoutes returns a copy of the VirtualService without the destinations and the mirrors to the not ready
+// subsets, and the not ready destinations it routed to. The whole VirtualService is held back when it routes to a
+// not ready Service, when a TCP or TLS route goes to a not ready subset, or when one of its catch-all routes would be
+// left out, since the requests served by the previous version would be dropped
+func holdBackNotReadyRoutes(virtualService *v1alpha3.VirtualService, notReadyDestinations []notReadyDestination) (*v1alpha3.VirtualService, []notReadyDestination, bool) {
+	routedServiceKeys := getRoutedServiceKeys(virtualService)
+	routedNotReadyDestinations := lo.Filter(notReadyDestinations, func(destination notReadyDestination, _ int) bool {
+		return lo.Contains(routedServiceKeys, destination.getServiceKey())
+	})
+	if len(routedNotReadyDestinations) == 0 {
+		return virtualService, nil, false
+	}
+
+	prunedVirtualService := virtualService.DeepCopy()
+	var heldBackDestinations []notReadyDestination
+	for _, destination := range routedNotReadyDestinations {
+		if destination.subsetName == wholeService || isRoutedByTCPOrTLS(virtualService, destination) {
+			return nil, routedNotReadyDestinations, true
+		}
+		host := destination.serviceName + hostSeparator + destination.namespace
+		httpRoutes, wasPruned := removeSubsetDestinations(prunedVirtualService.Spec.Http, virtualService.Namespace, host, destination.subsetName)
+		if wasPruned {
+			prunedVirtualService.Spec.Http = httpRoutes
+			heldBackDestinations = append(heldBackDestinations, destination)
+		}
+	}
+
+	if countCatchAllRoutes(prunedVirtualService.Spec.Http) < countCatchAllRoutes(virtualService.Spec.Http) {
+		return nil, heldBackDestinations, true
+	}
+	return prunedVirtualService, heldBackDestinations, false
+}
+
+func isRoutedByTCPOrTLS(virtualService *v1alpha3.VirtualService, destination notReadyDestination) bool {
+	var routeDestinations []*istio.RouteDestination
+	for _, tcpRoute := range virtualService.Spec.GetTcp() {
+		routeDestinations = append(routeDestinations, tcpRoute.GetRoute()...)
+	}
+	for _, tlsRoute := range virtualService.Spec.GetTls() {
+		routeDestinations = append(routeDestinations, tlsRoute.GetRoute()...)
+	}
+	host := getFullyQualifiedHost(destination.serviceName, destination.namespace)
+	return lo.ContainsBy(routeDestinations, func(routeDestination *istio.RouteDestination) bool {
+		return routeDestination.GetDestination().GetSubset() == destination.subsetName &&
+			getFullyQualifiedHost(routeDestination.GetDestination().GetHost(), virtualService.Namespace) == host
+	})
+}
+
+func joinNotReadyDestinations(destinations []notReadyDestination) string {
+	return strings.Join(lo.Map(destinations, func(destination notReadyDestination, _ int) string { return destination.String() }), ", ")
+}
+
+func countCatchAllRoutes(httpRoutes []*istio.HTTPRoute) int {
+	return lo.CountBy(httpRoutes, func(httpRoute *istio.HTTPRoute) bool { return len(httpRoute.GetMatch()) == 0 })
+}
+
+// getRoutedServiceKeys returns the keys of the Services the VirtualService sends or mirrors traffic to, the hosts are resolved
+// relative to the VirtualService namespace, e.g. reviews, reviews.prod and reviews.prod.svc.cluster.local
+func getRoutedServiceKeys(virtualService *v1alpha3.VirtualService) []string {
+	var hosts []string
+	for _, httpRoute := range virtualService.Spec.GetHttp() {
+		for _, routeDestination := range httpRoute.GetRoute() {
+			hosts = append(hosts, routeDestination.GetDestination().GetHost())
+		}
+		hosts = append(hosts, httpRoute.GetMirror().GetHost())
+	}
+	for _, tcpRoute := range virtualService.Spec.GetTcp() {
+		for _, routeDestination := range tcpRoute.GetRoute() {
+			hosts = append(hosts, routeDestination.GetDestination().GetHost())
+		}
+	}
+	for _, tlsRoute := range virtualService.Spec.GetTls() {
+		for _, routeDestination := range tlsRoute.GetRoute() {
+			hosts = append(hosts, routeDestination.GetDestination().GetHost())
+		}
+	}
+
+	return lo.Uniq(lo.Map(lo.Compact(hosts), func(host string, _ int) string {
+		hostParts := strings.Split(host, hostSeparator)
+		namespace := virtualService.Namespace
+		if len(hostParts) > 1 {
+			namespace = hostParts[1]
+		}
+		return getObjectKey(namespace, hostParts[0])
+	}))
+}
+
+func getWorkloadKey(workload *managedObject) string {
+	return workload.kind.groupVersionKind.Kind + "/" + getObjectKey(workload.object.GetNamespace(), workload.object.GetName())
+}
+
+func hasResultStatus(report *ApplyReport, managedObj *managedObject, status ResourceApplyStatus) bool {
+	return lo.ContainsBy(report.GetResultsWithStatus(status), func(result *ResourceApplyResult) bool {
+		return result.Kind == managedObj.kind.groupVersionKind.Kind && result.Namespace == managedObj.object.GetNamespace() && result.Name == managedObj.object.GetName()
+	})
+}
@@ -0,0 +1,249 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestIsDeploymentRolledOut(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews-v2", Namespace: defaultNamespace, Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           3,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  2,
+		},
+	}
+
+	isRolledOut, err := isDeploymentRolledOut(deployment)
+	require.NoError(t, err)
+	require.False(t, isRolledOut)
+
+	// The old replica is still terminating
+	deployment.Status.ObservedGeneration = 2
+	isRolledOut, err = isDeploymentRolledOut(deployment)
+	require.NoError(t, err)
+	require.False(t, isRolledOut)
+
+	deployment.Status.Replicas = 2
+	isRolledOut, err = isDeploymentRolledOut(deployment)
+	require.NoError(t, err)
+	require.True(t, isRolledOut)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: deploymentProgressDeadlineExceededReason},
+	}
+	_, err = isDeploymentRolledOut(deployment)
+	require.Error(t, err)
+}
+
+func TestIsStatefulSetRolledOut(t *testing.T) {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: defaultNamespace, Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			ReadyReplicas:      1,
+			CurrentRevision:    "redis-1",
+			UpdateRevision:     "redis-2",
+		},
+	}
+	require.False(t, isStatefulSetRolledOut(statefulSet))
+
+	statefulSet.Status.CurrentRevision = "redis-2"
+	require.True(t, isStatefulSetRolledOut(statefulSet))
+
+	statefulSet.Status.ReadyReplicas = 0
+	require.False(t, isStatefulSetRolledOut(statefulSet))
+}
+
+func TestIsAnyEndpointOfPodsReady_OnlyCountsTheEndpointsOfThePods(t *testing.T) {
+	notReady := false
+	newPodNames := []string{"reviews-v2-7d4b9-abcde"}
+	endpointSlices := []discoveryv1.EndpointSlice{
+		{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &notReady}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "reviews-v2-7d4b9-abcde"}}}},
+		// The old pod is still serving
+		{Endpoints: []discoveryv1.Endpoint{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "reviews-v2-5f6c8-fghij"}}}},
+	}
+	require.False(t, isAnyEndpointOfPodsReady(nil, newPodNames))
+	require.False(t, isAnyEndpointOfPodsReady(endpointSlices, newPodNames))
+
+	// A missing ready condition means the endpoint is ready
+	endpointSlices = append(endpointSlices, discoveryv1.EndpointSlice{Endpoints: []discoveryv1.Endpoint{{TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "reviews-v2-7d4b9-abcde"}}}})
+	require.True(t, isAnyEndpointOfPodsReady(endpointSlices, newPodNames))
+}
+
+func TestGetServicesBackedByWorkload_MatchesTheSelectorInTheSameNamespace(t *testing.T) {
+	reviewsService := &managedObject{kind: serviceKind, object: newServiceForTesting("reviews", defaultNamespace, map[string]string{"app": "reviews"})}
+	otherNamespaceService := &managedObject{kind: serviceKind, object: newServiceForTesting("reviews", "prod", map[string]string{"app": "reviews"})}
+	ratingsService := &managedObject{kind: serviceKind, object: newServiceForTesting("ratings", defaultNamespace, map[string]string{"app": "ratings"})}
+	services := []*managedObject{reviewsService, otherNamespaceService, ratingsService}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews-v2", Namespace: defaultNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "reviews", "version": "v2"}},
+			},
+		},
+	}
+	workload := &managedObject{kind: deploymentKind, object: deployment}
+
+	require.Equal(t, []*managedObject{reviewsService}, getServicesBackedByWorkload(workload, services))
+
+	noReplicas := int32(0)
+	deployment.Spec.Replicas = &noReplicas
+	require.Empty(t, getServicesBackedByWorkload(workload, services))
+}
+
+func TestGetRoutedServiceKeys_ResolvesTheHostsRelativeToTheVirtualServiceNamespace(t *testing.T) {
+	virtualService := &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: defaultNamespace},
+		Spec: istio.VirtualService{
+			Http: []*istio.HTTPRoute{
+				{
+					Route: []*istio.HTTPRouteDestination{
+						{Destination: &istio.Destination{Host: "reviews", Subset: "v1"}},
+						{Destination: &istio.Destination{Host: "reviews", Subset: "v2"}},
+						{Destination: &istio.Destination{Host: "ratings.prod.svc.cluster.local"}},
+					},
+					Mirror: &istio.Destination{Host: "reviews-shadow", Subset: "dev"},
+				},
+			},
+			Tcp: []*istio.TCPRoute{
+				{Route: []*istio.RouteDestination{{Destination: &istio.Destination{Host: "redis.prod"}}}},
+			},
+		},
+	}
+
+	require.Equal(t, []string{"default/reviews", "prod/ratings", "default/reviews-shadow", "prod/redis"}, getRoutedServiceKeys(virtualService))
+}
+
+func TestWaitForReadiness_FailedWorkloadsMakeTheirServicesNotReady(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, time.Minute)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews-v2", Namespace: defaultNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "reviews", "version": "v2"}},
+			},
+		},
+	}
+	managedObjects := []*managedObject{
+		{kind: serviceKind, object: newServiceForTesting("reviews", defaultNamespace, map[string]string{"app": "reviews"})},
+		{kind: deploymentKind, object: deployment},
+	}
+
+	report := newApplyReport()
+	report.setResult(deploymentKind, deployment, FailedResourceApplyStatus, "forbidden")
+
+	notReadyDestinations := manager.waitForReadiness(context.Background(), managedObjects, doNotFullResync, report)
+	require.Equal(t, []notReadyDestination{{namespace: defaultNamespace, serviceName: "reviews", subsetName: wholeService}}, notReadyDestinations)
+	require.Equal(t, []string{"Deployment/default/reviews-v2"}, manager.notReadyWorkloadKeys)
+
+	disabledManager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
+	require.Empty(t, disabledManager.waitForReadiness(context.Background(), managedObjects, doNotFullResync, report))
+}
+
+func TestWaitForReadiness_SkipsTheUnchangedWorkloadsWhichWereReady(t *testing.T) {
+	manager := NewClusterManager(nil, nil, testTenantUuid, doNotForceApplyOwnership, time.Minute)
+
+	deployment := newDeploymentForTesting("reviews-v1", defaultNamespace, map[string]string{"app": "reviews", "version": "v1"})
+	managedObjects := []*managedObject{
+		{kind: serviceKind, object: newServiceForTesting("reviews", defaultNamespace, map[string]string{"app": "reviews"})},
+		{kind: deploymentKind, object: deployment},
+	}
+
+	report := newApplyReport()
+	report.setResult(deploymentKind, deployment, UnchangedResourceApplyStatus, "")
+
+	// The manager has no clients, so checking the workload would panic
+	require.Empty(t, manager.waitForReadiness(context.Background(), managedObjects, doNotFullResync, report))
+	require.Empty(t, manager.notReadyWorkloadKeys)
+}
+
+func TestGetNotReadyDestinations_OnlyTheSubsetsWithoutReadyWorkloads(t *testing.T) {
+	reviewsService := &managedObject{kind: serviceKind, object: newServiceForTesting(subsetTestName, subsetTestNamespace, map[string]string{"app": "reviews"})}
+	v1Workload := &managedObject{kind: deploymentKind, object: newDeploymentForTesting("reviews-v1", subsetTestNamespace, map[string]string{"app": "reviews", "version": "v1"})}
+	devWorkload := &managedObject{kind: deploymentKind, object: newDeploymentForTesting("reviews-dev", subsetTestNamespace, map[string]string{"app": "reviews", "version": "dev"})}
+	workloads := []*managedObject{v1Workload, devWorkload}
+	services := []*managedObject{reviewsService}
+	destinationRules := []*managedObject{{kind: destinationRuleKind, object: newReviewsDestinationRule()}}
+
+	require.Empty(t, getNotReadyDestinations(nil, workloads, services, destinationRules))
+	require.Equal(t, []notReadyDestination{{namespace: subsetTestNamespace, serviceName: subsetTestName, subsetName: "dev"}},
+		getNotReadyDestinations([]*managedObject{devWorkload}, workloads, services, destinationRules))
+	require.Equal(t, []notReadyDestination{{namespace: subsetTestNamespace, serviceName: subsetTestName, subsetName: wholeService}},
+		getNotReadyDestinations(workloads, workloads, services, destinationRules))
+}
+
+func TestHoldBackNotReadyRoutes_LeavesOutTheRoutesToTheNotReadySubsets(t *testing.T) {
+	virtualService := newReviewsVirtualService()
+	virtualService.Spec.Http[0].Match = []*istio.HTTPMatchRequest{{Headers: map[string]*istio.StringMatch{"x-kardinal-trace-id": {MatchType: &istio.StringMatch_Exact{Exact: "dev"}}}}}
+
+	prunedVirtualService, heldBackDestinations, isHeldBack := holdBackNotReadyRoutes(virtualService, []notReadyDestination{{namespace: "ratings", serviceName: "ratings", subsetName: "v1"}})
+	require.False(t, isHeldBack)
+	require.Empty(t, heldBackDestinations)
+	require.Same(t, virtualService, prunedVirtualService)
+
+	devDestination := notReadyDestination{namespace: subsetTestNamespace, serviceName: subsetTestName, subsetName: "dev"}
+	prunedVirtualService, heldBackDestinations, isHeldBack = holdBackNotReadyRoutes(virtualService, []notReadyDestination{devDestination})
+	require.False(t, isHeldBack)
+	require.Equal(t, []notReadyDestination{devDestination}, heldBackDestinations)
+	require.Equal(t, []string{"canary", "default"}, getRouteNames(prunedVirtualService))
+	require.Equal(t, int32(100), prunedVirtualService.Spec.Http[0].Route[0].Weight)
+	// The desired VirtualService is left as it is
+	require.Len(t, virtualService.Spec.Http, 3)
+
+	// The default route only goes to v1, so the requests it serves would be dropped
+	_, _, isHeldBack = holdBackNotReadyRoutes(virtualService, []notReadyDestination{{namespace: subsetTestNamespace, serviceName: subsetTestName, subsetName: "v1"}})
+	require.True(t, isHeldBack)
+
+	_, _, isHeldBack = holdBackNotReadyRoutes(virtualService, []notReadyDestination{{namespace: subsetTestNamespace, serviceName: subsetTestName, subsetName: wholeService}})
+	require.True(t, isHeldBack)
+}
+
+func TestApplyReport_SetResultReplacesThePreviousResult(t *testing.T) {
+	report := newApplyReport()
+	service := newServiceForTesting("reviews", defaultNamespace, nil)
+
+	report.setResult(serviceKind, service, AppliedResourceApplyStatus, "")
+	report.setResult(serviceKind, service, NotReadyResourceApplyStatus, "no ready endpoints")
+
+	require.Equal(t, []*ResourceApplyResult{
+		{Kind: "Service", Namespace: defaultNamespace, Name: "reviews", Status: NotReadyResourceApplyStatus, Message: "no ready endpoints"},
+	}, report.Results)
+	require.Equal(t, "0 applied, 0 unchanged, 0 failed, 1 not-ready, 0 held-back", report.String())
+}
+
+func newDeploymentForTesting(name string, namespace string, podLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+func newServiceForTesting(name string, namespace string, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+}
@@ -0,0 +1,97 @@
+package cluster_manager
+
+import (
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	coreGroup         = ""
+	appsGroup         = "apps"
+	batchGroup        = "batch"
+	istioNetworkGroup = "networking.istio.io"
+
+	coreVersion         = "v1"
+	appsVersion         = "v1"
+	batchVersion        = "v1"
+	istioNetworkVersion = "v1alpha3"
+
+	isNamespaced    = true
+	isClusterScoped = false
+)
+
+// resourceKind describes a Kubernetes kind managed through the dynamic client
+type resourceKind struct {
+	groupVersionKind     schema.GroupVersionKind
+	groupVersionResource schema.GroupVersionResource
+	isNamespaced         bool
+}
+
+var (
+	namespaceKind             = newResourceKind(coreGroup, coreVersion, "Namespace", "namespaces", isClusterScoped)
+	serviceKind               = newResourceKind(coreGroup, coreVersion, "Service", "services", isNamespaced)
+	configMapKind             = newResourceKind(coreGroup, coreVersion, "ConfigMap", "configmaps", isNamespaced)
+	secretKind                = newResourceKind(coreGroup, coreVersion, "Secret", "secrets", isNamespaced)
+	serviceAccountKind        = newResourceKind(coreGroup, coreVersion, "ServiceAccount", "serviceaccounts", isNamespaced)
+	persistentVolumeClaimKind = newResourceKind(coreGroup, coreVersion, "PersistentVolumeClaim", "persistentvolumeclaims", isNamespaced)
+	deploymentKind            = newResourceKind(appsGroup, appsVersion, "Deployment", "deployments", isNamespaced)
+	statefulSetKind           = newResourceKind(appsGroup, appsVersion, "StatefulSet", "statefulsets", isNamespaced)
+	jobKind                   = newResourceKind(batchGroup, batchVersion, "Job", "jobs", isNamespaced)
+	cronJobKind               = newResourceKind(batchGroup, batchVersion, "CronJob", "cronjobs", isNamespaced)
+	virtualServiceKind        = newResourceKind(istioNetworkGroup, istioNetworkVersion, "VirtualService", "virtualservices", isNamespaced)
+	destinationRuleKind       = newResourceKind(istioNetworkGroup, istioNetworkVersion, "DestinationRule", "destinationrules", isNamespaced)
+	gatewayKind               = newResourceKind(istioNetworkGroup, istioNetworkVersion, "Gateway", "gateways", isNamespaced)
+)
+
+func newResourceKind(group string, version string, kind string, resource string, isNamespaced bool) resourceKind {
+	return resourceKind{
+		groupVersionKind: schema.GroupVersionKind{
+			Group:   group,
+			Version: version,
+			Kind:    kind,
+		},
+		groupVersionResource: schema.GroupVersionResource{
+			Group:    group,
+			Version:  version,
+			Resource: resource,
+		},
+		isNamespaced: isNamespaced,
+	}
+}
+
+// managedKinds are the kinds Kardinal creates from the cluster resources and therefore the ones it cleans up
+var managedKinds = []resourceKind{
+	serviceAccountKind,
+	configMapKind,
+	secretKind,
+	persistentVolumeClaimKind,
+	serviceKind,
+	deploymentKind,
+	statefulSetKind,
+	jobKind,
+	cronJobKind,
+	virtualServiceKind,
+	destinationRuleKind,
+	gatewayKind,
+}
+
+type managedObject struct {
+	kind   resourceKind
+	object metav1.Object
+}
+
+func isManagedKind(kind resourceKind) bool {
+	return lo.ContainsBy(managedKinds, func(managedKind resourceKind) bool {
+		return managedKind.groupVersionKind.GroupKind() == kind.groupVersionKind.GroupKind()
+	})
+}
+
+// getRBACResourceName returns the resource the way the kubectl and the `kardinal manager deploy --extra-resource` flag
+// name it, e.g. 'certificates.cert-manager.io'
+func (kind resourceKind) getRBACResourceName() string {
+	if kind.groupVersionResource.Group == coreGroup {
+		return kind.groupVersionResource.Resource
+	}
+	return kind.groupVersionResource.Resource + "." + kind.groupVersionResource.Group
+}
@@ -0,0 +1,273 @@
+package cluster_manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"slices"
+)
+
+// Istio evaluates the HTTP routes of a virtual service in order, see
+// https://istio.io/latest/docs/concepts/traffic-management/#routing-rule-precedence, so the routing rules added through
+// the manager are kept before the other routes, sorted by descending priority. Their priorities are kept in an
+// annotation of the virtual service, by rule name
+const routingRulePrioritiesAnnotationKey = "dev.kardinal.routing-rule-priorities"
+
+var routingRuleResource = schema.GroupResource{Group: "", Resource: "routing rule"}
+
+// RoutingRule is a named HTTP route of a virtual service, the rules with a higher priority are evaluated first
+type RoutingRule struct {
+	HTTPRoute *istio.HTTPRoute
+	Priority  int32
+}
+
+// GetRoutingRules returns the routing rules added through the manager, in the order Istio evaluates them
+func (manager *ClusterManager) GetRoutingRules(ctx context.Context, namespace string, vsName string) ([]*RoutingRule, error) {
+	vs, err := manager.GetVirtualService(ctx, namespace, vsName)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred retrieving virtual service '%s'", vsName)
+	}
+
+	priorities, err := getRoutingRulePriorities(vs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the routing rule priorities of virtual service '%s'", vsName)
+	}
+
+	var routingRules []*RoutingRule
+	for _, httpRoute := range vs.Spec.GetHttp() {
+		if priority, found := priorities[httpRoute.GetName()]; found {
+			routingRules = append(routingRules, &RoutingRule{HTTPRoute: httpRoute, Priority: priority})
+		}
+	}
+	return routingRules, nil
+}
+
+// AddRoutingRule inserts the rule after the rules with a higher priority and before the ones with the same or a lower
+// priority. Adding the same rule again is a no-op, while adding a different rule with the name of an existing one fails
+func (manager *ClusterManager) AddRoutingRule(ctx context.Context, namespace string, vsName string, routingRule *istio.HTTPRoute, priority int32) error {
+	ruleName := routingRule.GetName()
+	if ruleName == "" {
+		return stacktrace.NewError("The routing rules need a name")
+	}
+
+	err := manager.updateRoutingRules(ctx, namespace, vsName, func(httpRoutes []*istio.HTTPRoute, priorities map[string]int32) ([]*istio.HTTPRoute, bool, error) {
+		existingRule, _, found := lo.FindIndexOf(httpRoutes, func(httpRoute *istio.HTTPRoute) bool {
+			return httpRoute.GetName() == ruleName
+		})
+		if !found {
+			return insertRoutingRule(httpRoutes, priorities, routingRule, priority), true, nil
+		}
+
+		existingPriority, isManaged := priorities[ruleName]
+		if isManaged && existingPriority == priority && proto.Equal(existingRule, routingRule) {
+			return nil, false, nil
+		}
+		return nil, false, apierrors.NewAlreadyExists(routingRuleResource, ruleName)
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred adding routing rule '%s' to virtual service '%s'", ruleName, vsName)
+	}
+	return nil
+}
+
+// ReplaceRoutingRule replaces the rule with the same name, moving it if its priority changed
+func (manager *ClusterManager) ReplaceRoutingRule(ctx context.Context, namespace string, vsName string, routingRule *istio.HTTPRoute, priority int32) error {
+	ruleName := routingRule.GetName()
+	if ruleName == "" {
+		return stacktrace.NewError("The routing rules need a name")
+	}
+
+	err := manager.updateRoutingRules(ctx, namespace, vsName, func(httpRoutes []*istio.HTTPRoute, priorities map[string]int32) ([]*istio.HTTPRoute, bool, error) {
+		httpRoutes, found := removeRoutingRule(httpRoutes, priorities, ruleName)
+		if !found {
+			return nil, false, apierrors.NewNotFound(routingRuleResource, ruleName)
+		}
+		return insertRoutingRule(httpRoutes, priorities, routingRule, priority), true, nil
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred replacing routing rule '%s' of virtual service '%s'", ruleName, vsName)
+	}
+	return nil
+}
+
+// RemoveRoutingRule removes the route with the given name, removing a rule that isn't there is a no-op
+func (manager *ClusterManager) RemoveRoutingRule(ctx context.Context, namespace string, vsName string, ruleName string) error {
+	err := manager.updateRoutingRules(ctx, namespace, vsName, func(httpRoutes []*istio.HTTPRoute, priorities map[string]int32) ([]*istio.HTTPRoute, bool, error) {
+		httpRoutes, found := removeRoutingRule(httpRoutes, priorities, ruleName)
+		return httpRoutes, found, nil
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing routing rule '%s' from virtual service '%s'", ruleName, vsName)
+	}
+	return nil
+}
+
+// updateRoutingRules reads the virtual service, lets the update function change its routes and priorities, and
+// updates it if they changed, retrying on conflicts
+func (manager *ClusterManager) updateRoutingRules(
+	ctx context.Context,
+	namespace string,
+	vsName string,
+	update func(httpRoutes []*istio.HTTPRoute, priorities map[string]int32) ([]*istio.HTTPRoute, bool, error),
+) error {
+	virtServiceClient := manager.istioClient.clientSet.NetworkingV1alpha3().VirtualServices(namespace)
+
+	// The errors are returned as they are in the retried function, so the conflicts can be detected
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vs, err := virtServiceClient.Get(ctx, vsName, globalGetOptions)
+		if err != nil {
+			return err
+		}
+
+		priorities, err := getRoutingRulePriorities(vs)
+		if err != nil {
+			return err
+		}
+
+		httpRoutes, hasChanged, err := update(vs.Spec.Http, priorities)
+		if err != nil || !hasChanged {
+			return err
+		}
+
+		vs.Spec.Http = httpRoutes
+		if err = setRoutingRulePriorities(vs, priorities); err != nil {
+			return err
+		}
+		// The update fails with a conflict if the virtual service changed since it was read
+		_, err = virtServiceClient.Update(ctx, vs, globalUpdateOptions)
+		return err
+	})
+}
+
+// addTrafficRoutingRules adds the routing rules of the canaries and mirrors in the cluster resources, and the faults of
+// the flows, to the VirtualServices they target. The VirtualServices are copied so the cluster resources are left as
+// they were received, and the invalid rules are left out and reported in the error so their VirtualServices are still
+// published with the rest of their routes
+func addTrafficRoutingRules(managedObjects []*managedObject, clusterResources *types.ClusterResources) error {
+	virtualServices := map[string]*v1alpha3.VirtualService{}
+	for _, managedObj := range managedObjects {
+		virtualService, ok := managedObj.object.(*v1alpha3.VirtualService)
+		if !ok {
+			continue
+		}
+		virtualServiceCopy := virtualService.DeepCopy()
+		managedObj.object = virtualServiceCopy
+		virtualServices[getObjectKey(virtualServiceCopy.GetNamespace(), virtualServiceCopy.GetName())] = virtualServiceCopy
+	}
+
+	var routingErrors []error
+	for _, canary := range lo.FromPtr(clusterResources.Canaries) {
+		if err := addCanaryRoutingRule(virtualServices, canary); err != nil {
+			routingErrors = append(routingErrors, stacktrace.Propagate(err, "An error occurred adding the routing rule of canary subset '%s'", canary.CanarySubset))
+		}
+	}
+	for _, mirror := range lo.FromPtr(clusterResources.Mirrors) {
+		if err := addMirrorRoutingRule(virtualServices, mirror); err != nil {
+			routingErrors = append(routingErrors, stacktrace.Propagate(err, "An error occurred adding the routing rule of mirror subset '%s'", mirror.MirrorSubset))
+		}
+	}
+	for _, flowFault := range lo.FromPtr(clusterResources.FlowFaults) {
+		if err := addFlowFault(virtualServices, flowFault); err != nil {
+			routingErrors = append(routingErrors, stacktrace.Propagate(err, "An error occurred adding the fault of flow '%s'", flowFault.FlowId))
+		}
+	}
+
+	return errors.Join(routingErrors...)
+}
+
+func getTargetVirtualService(virtualServices map[string]*v1alpha3.VirtualService, namespace string, vsName string) (*v1alpha3.VirtualService, error) {
+	virtualService, found := virtualServices[getObjectKey(namespace, vsName)]
+	if !found {
+		return nil, stacktrace.NewError("VirtualService '%s' in namespace '%s' isn't in the cluster resources", vsName, namespace)
+	}
+	return virtualService, nil
+}
+
+// setRoutingRule adds the routing rule to a VirtualService about to be applied, replacing the rule with the same name
+func setRoutingRule(vs *v1alpha3.VirtualService, routingRule *istio.HTTPRoute, priority int32) error {
+	priorities, err := getRoutingRulePriorities(vs)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the routing rule priorities of virtual service '%s'", vs.GetName())
+	}
+
+	httpRoutes, _ := removeRoutingRule(vs.Spec.Http, priorities, routingRule.GetName())
+	vs.Spec.Http = insertRoutingRule(httpRoutes, priorities, routingRule, priority)
+	return setRoutingRulePriorities(vs, priorities)
+}
+
+// insertRoutingRule keeps the managed rules before the other routes, except the ones which match every request. Those
+// go after the other routes with matches, e.g. the flow routes published by Kontrol, so they don't shadow them, and the
+// default route goes after all of them
+func insertRoutingRule(httpRoutes []*istio.HTTPRoute, priorities map[string]int32, routingRule *istio.HTTPRoute, priority int32) []*istio.HTTPRoute {
+	matchesEveryRequest := len(routingRule.GetMatch()) == 0
+	_, insertIndex, found := lo.FindIndexOf(httpRoutes, func(httpRoute *istio.HTTPRoute) bool {
+		routePriority, isManaged := priorities[httpRoute.GetName()]
+		if !isManaged {
+			return !matchesEveryRequest || len(httpRoute.GetMatch()) == 0
+		}
+		return routePriority <= priority
+	})
+	if !found || priority == defaultRouteRulePriority {
+		insertIndex = len(httpRoutes)
+	}
+	priorities[routingRule.GetName()] = priority
+	return slices.Insert(httpRoutes, insertIndex, routingRule)
+}
+
+func removeRoutingRule(httpRoutes []*istio.HTTPRoute, priorities map[string]int32, ruleName string) ([]*istio.HTTPRoute, bool) {
+	_, ruleIndex, found := lo.FindIndexOf(httpRoutes, func(httpRoute *istio.HTTPRoute) bool {
+		return httpRoute.GetName() == ruleName
+	})
+	if !found {
+		return httpRoutes, false
+	}
+	delete(priorities, ruleName)
+	return slices.Delete(httpRoutes, ruleIndex, ruleIndex+1), true
+}
+
+func getRoutingRulePriorities(vs *v1alpha3.VirtualService) (map[string]int32, error) {
+	priorities := map[string]int32{}
+	serializedPriorities, found := vs.GetAnnotations()[routingRulePrioritiesAnnotationKey]
+	if !found {
+		return priorities, nil
+	}
+	if err := json.Unmarshal([]byte(serializedPriorities), &priorities); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the '%s' annotation", routingRulePrioritiesAnnotationKey)
+	}
+	return priorities, nil
+}
+
+// setRoutingRulePriorities drops the priorities of the rules which aren't in the virtual service anymore, e.g. after
+// Kontrol published a new version of it
+func setRoutingRulePriorities(vs *v1alpha3.VirtualService, priorities map[string]int32) error {
+	routeNames := lo.Map(vs.Spec.GetHttp(), func(httpRoute *istio.HTTPRoute, _ int) string {
+		return httpRoute.GetName()
+	})
+	priorities = lo.PickByKeys(priorities, routeNames)
+
+	annotations := vs.GetAnnotations()
+	if len(priorities) == 0 {
+		delete(annotations, routingRulePrioritiesAnnotationKey)
+		return nil
+	}
+
+	serializedPriorities, err := json.Marshal(priorities)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred serializing the routing rule priorities")
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[routingRulePrioritiesAnnotationKey] = string(serializedPriorities)
+	vs.SetAnnotations(annotations)
+	return nil
+}
@@ -0,0 +1,111 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"istio.io/client-go/pkg/clientset/versioned/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+	"time"
+)
+
+func TestAddRoutingRule_KeepsTheRulesSortedByPriority(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("low"), 0))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("high"), 10))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("mid"), 5))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("newer-low"), 0))
+
+	// The routes which weren't added as routing rules are evaluated last
+	require.Equal(t, []string{"high", "mid", "newer-low", "low", "dev-flow", "canary", "default"}, getTestRouteNames(t, clusterManager))
+
+	routingRules, err := clusterManager.GetRoutingRules(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	require.Equal(t, []int32{10, 5, 0, 0}, lo.Map(routingRules, func(routingRule *RoutingRule, _ int) int32 {
+		return routingRule.Priority
+	}))
+}
+
+func TestAddRoutingRule_IsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("dev-2"), 0))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("dev-2"), 0))
+	require.Equal(t, []string{"dev-2", "dev-flow", "canary", "default"}, getTestRouteNames(t, clusterManager))
+
+	err := clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("dev-2"), 1)
+	require.True(t, apierrors.IsAlreadyExists(stacktrace.RootCause(err)))
+
+	err = clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, &istio.HTTPRoute{}, 0)
+	require.Error(t, err)
+}
+
+func TestReplaceAndRemoveRoutingRule(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("first"), 10))
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("second"), 5))
+
+	require.NoError(t, clusterManager.ReplaceRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("second"), 20))
+	require.Equal(t, []string{"second", "first", "dev-flow", "canary", "default"}, getTestRouteNames(t, clusterManager))
+
+	err := clusterManager.ReplaceRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("missing"), 0)
+	require.True(t, apierrors.IsNotFound(stacktrace.RootCause(err)))
+
+	require.NoError(t, clusterManager.RemoveRoutingRule(ctx, subsetTestNamespace, subsetTestName, "second"))
+	require.NoError(t, clusterManager.RemoveRoutingRule(ctx, subsetTestNamespace, subsetTestName, "second"))
+	require.Equal(t, []string{"first", "dev-flow", "canary", "default"}, getTestRouteNames(t, clusterManager))
+
+	virtualService, err := clusterManager.GetVirtualService(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	require.Equal(t, `{"first":10}`, virtualService.Annotations[routingRulePrioritiesAnnotationKey])
+}
+
+func TestAddRoutingRule_RetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	istioClientSet := fake.NewSimpleClientset(newReviewsDestinationRule(), newReviewsVirtualService())
+	clusterManager := NewClusterManager(nil, newIstioClient(istioClientSet, nil), "", false, time.Minute)
+
+	conflicts := 0
+	istioClientSet.PrependReactor("update", "virtualservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, apierrors.NewConflict(virtualServiceKind.groupVersionResource.GroupResource(), subsetTestName, nil)
+	})
+
+	require.NoError(t, clusterManager.AddRoutingRule(ctx, subsetTestNamespace, subsetTestName, newTestRoutingRule("dev-2"), 0))
+	require.Equal(t, 1, conflicts)
+	require.Equal(t, []string{"dev-2", "dev-flow", "canary", "default"}, getTestRouteNames(t, clusterManager))
+}
+
+func newTestRoutingRule(name string) *istio.HTTPRoute {
+	return &istio.HTTPRoute{
+		Name:  name,
+		Match: []*istio.HTTPMatchRequest{{Headers: map[string]*istio.StringMatch{"x-kardinal-flow": {MatchType: &istio.StringMatch_Exact{Exact: name}}}}},
+		Route: []*istio.HTTPRouteDestination{{Destination: &istio.Destination{Host: subsetTestName, Subset: "dev"}}},
+	}
+}
+
+func getTestRouteNames(t *testing.T, clusterManager *ClusterManager) []string {
+	virtualService, err := clusterManager.GetVirtualService(context.Background(), subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	return getRouteNames(virtualService)
+}
+
+func getRouteNames(virtualService *v1alpha3.VirtualService) []string {
+	return lo.Map(virtualService.Spec.GetHttp(), func(httpRoute *istio.HTTPRoute, _ int) string {
+		return httpRoute.GetName()
+	})
+}
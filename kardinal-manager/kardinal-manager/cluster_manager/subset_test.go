@@ -0,0 +1,129 @@
+package cluster_manager
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"istio.io/client-go/pkg/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+const (
+	subsetTestNamespace = "prod"
+	subsetTestName      = "reviews"
+)
+
+func TestAddSubset_UpdatesTheExistingSubset(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	err := clusterManager.AddSubset(ctx, subsetTestNamespace, subsetTestName, &istio.Subset{Name: "dev", Labels: map[string]string{"version": "dev-2"}})
+	require.NoError(t, err)
+	err = clusterManager.AddSubset(ctx, subsetTestNamespace, subsetTestName, &istio.Subset{Name: "v2", Labels: map[string]string{"version": "v2"}})
+	require.NoError(t, err)
+
+	destinationRule, err := clusterManager.GetDestinationRule(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	subsets := destinationRule.Spec.GetSubsets()
+	require.Len(t, subsets, 3)
+	require.Equal(t, "dev", subsets[1].GetName())
+	require.Equal(t, map[string]string{"version": "dev-2"}, subsets[1].GetLabels())
+	require.Equal(t, "v2", subsets[2].GetName())
+}
+
+func TestRemoveSubset_PrunesTheRoutesToTheSubset(t *testing.T) {
+	ctx := context.Background()
+	clusterManager := newSubsetTestClusterManager(newReviewsDestinationRule(), newReviewsVirtualService())
+
+	err := clusterManager.RemoveSubset(ctx, subsetTestNamespace, subsetTestName, "dev")
+	require.NoError(t, err)
+
+	destinationRule, err := clusterManager.GetDestinationRule(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	require.Len(t, destinationRule.Spec.GetSubsets(), 1)
+	require.Equal(t, "v1", destinationRule.Spec.GetSubsets()[0].GetName())
+
+	virtualService, err := clusterManager.GetVirtualService(ctx, subsetTestNamespace, subsetTestName)
+	require.NoError(t, err)
+	httpRoutes := virtualService.Spec.GetHttp()
+	// The route only sending traffic to the dev subset is gone, and the canary route sends everything to v1
+	require.Len(t, httpRoutes, 2)
+	require.Equal(t, "canary", httpRoutes[0].GetName())
+	require.Len(t, httpRoutes[0].GetRoute(), 1)
+	require.Equal(t, "v1", httpRoutes[0].GetRoute()[0].GetDestination().GetSubset())
+	require.Equal(t, int32(100), httpRoutes[0].GetRoute()[0].GetWeight())
+	require.Equal(t, "default", httpRoutes[1].GetName())
+
+	// Removing it again is a no-op
+	err = clusterManager.RemoveSubset(ctx, subsetTestNamespace, subsetTestName, "dev")
+	require.NoError(t, err)
+}
+
+func TestRemoveSubsetDestinations_RescalesTheWeightsLeft(t *testing.T) {
+	httpRoutes := []*istio.HTTPRoute{
+		{
+			Route: []*istio.HTTPRouteDestination{
+				{Destination: &istio.Destination{Host: "reviews", Subset: "v1"}, Weight: 30},
+				{Destination: &istio.Destination{Host: "reviews", Subset: "v2"}, Weight: 30},
+				{Destination: &istio.Destination{Host: "reviews", Subset: "dev"}, Weight: 40},
+			},
+		},
+	}
+
+	prunedHTTPRoutes, wasPruned := removeSubsetDestinations(httpRoutes, subsetTestNamespace, "reviews", "dev")
+	require.True(t, wasPruned)
+	require.Len(t, prunedHTTPRoutes[0].GetRoute(), 2)
+	require.Equal(t, int32(50), prunedHTTPRoutes[0].GetRoute()[0].GetWeight())
+	require.Equal(t, int32(50), prunedHTTPRoutes[0].GetRoute()[1].GetWeight())
+
+	// The same subset name on another host is left alone
+	_, wasPruned = removeSubsetDestinations(prunedHTTPRoutes, subsetTestNamespace, "ratings", "v1")
+	require.False(t, wasPruned)
+}
+
+func newSubsetTestClusterManager(destinationRule *v1alpha3.DestinationRule, virtualService *v1alpha3.VirtualService) *ClusterManager {
+	istioClientObj := newIstioClient(fake.NewSimpleClientset(destinationRule, virtualService), nil)
+	return NewClusterManager(nil, istioClientObj, "", false, time.Minute)
+}
+
+func newReviewsDestinationRule() *v1alpha3.DestinationRule {
+	return &v1alpha3.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{Name: subsetTestName, Namespace: subsetTestNamespace},
+		Spec: istio.DestinationRule{
+			Host: subsetTestName,
+			Subsets: []*istio.Subset{
+				{Name: "v1", Labels: map[string]string{"version": "v1"}},
+				{Name: "dev", Labels: map[string]string{"version": "dev"}},
+			},
+		},
+	}
+}
+
+func newReviewsVirtualService() *v1alpha3.VirtualService {
+	return &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: subsetTestName, Namespace: subsetTestNamespace},
+		Spec: istio.VirtualService{
+			Hosts: []string{subsetTestName},
+			Http: []*istio.HTTPRoute{
+				{
+					Name:  "dev-flow",
+					Route: []*istio.HTTPRouteDestination{{Destination: &istio.Destination{Host: subsetTestName, Subset: "dev"}}},
+				},
+				{
+					Name: "canary",
+					Route: []*istio.HTTPRouteDestination{
+						{Destination: &istio.Destination{Host: subsetTestName, Subset: "v1"}, Weight: 80},
+						{Destination: &istio.Destination{Host: "reviews.prod.svc.cluster.local", Subset: "dev"}, Weight: 20},
+					},
+				},
+				{
+					Name:  "default",
+					Route: []*istio.HTTPRouteDestination{{Destination: &istio.Destination{Host: subsetTestName, Subset: "v1"}}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package fetcher
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	backoffMultiplier = 2
+	// the wait is randomized between half and the whole computed interval, so several managers failing
+	// at the same time don't hammer Kontrol in lockstep
+	backoffJitterFactor = 0.5
+)
+
+type backoff struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+
+	consecutiveFailures int
+}
+
+func newBackoff(initialInterval time.Duration, maxInterval time.Duration) *backoff {
+	return &backoff{initialInterval: initialInterval, maxInterval: maxInterval, consecutiveFailures: 0}
+}
+
+// nextInterval registers a new failure and returns how long to wait before the next attempt
+func (backoff *backoff) nextInterval() time.Duration {
+	backoff.consecutiveFailures++
+
+	interval := backoff.initialInterval
+	for i := 1; i < backoff.consecutiveFailures && interval < backoff.maxInterval; i++ {
+		interval = interval * backoffMultiplier
+	}
+	if interval > backoff.maxInterval {
+		interval = backoff.maxInterval
+	}
+
+	jitter := time.Duration(rand.Float64() * backoffJitterFactor * float64(interval))
+
+	return interval - jitter
+}
+
+func (backoff *backoff) reset() {
+	backoff.consecutiveFailures = 0
+}
@@ -0,0 +1,42 @@
+package fetcher
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBackoff_GrowsExponentiallyUpToTheMaxInterval(t *testing.T) {
+	initialInterval := time.Second
+	maxInterval := 10 * time.Second
+	backoffObj := newBackoff(initialInterval, maxInterval)
+
+	expectedIntervals := []time.Duration{
+		1 * time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		10 * time.Second,
+		10 * time.Second,
+	}
+
+	for _, expectedInterval := range expectedIntervals {
+		interval := backoffObj.nextInterval()
+		require.LessOrEqual(t, interval, expectedInterval)
+		require.GreaterOrEqual(t, interval, time.Duration(float64(expectedInterval)*(1-backoffJitterFactor)))
+	}
+	require.Equal(t, len(expectedIntervals), backoffObj.consecutiveFailures)
+}
+
+func TestBackoff_Reset(t *testing.T) {
+	initialInterval := time.Second
+	backoffObj := newBackoff(initialInterval, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		backoffObj.nextInterval()
+	}
+	backoffObj.reset()
+
+	require.Equal(t, 0, backoffObj.consecutiveFailures)
+	require.LessOrEqual(t, backoffObj.nextInterval(), initialInterval)
+}
@@ -0,0 +1,145 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"io"
+	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"kardinal.kontrol/kardinal-manager/version"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// The cluster status endpoint is a sibling of the cluster resources one, e.g. /tenant/{uuid}/cluster-status
+	parentPathElement        = ".."
+	clusterStatusPathElement = "cluster-status"
+	jsonContentTypeHeaderVal = "application/json"
+	contentTypeHeaderKey     = "Content-Type"
+	clusterStatusPostTimeout = time.Second * 10
+	minSuccessfulStatusCode  = 200
+	maxSuccessfulStatusCode  = 299
+)
+
+// reportClusterStatus sends the result of a sync to Kontrol, so the CLI can tell when the flows were really applied,
+// a failure is only logged since it shouldn't make the sync fail
+func (fetcher *fetcher) reportClusterStatus(ctx context.Context, clusterResourcesVersion *string, applyReport *cluster_manager.ApplyReport, syncErr error) {
+	syncTime := time.Now().UTC()
+	if syncErr == nil {
+		fetcher.lastSuccessfulSyncTime = &syncTime
+	}
+
+	clusterStatus := newClusterStatus(clusterResourcesVersion, syncTime, fetcher.lastSuccessfulSyncTime, fetcher.isDryRun, applyReport, syncErr)
+
+	if err := fetcher.postClusterStatus(ctx, clusterStatus); err != nil {
+		logrus.Warnf("Failed to report the cluster status to Kontrol, the result of this sync won't be visible in the CLI. Error was:\n%s", err)
+	}
+}
+
+func (fetcher *fetcher) postClusterStatus(ctx context.Context, clusterStatus *types.ClusterStatus) error {
+	clusterStatusEndpoint, err := getClusterStatusEndpoint(fetcher.configEndpoint)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the cluster status endpoint")
+	}
+
+	clusterStatusBytes, err := json.Marshal(clusterStatus)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred marshalling the cluster status")
+	}
+
+	postCtx, cancel := context.WithTimeout(ctx, clusterStatusPostTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(postCtx, http.MethodPost, clusterStatusEndpoint, bytes.NewReader(clusterStatusBytes))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the request for endpoint '%s'", clusterStatusEndpoint)
+	}
+	request.Header.Set(contentTypeHeaderKey, jsonContentTypeHeaderVal)
+
+	resp, err := fetcher.httpClient.Do(request)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error posting the cluster status to endpoint '%s'", clusterStatusEndpoint)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < minSuccessfulStatusCode || resp.StatusCode > maxSuccessfulStatusCode {
+		responseBodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytesInErrorMessage))
+		return stacktrace.NewError("The cluster status endpoint '%s' returned an unexpected status '%s' with body '%s'", clusterStatusEndpoint, resp.Status, string(responseBodyBytes))
+	}
+
+	return nil
+}
+
+// getClusterStatusEndpoint turns e.g. https://kontrol/tenant/{uuid}/cluster-resources?foo=bar into
+// https://kontrol/tenant/{uuid}/cluster-status
+func getClusterStatusEndpoint(configEndpoint string) (string, error) {
+	configEndpointURL, err := url.Parse(configEndpoint)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred parsing the config endpoint '%s'", configEndpoint)
+	}
+
+	clusterStatusURL := configEndpointURL.JoinPath(parentPathElement, clusterStatusPathElement)
+	clusterStatusURL.RawQuery = ""
+
+	return clusterStatusURL.String(), nil
+}
+
+func newClusterStatus(
+	clusterResourcesVersion *string,
+	syncTime time.Time,
+	lastSuccessfulSyncTime *time.Time,
+	isDryRun bool,
+	applyReport *cluster_manager.ApplyReport,
+	syncErr error,
+) *types.ClusterStatus {
+	var applyResults []*cluster_manager.ResourceApplyResult
+	if applyReport != nil {
+		applyResults = applyReport.Results
+	}
+
+	resources := lo.Map(applyResults, func(result *cluster_manager.ResourceApplyResult, _ int) types.ResourceStatus {
+		return types.ResourceStatus{
+			Kind:      result.Kind,
+			Message:   lo.EmptyableToPtr(result.Message),
+			Name:      result.Name,
+			Namespace: lo.EmptyableToPtr(result.Namespace),
+			// The apply statuses and the API ones share the same values
+			Status: types.ResourceStatusStatus(result.Status),
+		}
+	})
+
+	return &types.ClusterStatus{
+		DryRun:                 lo.EmptyableToPtr(isDryRun),
+		Errors:                 getErrorMessages(syncErr),
+		LastSuccessfulSyncTime: lastSuccessfulSyncTime,
+		ManagerVersion:         version.Commit,
+		Resources:              resources,
+		SyncTime:               syncTime,
+		Version:                clusterResourcesVersion,
+	}
+}
+
+// getErrorMessages returns the root cause of every error joined with errors.Join, without the stack traces which are
+// only useful in the manager logs
+func getErrorMessages(err error) []string {
+	messages := []string{}
+	if err == nil {
+		return messages
+	}
+
+	rootCause := stacktrace.RootCause(err)
+	if joinedErrors, ok := rootCause.(interface{ Unwrap() []error }); ok {
+		for _, joinedErr := range joinedErrors.Unwrap() {
+			messages = append(messages, getErrorMessages(joinedErr)...)
+		}
+		return messages
+	}
+
+	return append(messages, rootCause.Error())
+}
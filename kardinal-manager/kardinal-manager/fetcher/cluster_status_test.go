@@ -0,0 +1,54 @@
+package fetcher
+
+import (
+	"errors"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"testing"
+	"time"
+)
+
+func TestGetClusterStatusEndpoint(t *testing.T) {
+	clusterStatusEndpoint, err := getClusterStatusEndpoint("https://app.kardinal.dev/api/tenant/1234/cluster-resources?version=5")
+	require.NoError(t, err)
+	require.Equal(t, "https://app.kardinal.dev/api/tenant/1234/cluster-status", clusterStatusEndpoint)
+}
+
+func TestGetErrorMessages_ReturnsTheRootCauseOfEveryJoinedError(t *testing.T) {
+	require.Empty(t, getErrorMessages(nil))
+	require.NotNil(t, getErrorMessages(nil))
+
+	applyErr := stacktrace.Propagate(stacktrace.NewError("Services [default/reviews] are not ready"), "Failed to apply cluster resources")
+	cleanUpErr := stacktrace.Propagate(errors.New("forbidden"), "Failed to clean up cluster resources")
+	syncErr := stacktrace.Propagate(errors.Join(applyErr, nil, cleanUpErr), "Sync failed")
+
+	require.Equal(t, []string{"Services [default/reviews] are not ready", "forbidden"}, getErrorMessages(syncErr))
+}
+
+func TestNewClusterStatus_MapsTheApplyReport(t *testing.T) {
+	syncTime := time.Now()
+	applyReport := &cluster_manager.ApplyReport{
+		Results: []*cluster_manager.ResourceApplyResult{
+			{Kind: "Namespace", Namespace: "", Name: "prod", Status: cluster_manager.UnchangedResourceApplyStatus, Message: ""},
+			{Kind: "VirtualService", Namespace: "prod", Name: "reviews", Status: cluster_manager.HeldBackResourceApplyStatus, Message: "reviews is not ready"},
+		},
+	}
+
+	clusterStatus := newClusterStatus(lo.ToPtr("5"), syncTime, nil, false, applyReport, nil)
+
+	require.Equal(t, "5", *clusterStatus.Version)
+	require.Equal(t, syncTime, clusterStatus.SyncTime)
+	require.Nil(t, clusterStatus.LastSuccessfulSyncTime)
+	require.Nil(t, clusterStatus.DryRun)
+	require.Empty(t, clusterStatus.Errors)
+	require.Equal(t, []types.ResourceStatus{
+		{Kind: "Namespace", Message: nil, Name: "prod", Namespace: nil, Status: types.Unchanged},
+		{Kind: "VirtualService", Message: lo.ToPtr("reviews is not ready"), Name: "reviews", Namespace: lo.ToPtr("prod"), Status: types.HeldBack},
+	}, clusterStatus.Resources)
+
+	// A fetch error has no apply report
+	require.NotNil(t, newClusterStatus(nil, syncTime, nil, false, nil, errors.New("connection refused")).Resources)
+}
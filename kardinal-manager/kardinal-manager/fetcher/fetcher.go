@@ -1,33 +1,119 @@
 package fetcher
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
 	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"io"
 	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"kardinal.kontrol/kardinal-manager/health"
+	"kardinal.kontrol/kardinal-manager/metrics"
 	"kardinal.kontrol/kardinal-manager/utils"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
 const (
 	defaultTickerDuration              = time.Second * 5
 	fetcherJobDurationSecondsEnvVarKey = "KARDINAL_MANAGER_FETCHER_JOB_DURATION_SECONDS"
+
+	defaultLongPollWaitSeconds          = 30
+	fetcherLongPollWaitSecondsEnvVarKey = "KARDINAL_MANAGER_FETCHER_LONG_POLL_WAIT_SECONDS"
+	longPollRequestTimeoutMargin        = time.Second * 10
+	minLongPollRequestInterval          = time.Second
+	defaultFullResyncDuration           = time.Minute * 5
+	fetcherFullResyncSecondsEnvVarKey   = "KARDINAL_MANAGER_FETCHER_FULL_RESYNC_SECONDS"
+	versionQueryParamKey                = "version"
+	waitQueryParamKey                   = "wait"
+	noVersion                           = ""
+	longPollingDisabledWaitSeconds      = 0
+
+	initialRetryInterval                   = time.Second
+	maxRetryInterval                       = time.Minute * 2
+	defaultMaxConsecutiveFailures          = 20
+	fetcherMaxConsecutiveFailuresEnvVarKey = "KARDINAL_MANAGER_FETCHER_MAX_CONSECUTIVE_FAILURES"
+	unlimitedConsecutiveFailures           = 0
+	maxResponseBodyBytesInErrorMessage     = 512
+
+	defaultDryRun          = false
+	fetcherDryRunEnvVarKey = "KARDINAL_MANAGER_DRY_RUN"
 )
 
 type fetcher struct {
 	clusterManager *cluster_manager.ClusterManager
 	configEndpoint string
+
+	longPollWaitSeconds int
+	httpClient          *http.Client
+
+	// lastAppliedVersion is the version of the last cluster resources applied, it's sent to Kontrol so the
+	// long-poll request is only answered when there is something new to apply
+	lastAppliedVersion string
+	// lastAppliedPayload is the last cluster resources response applied, so an unchanged response is a no-op even
+	// when Kontrol doesn't version the cluster resources
+	lastAppliedPayload []byte
+	lastFullSyncTime   time.Time
+	// isLongPollingSupported is false until Kontrol answers a long-poll request with a new version or a 304
+	isLongPollingSupported bool
+	// minLongPollRequestInterval is the minimum time between the starts of two long-poll requests
+	minLongPollRequestInterval time.Duration
+
+	// isDryRun makes the fetcher only log the changes it would make in the cluster
+	isDryRun bool
+
+	// lastSuccessfulSyncTime is reported to Kontrol with every cluster status
+	lastSuccessfulSyncTime *time.Time
+	// lastApplyReport is the result of applying the last applied version, it's reported again by the cycles with
+	// nothing new to apply
+	lastApplyReport *cluster_manager.ApplyReport
+
+	readiness *health.Readiness
 }
 
-func NewFetcher(clusterManager *cluster_manager.ClusterManager, configEndpoint string) *fetcher {
-	return &fetcher{clusterManager: clusterManager, configEndpoint: configEndpoint}
+func NewFetcher(clusterManager *cluster_manager.ClusterManager, configEndpoint string, readiness *health.Readiness) *fetcher {
+	longPollWaitSeconds := defaultLongPollWaitSeconds
+
+	longPollWaitSecondsEnvVarValue, err := utils.GetIntFromEnvVar(fetcherLongPollWaitSecondsEnvVarKey, "fetcher long-poll wait seconds")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the fetcher long-poll wait seconds from the env var, using default value '%d'. Error:\n%s", defaultLongPollWaitSeconds, err)
+	} else {
+		longPollWaitSeconds = longPollWaitSecondsEnvVarValue
+	}
+
+	isDryRun, err := utils.GetBoolFromEnvVar(fetcherDryRunEnvVarKey, "fetcher dry-run")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the fetcher dry-run value from the env var, using default value '%t'. Error:\n%s", defaultDryRun, err)
+		isDryRun = defaultDryRun
+	}
+
+	return &fetcher{
+		clusterManager:      clusterManager,
+		configEndpoint:      configEndpoint,
+		longPollWaitSeconds: longPollWaitSeconds,
+		httpClient: &http.Client{
+			Timeout: time.Second*time.Duration(longPollWaitSeconds) + longPollRequestTimeoutMargin,
+		},
+		lastAppliedVersion:         noVersion,
+		lastAppliedPayload:         nil,
+		lastFullSyncTime:           time.Time{},
+		isLongPollingSupported:     false,
+		minLongPollRequestInterval: minLongPollRequestInterval,
+		isDryRun:                   isDryRun,
+		lastSuccessfulSyncTime:     nil,
+		lastApplyReport:            nil,
+		readiness:                  readiness,
+	}
 }
 
+// Run keeps a long-poll request open against Kontrol so flow changes are applied as soon as they are published,
+// if Kontrol doesn't support long-polling it falls back to fetching the cluster resources every tick
 func (fetcher *fetcher) Run(ctx context.Context) error {
 
 	fetcherTickerDuration := defaultTickerDuration
@@ -41,66 +127,280 @@ func (fetcher *fetcher) Run(ctx context.Context) error {
 		fetcherTickerDuration = time.Second * time.Duration(int64(fetcherJobDurationSecondsEnVarValue))
 	}
 
+	fullResyncDuration := defaultFullResyncDuration
+
+	fullResyncSecondsEnvVarValue, err := utils.GetIntFromEnvVar(fetcherFullResyncSecondsEnvVarKey, "fetcher full resync seconds")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the fetcher full resync seconds from the env var, using default value '%s'. Error:\n%s", defaultFullResyncDuration, err)
+	}
+
+	if fullResyncSecondsEnvVarValue != 0 {
+		fullResyncDuration = time.Second * time.Duration(int64(fullResyncSecondsEnvVarValue))
+	}
+
+	maxConsecutiveFailures := defaultMaxConsecutiveFailures
+
+	maxConsecutiveFailuresEnvVarValue, err := utils.GetIntFromEnvVar(fetcherMaxConsecutiveFailuresEnvVarKey, "fetcher max consecutive failures")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the fetcher max consecutive failures from the env var, using default value '%d'. Error:\n%s", defaultMaxConsecutiveFailures, err)
+	} else {
+		maxConsecutiveFailures = maxConsecutiveFailuresEnvVarValue
+	}
+
+	retryBackoff := newBackoff(initialRetryInterval, maxRetryInterval)
+
+	// Every leadership term starts with a full resync, the objects applied by the previous leader are cleaned up too
+	fetcher.lastFullSyncTime = time.Time{}
+
 	ticker := time.NewTicker(fetcherTickerDuration)
 	defer ticker.Stop()
 
 	for {
+		// Forgetting the last applied version forces Kontrol to answer right away, so the whole set of resources
+		// is applied again, even the objects whose desired state didn't change, and the drift in the cluster gets
+		// corrected
+		if time.Since(fetcher.lastFullSyncTime) > fullResyncDuration {
+			fetcher.lastAppliedVersion = noVersion
+			fetcher.lastAppliedPayload = nil
+		}
+
+		cycleStartTime := time.Now()
+		logrus.Debugf("New fetcher execution at %s", cycleStartTime)
+		if err := fetcher.fetchAndApply(ctx); err != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			// A single failed cycle (Kontrol down, API server conflicts, etc.) shouldn't take the manager down,
+			// only a run of failures long enough to exceed the budget is reported to the caller
+			if maxConsecutiveFailures != unlimitedConsecutiveFailures && retryBackoff.consecutiveFailures+1 >= maxConsecutiveFailures {
+				return stacktrace.Propagate(err, "Failed to fetch and apply the cluster configuration %d consecutive times", maxConsecutiveFailures)
+			}
+			retryInterval := retryBackoff.nextInterval()
+			logrus.Errorf("Failed to fetch and apply the cluster configuration (%d consecutive failures), retrying in %s. Error was:\n%s", retryBackoff.consecutiveFailures, retryInterval, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+		retryBackoff.reset()
+
+		// A Kontrol answering the long-poll requests right away, e.g. with a 304, would make the loop spin, so
+		// the next request waits until the minimum interval since this one passed
+		if fetcher.isLongPollingSupported {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(fetcher.minLongPollRequestInterval - time.Since(cycleStartTime)):
+			}
+			continue
+		}
+
 		select {
+		case <-ctx.Done():
+			return nil
 		case <-ticker.C:
-			logrus.Debugf("New fetcher execution at %s", time.Now())
-			if err := fetcher.fetchAndApply(ctx); err != nil {
-				return stacktrace.Propagate(err, "Failed to fetch and apply the cluster configuration")
-			}
 		}
 	}
 }
 
 func (fetcher *fetcher) fetchAndApply(ctx context.Context) error {
-	clusterResources, err := fetcher.getClusterResourcesFromCloud()
+	clusterResources, payload, err := fetcher.getClusterResourcesFromCloud(ctx)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred fetching cluster resources from cloud")
+		err = stacktrace.Propagate(err, "An error occurred fetching cluster resources from cloud")
+		recordSyncResult(err)
+		fetcher.reportClusterStatus(ctx, nil, nil, err)
+		return err
+	}
+
+	// Every cycle is reported, even when there is nothing new to apply, so the CLI gets the result of a change which
+	// didn't change the cluster resources too
+	if clusterResources == nil {
+		fetcher.reportClusterStatus(ctx, lo.EmptyableToPtr(fetcher.lastAppliedVersion), fetcher.lastApplyReport, nil)
+		return nil
+	}
+
+	if fetcher.lastAppliedPayload != nil && bytes.Equal(payload, fetcher.lastAppliedPayload) {
+		logrus.Debugf("The cluster resources didn't change since the last apply, nothing to do")
+		fetcher.reportClusterStatus(ctx, clusterResources.Version, fetcher.lastApplyReport, nil)
+		return nil
+	}
+
+	if clusterResources.Version != nil && *clusterResources.Version != noVersion && *clusterResources.Version == fetcher.lastAppliedVersion {
+		logrus.Debugf("The cluster resources version '%s' has already been applied, nothing to do", fetcher.lastAppliedVersion)
+		fetcher.reportClusterStatus(ctx, clusterResources.Version, fetcher.lastApplyReport, nil)
+		return nil
+	}
+
+	if fetcher.isDryRun {
+		plan, err := fetcher.clusterManager.PlanClusterResources(ctx, clusterResources)
+		if err != nil {
+			err = stacktrace.Propagate(err, "Failed to plan the cluster resources changes")
+			fetcher.reportClusterStatus(ctx, clusterResources.Version, nil, err)
+			return err
+		}
+		logrus.Infof("Running in dry-run mode, the following changes were not applied:\n%s", plan)
+		fetcher.reportClusterStatus(ctx, clusterResources.Version, nil, nil)
+		fetcher.recordApplied(clusterResources, payload, nil)
+		fetcher.readiness.RecordSuccessfulSync()
+		return nil
 	}
 
-	if err = fetcher.clusterManager.ApplyClusterResources(ctx, clusterResources); err != nil {
-		return stacktrace.Propagate(err, "Failed to apply cluster resources '%+v'", clusterResources)
+	// The clean-up runs even when some resources failed to apply, it only removes what is no longer desired
+	syncStartTime := time.Now()
+	// Nothing recorded as applied means it's the first sync or a full resync
+	isFullResync := fetcher.lastAppliedPayload == nil
+	applyReport, applyErr := fetcher.clusterManager.ApplyClusterResources(ctx, clusterResources, isFullResync)
+	logrus.Infof("Cluster resources version '%s' apply result: %s", lo.FromPtr(clusterResources.Version), applyReport)
+	if applyErr != nil {
+		// The cluster resources are not printed because they can contain Secrets
+		applyErr = stacktrace.Propagate(applyErr, "Failed to apply cluster resources version '%s'", lo.FromPtr(clusterResources.Version))
 	}
 
-	if err = fetcher.clusterManager.CleanUpClusterResources(ctx, clusterResources); err != nil {
-		return stacktrace.Propagate(err, "Failed to clean up cluster resources '%+v'", clusterResources)
+	cleanUpErr := fetcher.clusterManager.CleanUpClusterResources(ctx, clusterResources, isFullResync)
+	if cleanUpErr != nil {
+		cleanUpErr = stacktrace.Propagate(cleanUpErr, "Failed to clean up cluster resources version '%s'", lo.FromPtr(clusterResources.Version))
 	}
 
+	syncErr := errors.Join(applyErr, cleanUpErr)
+	metrics.SyncDurationSeconds.Observe(time.Since(syncStartTime).Seconds())
+	recordSyncResult(syncErr)
+	fetcher.reportClusterStatus(ctx, clusterResources.Version, applyReport, syncErr)
+	if syncErr != nil {
+		return syncErr
+	}
+
+	fetcher.recordApplied(clusterResources, payload, applyReport)
+	fetcher.readiness.RecordSuccessfulSync()
+
 	return nil
 }
 
-func (fetcher *fetcher) getClusterResourcesFromCloud() (*types.ClusterResources, error) {
+// recordSyncResult counts the fetch errors as failed syncs too, like the cluster status reported to Kontrol does
+func recordSyncResult(syncErr error) {
+	syncTimestamp := float64(time.Now().Unix())
+	metrics.LastSyncTimestampSeconds.Set(syncTimestamp)
+	if syncErr != nil {
+		metrics.SyncsTotal.Inc(metrics.FailedSyncResult)
+		return
+	}
+	metrics.SyncsTotal.Inc(metrics.SuccessfulSyncResult)
+	metrics.LastSuccessfulSyncTimestampSeconds.Set(syncTimestamp)
+}
+
+func (fetcher *fetcher) recordApplied(clusterResources *types.ClusterResources, payload []byte, applyReport *cluster_manager.ApplyReport) {
+	fetcher.lastAppliedPayload = payload
+	fetcher.lastApplyReport = applyReport
+	if fetcher.lastAppliedVersion == noVersion {
+		fetcher.lastFullSyncTime = time.Now()
+	}
+	if clusterResources.Version != nil {
+		fetcher.lastAppliedVersion = *clusterResources.Version
+	}
+}
+
+// getClusterResourcesFromCloud returns nil cluster resources when there is nothing new to apply, and the raw response
+// payload otherwise
+func (fetcher *fetcher) getClusterResourcesFromCloud(ctx context.Context) (*types.ClusterResources, []byte, error) {
 
 	configEndpointURL, err := url.Parse(fetcher.configEndpoint)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred parsing the config endpoint '%s'", fetcher.configEndpoint)
+		return nil, nil, stacktrace.Propagate(err, "An error occurred parsing the config endpoint '%s'", fetcher.configEndpoint)
 	}
 
-	resp, err := http.Get(configEndpointURL.String())
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Error fetching cluster resources from endpoint '%s'", fetcher.configEndpoint)
+	knownVersion := fetcher.lastAppliedVersion
+	isLongPollRequest := knownVersion != noVersion && fetcher.longPollWaitSeconds > longPollingDisabledWaitSeconds
+	if isLongPollRequest {
+		queryValues := configEndpointURL.Query()
+		queryValues.Set(versionQueryParamKey, knownVersion)
+		queryValues.Set(waitQueryParamKey, strconv.Itoa(fetcher.longPollWaitSeconds))
+		configEndpointURL.RawQuery = queryValues.Encode()
 	}
-	defer resp.Body.Close()
-	logrus.Debugf("Fetching cluster resources from endpoint '%s'", fetcher.configEndpoint)
 
-	responseBodyBytes, err := io.ReadAll(resp.Body)
+	statusCode, responseBodyBytes, err := requestClusterResources(ctx, fetcher.httpClient, configEndpointURL.String())
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "Error reading the response from '%v'", fetcher.configEndpoint)
+		return nil, nil, stacktrace.Propagate(err, "An error occurred requesting the cluster resources from endpoint '%s'", fetcher.configEndpoint)
+	}
+
+	if statusCode == http.StatusNotModified {
+		logrus.Debugf("The cluster resources didn't change from version '%s' during the long-poll wait", knownVersion)
+		fetcher.isLongPollingSupported = true
+		return nil, nil, nil
 	}
 
 	if len(responseBodyBytes) == 0 {
 		logrus.Debugf("The cluster resources endpoint '%s' returned an empty body", fetcher.configEndpoint)
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	var clusterResources *types.ClusterResources
 
 	if err = json.Unmarshal(responseBodyBytes, &clusterResources); err != nil {
-		return nil, stacktrace.Propagate(err, "And error occurred unmarshalling the response to a config response object")
+		return nil, nil, stacktrace.Propagate(err, "And error occurred unmarshalling the response to a config response object")
+	}
+
+	// An endpoint which ignores the long-poll query params answers right away with the same (or without) version
+	if isLongPollRequest {
+		fetcher.isLongPollingSupported = clusterResources != nil && clusterResources.Version != nil && *clusterResources.Version != knownVersion
+	}
+
+	return clusterResources, responseBodyBytes, nil
+}
+
+// PlanClusterResources plans the changes for the cluster resources currently published by Kontrol, it can run on any
+// replica because it only reads the fields set when creating the fetcher
+func (fetcher *fetcher) PlanClusterResources(ctx context.Context) (*cluster_manager.Plan, error) {
+	_, responseBodyBytes, err := requestClusterResources(ctx, fetcher.httpClient, fetcher.configEndpoint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred requesting the cluster resources from endpoint '%s'", fetcher.configEndpoint)
+	}
+
+	var clusterResources *types.ClusterResources
+	if len(responseBodyBytes) > 0 {
+		if err = json.Unmarshal(responseBodyBytes, &clusterResources); err != nil {
+			return nil, stacktrace.Propagate(err, "And error occurred unmarshalling the response to a config response object")
+		}
+	}
+
+	plan, err := fetcher.clusterManager.PlanClusterResources(ctx, clusterResources)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred planning the cluster resources changes")
+	}
+
+	return plan, nil
+}
+
+// requestClusterResources returns the response status code and body, which is only read when the status is OK
+func requestClusterResources(ctx context.Context, httpClient *http.Client, endpointURL string) (int, []byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
+	if err != nil {
+		return 0, nil, stacktrace.Propagate(err, "An error occurred creating the request for endpoint '%s'", endpointURL)
+	}
+
+	logrus.Debugf("Fetching cluster resources from endpoint '%s'", endpointURL)
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		return 0, nil, stacktrace.Propagate(err, "Error fetching cluster resources from endpoint '%s'", endpointURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return resp.StatusCode, nil, nil
+	}
+
+	responseBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, stacktrace.Propagate(err, "Error reading the response from '%v'", endpointURL)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		responseBodyStr := string(responseBodyBytes)
+		if len(responseBodyStr) > maxResponseBodyBytesInErrorMessage {
+			responseBodyStr = responseBodyStr[:maxResponseBodyBytesInErrorMessage]
+		}
+		return 0, nil, stacktrace.NewError("The cluster resources endpoint '%s' returned an unexpected status '%s' with body '%s'", endpointURL, resp.Status, responseBodyStr)
 	}
 
-	return clusterResources, nil
+	return resp.StatusCode, responseBodyBytes, nil
 }
@@ -2,20 +2,33 @@ package fetcher
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/kurtosis-tech/kardinal/libs/manager-kontrol-api/api/golang/types"
 	"github.com/stretchr/testify/require"
 	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"kardinal.kontrol/kardinal-manager/health"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
+)
+
+const (
+	doNotForceApplyOwnership = false
+	testTenantUuid           = "00000000-0000-0000-0000-000000000000"
+	noReadinessTimeout       = 0
 )
 
 // This test can be executed and use Minikube dashboard and Kiali Dashboard to see the changes between prod apply and devInProd apply
 // these code is meant for local iteration for now and less for unit testing
 func TestVotingAppDemoProdAndDevCase(t *testing.T) {
-	clusterManager, err := cluster_manager.CreateClusterManager()
+	clusterManager, err := cluster_manager.CreateClusterManager(testTenantUuid, doNotForceApplyOwnership, noReadinessTimeout)
 	require.NoError(t, err)
 
 	prodOnlyDemoConfigEndpoint := "https://gist.githubusercontent.com/leoporoli/477b9b95238ffa994fb62849debb9abc/raw/b911cbe28df8cb65bf84834f666f94488937c364/cluster-resources-examples.json"
 
-	prodFetcher := NewFetcher(clusterManager, prodOnlyDemoConfigEndpoint)
+	prodFetcher := NewFetcher(clusterManager, prodOnlyDemoConfigEndpoint, health.NewReadiness())
 
 	ctx := context.Background()
 
@@ -27,7 +40,7 @@ func TestVotingAppDemoProdAndDevCase(t *testing.T) {
 
 	devInProdEndpoint := "https://gist.githubusercontent.com/leoporoli/d3e3afb29fa0dcc12738df558b263154/raw/7da19c18d34edf09bd2fe2939134b1d0424d1c2b/cluster-resources-for-dev.json"
 
-	devInProdFetcher := NewFetcher(clusterManager, devInProdEndpoint)
+	devInProdFetcher := NewFetcher(clusterManager, devInProdEndpoint, health.NewReadiness())
 
 	err = devInProdFetcher.fetchAndApply(ctx)
 	require.NoError(t, err)
@@ -41,3 +54,107 @@ func TestVotingAppDemoProdAndDevCase(t *testing.T) {
 
 	// Now you can check that dev components has been removed from the cluster
 }
+
+func TestFetchAndApply_ReportsTheClusterStatusWhenThereIsNothingNewToApply(t *testing.T) {
+	payload := []byte(`{"version":"5"}`)
+	var isNotModified atomic.Bool
+	clusterStatuses := make(chan *types.ClusterStatus, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tenant/1234/cluster-resources":
+			if isNotModified.Load() {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write(payload)
+		case "/tenant/1234/cluster-status":
+			var clusterStatus *types.ClusterStatus
+			if err := json.NewDecoder(r.Body).Decode(&clusterStatus); err != nil {
+				t.Errorf("An error occurred decoding the cluster status: %s", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			clusterStatuses <- clusterStatus
+		}
+	}))
+	defer server.Close()
+
+	applyReport := &cluster_manager.ApplyReport{
+		Results: []*cluster_manager.ResourceApplyResult{
+			{Kind: "Service", Namespace: "prod", Name: "reviews", Status: cluster_manager.AppliedResourceApplyStatus, Message: ""},
+		},
+	}
+	testFetcher := NewFetcher(nil, server.URL+"/tenant/1234/cluster-resources", health.NewReadiness())
+	testFetcher.recordApplied(&types.ClusterResources{Version: nil}, payload, applyReport)
+	testFetcher.lastAppliedVersion = "5"
+
+	// The same payload, then a long-poll request answered with a 304
+	require.NoError(t, testFetcher.fetchAndApply(context.Background()))
+	isNotModified.Store(true)
+	require.NoError(t, testFetcher.fetchAndApply(context.Background()))
+
+	// The status is reported before fetchAndApply returns, so both are already in the channel
+	require.Len(t, clusterStatuses, 2)
+	close(clusterStatuses)
+	for clusterStatus := range clusterStatuses {
+		require.Equal(t, "5", *clusterStatus.Version)
+		require.Empty(t, clusterStatus.Errors)
+		require.Nil(t, clusterStatus.DryRun)
+		require.Len(t, clusterStatus.Resources, 1)
+		require.Equal(t, types.Applied, clusterStatus.Resources[0].Status)
+	}
+}
+
+func TestRun_KeepsTheMinimumIntervalBetweenTheLongPollRequestsAnsweredRightAway(t *testing.T) {
+	var clusterResourcesRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tenant/1234/cluster-resources" {
+			clusterResourcesRequests.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}))
+	defer server.Close()
+
+	testFetcher := NewFetcher(nil, server.URL+"/tenant/1234/cluster-resources", health.NewReadiness())
+	testFetcher.minLongPollRequestInterval = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*testFetcher.minLongPollRequestInterval)
+	defer cancel()
+
+	runStartTime := time.Now()
+	require.NoError(t, testFetcher.Run(ctx))
+	runDuration := time.Since(runStartTime)
+
+	require.True(t, testFetcher.isLongPollingSupported)
+	// Every request starts at least the minimum interval after the previous one, however slow the scheduling is
+	maxClusterResourcesRequests := int32(runDuration/testFetcher.minLongPollRequestInterval) + 1
+	require.Positive(t, clusterResourcesRequests.Load())
+	require.LessOrEqual(t, clusterResourcesRequests.Load(), maxClusterResourcesRequests)
+}
+
+func TestPlanClusterResources_PlansTheClusterResourcesPublishedRightNow(t *testing.T) {
+	var isKontrolDown atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The plan never long-polls, it has to return the changes right away
+		if r.URL.RawQuery != "" {
+			t.Errorf("the plan request has the query '%s'", r.URL.RawQuery)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if isKontrolDown.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	testFetcher := NewFetcher(nil, server.URL+"/tenant/1234/cluster-resources", health.NewReadiness())
+	testFetcher.lastAppliedVersion = "5"
+
+	plan, err := testFetcher.PlanClusterResources(context.Background())
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+
+	isKontrolDown.Store(true)
+	_, err = testFetcher.PlanClusterResources(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package health
+
+import "sync/atomic"
+
+// Readiness backs the readiness probe. The leader is ready once it synced the cluster resources without errors at least
+// once, the standbys are always ready since they are only waiting to take over, otherwise they would block the rollouts
+type Readiness struct {
+	isLeader  atomic.Bool
+	hasSynced atomic.Bool
+}
+
+func NewReadiness() *Readiness {
+	return &Readiness{
+		isLeader:  atomic.Bool{},
+		hasSynced: atomic.Bool{},
+	}
+}
+
+func (readiness *Readiness) SetLeader(isLeader bool) {
+	readiness.isLeader.Store(isLeader)
+}
+
+func (readiness *Readiness) RecordSuccessfulSync() {
+	readiness.hasSynced.Store(true)
+}
+
+func (readiness *Readiness) IsReady() bool {
+	return !readiness.isLeader.Load() || readiness.hasSynced.Load()
+}
@@ -0,0 +1,17 @@
+package health
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReadiness_TheLeaderIsOnlyReadyAfterTheFirstSuccessfulSync(t *testing.T) {
+	readiness := NewReadiness()
+	require.True(t, readiness.IsReady())
+
+	readiness.SetLeader(true)
+	require.False(t, readiness.IsReady())
+
+	readiness.RecordSuccessfulSync()
+	require.True(t, readiness.IsReady())
+}
@@ -0,0 +1,119 @@
+package leader_election
+
+import (
+	"context"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"time"
+)
+
+const (
+	leaseName = "kardinal-manager-leader"
+
+	// A standby takes over at most leaseDuration after the leader stops renewing the lease, and right away when the
+	// leader shuts down gracefully since the lease is released
+	leaseDuration   = time.Second * 15
+	renewDeadline   = time.Second * 10
+	retryPeriod     = time.Second * 2
+	releaseOnCancel = true
+)
+
+// LeaderElector makes sure only one of the manager replicas runs the fetcher at any time
+type LeaderElector struct {
+	kubernetesClientSet kubernetes.Interface
+	namespace           string
+	identity            string
+}
+
+func NewLeaderElector(kubernetesClientSet kubernetes.Interface, namespace string, identity string) *LeaderElector {
+	return &LeaderElector{
+		kubernetesClientSet: kubernetesClientSet,
+		namespace:           namespace,
+		identity:            identity,
+	}
+}
+
+// Run blocks until the context is cancelled, calling run every time this replica becomes the leader. The context
+// passed to run is cancelled when the leadership is lost, and an error returned by run is returned right away
+func (elector *LeaderElector) Run(ctx context.Context, run func(ctx context.Context) error) error {
+	for {
+		isLeadershipLost, err := elector.campaign(ctx, run)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred running as the leader")
+		}
+		if !isLeadershipLost {
+			return nil
+		}
+		logrus.Warnf("Replica '%s' lost the leadership, running as a standby until it's elected again", elector.identity)
+	}
+}
+
+// campaign waits to become the leader and then calls run until it returns, it returns whether the leadership was lost
+// as opposed to the context being cancelled
+func (elector *LeaderElector) campaign(ctx context.Context, run func(ctx context.Context) error) (bool, error) {
+	electionCtx, cancelElection := context.WithCancel(ctx)
+	defer cancelElection()
+
+	// run is called from this goroutine rather than the elector one, so the lease is only released once it returned
+	leadingCtxs := make(chan context.Context, 1)
+
+	leaderElector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      leaseName,
+				Namespace: elector.namespace,
+			},
+			Client: elector.kubernetesClientSet.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity:      elector.identity,
+				EventRecorder: nil,
+			},
+		},
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: releaseOnCancel,
+		Name:            leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadingCtx context.Context) {
+				leadingCtxs <- leadingCtx
+			},
+			OnStoppedLeading: func() {},
+			OnNewLeader: func(leaderIdentity string) {
+				if leaderIdentity != elector.identity {
+					logrus.Infof("Replica '%s' is the leader, replica '%s' is running as a standby", leaderIdentity, elector.identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return false, stacktrace.Propagate(err, "An error occurred creating the leader elector for lease '%s' in namespace '%s'", leaseName, elector.namespace)
+	}
+
+	electionDone := make(chan struct{})
+	go func() {
+		defer close(electionDone)
+		leaderElector.Run(electionCtx)
+	}()
+
+	select {
+	case <-electionDone:
+		// The context was cancelled before this replica became the leader
+		return false, nil
+	case leadingCtx := <-leadingCtxs:
+		logrus.Infof("Replica '%s' is now the leader", elector.identity)
+		runErr := run(leadingCtx)
+
+		cancelElection()
+		<-electionDone
+
+		if runErr != nil {
+			return false, stacktrace.Propagate(runErr, "An error occurred while replica '%s' was the leader", elector.identity)
+		}
+		return ctx.Err() == nil, nil
+	}
+}
@@ -0,0 +1,52 @@
+package leader_election
+
+import (
+	"context"
+	"errors"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+const (
+	testNamespace = "default"
+	testIdentity  = "kardinal-manager-7d9f8-abcde"
+)
+
+func TestLeaderElector_RunsUntilTheContextIsCancelledAndReleasesTheLease(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	elector := NewLeaderElector(clientSet, testNamespace, testIdentity)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var leaseHolderWhileLeading string
+	err := elector.Run(ctx, func(leadingCtx context.Context) error {
+		lease, err := clientSet.CoordinationV1().Leases(testNamespace).Get(leadingCtx, leaseName, metav1.GetOptions{})
+		require.NoError(t, err)
+		leaseHolderWhileLeading = *lease.Spec.HolderIdentity
+
+		cancel()
+		<-leadingCtx.Done()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, testIdentity, leaseHolderWhileLeading)
+
+	// The lease is released on shutdown so a standby doesn't have to wait for it to expire
+	lease, err := clientSet.CoordinationV1().Leases(testNamespace).Get(context.Background(), leaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, lease.Spec.HolderIdentity)
+}
+
+func TestLeaderElector_ReturnsTheRunError(t *testing.T) {
+	elector := NewLeaderElector(fake.NewSimpleClientset(), testNamespace, testIdentity)
+
+	runErr := errors.New("the consecutive failures budget is exhausted")
+	err := elector.Run(context.Background(), func(_ context.Context) error {
+		return runErr
+	})
+	require.Equal(t, runErr, stacktrace.RootCause(err))
+}
@@ -5,46 +5,126 @@ import (
 	"github.com/sirupsen/logrus"
 	"kardinal.kontrol/kardinal-manager/cluster_manager"
 	"kardinal.kontrol/kardinal-manager/fetcher"
+	"kardinal.kontrol/kardinal-manager/health"
+	"kardinal.kontrol/kardinal-manager/leader_election"
 	"kardinal.kontrol/kardinal-manager/logger"
+	"kardinal.kontrol/kardinal-manager/server"
 	"kardinal.kontrol/kardinal-manager/utils"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const (
-	successExitCode                = 0
-	clusterConfigEndpointEnvVarKey = "KARDINAL_MANAGER_CLUSTER_CONFIG_ENDPOINT"
-	tenantUuidEnvVarKey            = "KARDINAL_MANAGER_TENANT_UUID"
+	successExitCode                  = 0
+	clusterConfigEndpointEnvVarKey   = "KARDINAL_MANAGER_CLUSTER_CONFIG_ENDPOINT"
+	tenantUuidEnvVarKey              = "KARDINAL_MANAGER_TENANT_UUID"
+	forceApplyOwnershipEnvVarKey     = "KARDINAL_MANAGER_FORCE_APPLY_OWNERSHIP"
+	defaultForceApplyOwnership       = false
+	readinessTimeoutSecondsEnvVarKey = "KARDINAL_MANAGER_READINESS_TIMEOUT_SECONDS"
+	defaultReadinessTimeout          = time.Minute * 2
+	podNameEnvVarKey                 = "KARDINAL_MANAGER_POD_NAME"
+	podNamespaceEnvVarKey            = "KARDINAL_MANAGER_POD_NAMESPACE"
+	defaultPodNamespace              = "default"
+	corsAllowedOriginsEnvVarKey      = "KARDINAL_MANAGER_CORS_ALLOWED_ORIGINS"
+	corsAllowedOriginsSeparator      = ","
 )
 
 func main() {
 
-	// Create context
-	ctx := context.Background()
+	// Stopping on SIGTERM releases the leader lease, so a standby replica takes over right away
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
 
 	if err := logger.ConfigureLogger(); err != nil {
-		logrus.Fatal("An error occurred configuring the logger!\nError was: %s", err)
+		logrus.Fatalf("An error occurred configuring the logger!\nError was: %s", err)
 	}
 
 	configEndpoint, err := utils.GetFromEnvVar(clusterConfigEndpointEnvVarKey, "the config endpoint")
 	if err != nil {
-		logrus.Fatal("An error occurred getting the config endpoint from the env vars!\nError was: %s", err)
+		logrus.Fatalf("An error occurred getting the config endpoint from the env vars!\nError was: %s", err)
 	}
 
-	clusterManager, err := cluster_manager.CreateClusterManager()
+	tenantUuid, err := utils.GetFromEnvVar(tenantUuidEnvVarKey, "the tenant UUID")
 	if err != nil {
-		logrus.Fatal("An error occurred while creating the cluster manager!\nError was: %s", err)
+		logrus.Fatalf("An error occurred getting the tenant UUID from the env vars!\nError was: %s", err)
 	}
 
-	fetcher := fetcher.NewFetcher(clusterManager, configEndpoint)
+	forceApplyOwnership, err := utils.GetBoolFromEnvVar(forceApplyOwnershipEnvVarKey, "force apply ownership")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the force apply ownership value from the env var, using default value '%t'. Error:\n%s", defaultForceApplyOwnership, err)
+		forceApplyOwnership = defaultForceApplyOwnership
+	}
 
-	if err = fetcher.Run(ctx); err != nil {
-		logrus.Fatalf("An error occurred while running the fetcher!\nError was: %s", err)
+	readinessTimeout := defaultReadinessTimeout
+
+	// Zero disables the wait for the workloads to be ready before publishing the routes to them
+	readinessTimeoutSecondsEnvVarValue, err := utils.GetIntFromEnvVar(readinessTimeoutSecondsEnvVarKey, "readiness timeout seconds")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the readiness timeout seconds from the env var, using default value '%s'. Error:\n%s", defaultReadinessTimeout, err)
+	} else {
+		readinessTimeout = time.Second * time.Duration(int64(readinessTimeoutSecondsEnvVarValue))
+	}
+
+	clusterManager, err := cluster_manager.CreateClusterManager(tenantUuid, forceApplyOwnership, readinessTimeout)
+	if err != nil {
+		logrus.Fatalf("An error occurred while creating the cluster manager!\nError was: %s", err)
+	}
+
+	podName, err := utils.GetFromEnvVar(podNameEnvVarKey, "the pod name")
+	if err != nil {
+		podName, err = os.Hostname()
+		if err != nil {
+			logrus.Fatalf("An error occurred getting the hostname to identify this replica in the leader election!\nError was: %s", err)
+		}
+		logrus.Debugf("the pod name env var is not set, using the hostname '%s' to identify this replica in the leader election", podName)
+	}
+
+	podNamespace, err := utils.GetFromEnvVar(podNamespaceEnvVarKey, "the pod namespace")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the pod namespace from the env var, using default value '%s'. Error:\n%s", defaultPodNamespace, err)
+		podNamespace = defaultPodNamespace
 	}
 
-	// No external clients connection so-far
-	//if err := server.CreateAndStartRestAPIServer(); err != nil {
-	//	logrus.Fatalf("The REST API server is down, exiting!\nError was: %s", err)
-	//}
+	kubernetesClientSet, err := cluster_manager.CreateKubernetesClientSet()
+	if err != nil {
+		logrus.Fatalf("An error occurred while creating the Kubernetes client for the leader election!\nError was: %s", err)
+	}
+
+	// No origin is allowed by default, browsers can't call the REST API unless it's set to a comma separated list of origins
+	var corsAllowedOrigins []string
+	corsAllowedOriginsEnvVarValue, err := utils.GetFromEnvVar(corsAllowedOriginsEnvVarKey, "the CORS allowed origins")
+	if err != nil {
+		logrus.Debugf("an error occurred while getting the CORS allowed origins from the env var, no origin is allowed. Error:\n%s", err)
+	} else {
+		corsAllowedOrigins = strings.Split(corsAllowedOriginsEnvVarValue, corsAllowedOriginsSeparator)
+	}
+
+	readiness := health.NewReadiness()
+
+	fetcher := fetcher.NewFetcher(clusterManager, configEndpoint, readiness)
+
+	// The server is up on every replica, so the probes, the metrics and the plan work for the standbys too
+	go func() {
+		if err := server.CreateAndStartRestAPIServer(clusterManager, fetcher, readiness, corsAllowedOrigins); err != nil {
+			logrus.Fatalf("The REST API server is down, exiting!\nError was: %s", err)
+		}
+	}()
+
+	// Only the leader replica runs the fetcher, the others wait as standbys to take over if it goes away
+	leaderElector := leader_election.NewLeaderElector(kubernetesClientSet, podNamespace, podName)
+
+	// Run only returns an error once the consecutive failures budget is exhausted, exiting lets Kubernetes restart the pod
+	err = leaderElector.Run(ctx, func(leadingCtx context.Context) error {
+		readiness.SetLeader(true)
+		defer readiness.SetLeader(false)
+		return fetcher.Run(leadingCtx)
+	})
+	if err != nil {
+		logrus.Fatalf("An error occurred while running the fetcher!\nError was: %s", err)
+	}
 
 	os.Exit(successExitCode)
 }
@@ -0,0 +1,249 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/stacktrace"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The metrics are written in the Prometheus text exposition format, which is simple enough that it doesn't justify
+// pulling the Prometheus client library and its dependencies into the manager
+const (
+	kindLabelName   = "kind"
+	resultLabelName = "result"
+
+	SuccessfulSyncResult = "success"
+	FailedSyncResult     = "failure"
+
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+
+	bucketSuffix     = "_bucket"
+	sumSuffix        = "_sum"
+	countSuffix      = "_count"
+	bucketLabelName  = "le"
+	infiniteBucketLe = "+Inf"
+
+	contentTypeHeaderKey  = "Content-Type"
+	textFormatContentType = "text/plain; version=0.0.4; charset=utf-8"
+	floatFormat           = 'f'
+	floatFormatPrecision  = -1
+	floatFormatBitSize    = 64
+)
+
+var (
+	syncDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300}
+
+	SyncDurationSeconds = newHistogram("kardinal_manager_sync_duration_seconds", "Duration of the syncs of the cluster resources, from the apply to the clean-up.", syncDurationBuckets)
+	SyncsTotal          = newCounterVec("kardinal_manager_syncs_total", "Number of syncs of the cluster resources by result.", resultLabelName)
+	SyncErrorsTotal     = newCounterVec("kardinal_manager_sync_errors_total", "Number of objects which failed to be applied or deleted by resource kind.", kindLabelName)
+	ObjectsCreatedTotal = newCounterVec("kardinal_manager_objects_created_total", "Number of objects created by resource kind.", kindLabelName)
+	ObjectsUpdatedTotal = newCounterVec("kardinal_manager_objects_updated_total", "Number of objects updated by resource kind.", kindLabelName)
+	ObjectsDeletedTotal = newCounterVec("kardinal_manager_objects_deleted_total", "Number of objects deleted by resource kind.", kindLabelName)
+
+	LastSyncTimestampSeconds           = newGauge("kardinal_manager_last_sync_timestamp_seconds", "Unix time of the last sync of the cluster resources.")
+	LastSuccessfulSyncTimestampSeconds = newGauge("kardinal_manager_last_successful_sync_timestamp_seconds", "Unix time of the last sync of the cluster resources without errors.")
+
+	// registeredMetrics is in the order the metrics are written
+	registeredMetrics = []metric{
+		SyncDurationSeconds,
+		SyncsTotal,
+		SyncErrorsTotal,
+		ObjectsCreatedTotal,
+		ObjectsUpdatedTotal,
+		ObjectsDeletedTotal,
+		LastSyncTimestampSeconds,
+		LastSuccessfulSyncTimestampSeconds,
+	}
+
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+type metric interface {
+	write(writer io.Writer) error
+}
+
+// Handler serves all the metrics for Prometheus to scrape
+func Handler() http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, _ *http.Request) {
+		responseWriter.Header().Set(contentTypeHeaderKey, textFormatContentType)
+		if err := Write(responseWriter); err != nil {
+			http.Error(responseWriter, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func Write(writer io.Writer) error {
+	for _, registeredMetric := range registeredMetrics {
+		if err := registeredMetric.write(writer); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the metrics")
+		}
+	}
+	return nil
+}
+
+// CounterVec is a counter partitioned by the values of a single label
+type CounterVec struct {
+	mutex     sync.Mutex
+	name      string
+	help      string
+	labelName string
+	values    map[string]float64
+}
+
+func newCounterVec(name string, help string, labelName string) *CounterVec {
+	return &CounterVec{
+		mutex:     sync.Mutex{},
+		name:      name,
+		help:      help,
+		labelName: labelName,
+		values:    map[string]float64{},
+	}
+}
+
+func (counter *CounterVec) Inc(labelValue string) {
+	counter.Add(labelValue, 1)
+}
+
+func (counter *CounterVec) Add(labelValue string, value float64) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	counter.values[labelValue] += value
+}
+
+func (counter *CounterVec) write(writer io.Writer) error {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	if err := writeHeader(writer, counter.name, counter.help, counterType); err != nil {
+		return err
+	}
+
+	labelValues := make([]string, 0, len(counter.values))
+	for labelValue := range counter.values {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	for _, labelValue := range labelValues {
+		if err := writeSample(writer, counter.name, counter.labelName, labelValue, counter.values[labelValue]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Gauge struct {
+	mutex sync.Mutex
+	name  string
+	help  string
+	value float64
+}
+
+func newGauge(name string, help string) *Gauge {
+	return &Gauge{
+		mutex: sync.Mutex{},
+		name:  name,
+		help:  help,
+		value: 0,
+	}
+}
+
+func (gauge *Gauge) Set(value float64) {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+	gauge.value = value
+}
+
+func (gauge *Gauge) write(writer io.Writer) error {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+
+	if err := writeHeader(writer, gauge.name, gauge.help, gaugeType); err != nil {
+		return err
+	}
+	return writeSample(writer, gauge.name, "", "", gauge.value)
+}
+
+type Histogram struct {
+	mutex sync.Mutex
+	name  string
+	help  string
+	// bucketUpperBounds are sorted in increasing order, the +Inf bucket is implicit
+	bucketUpperBounds []float64
+	bucketCounts      []uint64
+	sum               float64
+	count             uint64
+}
+
+func newHistogram(name string, help string, bucketUpperBounds []float64) *Histogram {
+	return &Histogram{
+		mutex:             sync.Mutex{},
+		name:              name,
+		help:              help,
+		bucketUpperBounds: bucketUpperBounds,
+		bucketCounts:      make([]uint64, len(bucketUpperBounds)),
+		sum:               0,
+		count:             0,
+	}
+}
+
+func (histogram *Histogram) Observe(value float64) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	for index, upperBound := range histogram.bucketUpperBounds {
+		if value <= upperBound {
+			histogram.bucketCounts[index]++
+		}
+	}
+	histogram.sum += value
+	histogram.count++
+}
+
+// write outputs the cumulative bucket counts, as Prometheus expects them
+func (histogram *Histogram) write(writer io.Writer) error {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	if err := writeHeader(writer, histogram.name, histogram.help, histogramType); err != nil {
+		return err
+	}
+	for index, upperBound := range histogram.bucketUpperBounds {
+		if err := writeSample(writer, histogram.name+bucketSuffix, bucketLabelName, formatFloat(upperBound), float64(histogram.bucketCounts[index])); err != nil {
+			return err
+		}
+	}
+	if err := writeSample(writer, histogram.name+bucketSuffix, bucketLabelName, infiniteBucketLe, float64(histogram.count)); err != nil {
+		return err
+	}
+	if err := writeSample(writer, histogram.name+sumSuffix, "", "", histogram.sum); err != nil {
+		return err
+	}
+	return writeSample(writer, histogram.name+countSuffix, "", "", float64(histogram.count))
+}
+
+func writeHeader(writer io.Writer, name string, help string, metricType string) error {
+	_, err := fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+	return err
+}
+
+// writeSample writes the sample without labels when the label name is empty
+func writeSample(writer io.Writer, name string, labelName string, labelValue string, value float64) error {
+	if labelName == "" {
+		_, err := fmt.Fprintf(writer, "%s %s\n", name, formatFloat(value))
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "%s{%s=\"%s\"} %s\n", name, labelName, labelValueEscaper.Replace(labelValue), formatFloat(value))
+	return err
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, floatFormat, floatFormatPrecision, floatFormatBitSize)
+}
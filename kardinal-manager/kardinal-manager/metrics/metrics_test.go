@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCounterVec_WritesTheLabelValuesSorted(t *testing.T) {
+	counter := newCounterVec("kardinal_manager_objects_created_total", "Number of objects created by resource kind.", kindLabelName)
+	counter.Inc("VirtualService")
+	counter.Inc("Deployment")
+	counter.Add("VirtualService", 2)
+	counter.Inc(`Weird"Kind`)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, counter.write(buffer))
+	require.Equal(t, `# HELP kardinal_manager_objects_created_total Number of objects created by resource kind.
+# TYPE kardinal_manager_objects_created_total counter
+kardinal_manager_objects_created_total{kind="Deployment"} 1
+kardinal_manager_objects_created_total{kind="VirtualService"} 3
+kardinal_manager_objects_created_total{kind="Weird\"Kind"} 1
+`, buffer.String())
+}
+
+func TestHistogram_WritesCumulativeBuckets(t *testing.T) {
+	histogram := newHistogram("kardinal_manager_sync_duration_seconds", "Duration of the syncs.", []float64{0.5, 1, 5})
+	histogram.Observe(0.25)
+	histogram.Observe(0.75)
+	histogram.Observe(10)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, histogram.write(buffer))
+	require.Equal(t, `# HELP kardinal_manager_sync_duration_seconds Duration of the syncs.
+# TYPE kardinal_manager_sync_duration_seconds histogram
+kardinal_manager_sync_duration_seconds_bucket{le="0.5"} 1
+kardinal_manager_sync_duration_seconds_bucket{le="1"} 2
+kardinal_manager_sync_duration_seconds_bucket{le="5"} 2
+kardinal_manager_sync_duration_seconds_bucket{le="+Inf"} 3
+kardinal_manager_sync_duration_seconds_sum 11
+kardinal_manager_sync_duration_seconds_count 3
+`, buffer.String())
+}
+
+func TestGauge_WritesTheLastValue(t *testing.T) {
+	gauge := newGauge("kardinal_manager_last_sync_timestamp_seconds", "Unix time of the last sync.")
+	gauge.Set(1718000000)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, gauge.write(buffer))
+	require.Equal(t, `# HELP kardinal_manager_last_sync_timestamp_seconds Unix time of the last sync.
+# TYPE kardinal_manager_last_sync_timestamp_seconds gauge
+kardinal_manager_last_sync_timestamp_seconds 1718000000
+`, buffer.String())
+}
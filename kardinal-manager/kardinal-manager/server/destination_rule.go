@@ -0,0 +1,72 @@
+package server
+
+import (
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+)
+
+// The REST types only cover the part of the Istio DestinationRule used by Kardinal flows: the host and the subsets
+// selecting the versions of a service by their labels
+
+func newRestDestinationRule(destinationRule *v1alpha3.DestinationRule) rest_types.DestinationRule {
+	subsets := lo.Map(destinationRule.Spec.GetSubsets(), func(subset *istio.Subset, _ int) rest_types.Subset {
+		return newRestSubset(subset)
+	})
+
+	return rest_types.DestinationRule{
+		Host:      destinationRule.Spec.GetHost(),
+		Name:      destinationRule.Name,
+		Namespace: lo.ToPtr(destinationRule.Namespace),
+		Subsets:   &subsets,
+	}
+}
+
+func newRestSubset(subset *istio.Subset) rest_types.Subset {
+	return rest_types.Subset{
+		Labels: lo.Ternary(len(subset.GetLabels()) > 0, lo.ToPtr(subset.GetLabels()), nil),
+		Name:   subset.GetName(),
+	}
+}
+
+func newIstioDestinationRule(restDestinationRule rest_types.DestinationRule, namespace string) (*v1alpha3.DestinationRule, error) {
+	if restDestinationRule.Name == "" {
+		return nil, stacktrace.NewError("The destination rule needs a name")
+	}
+	if restDestinationRule.Host == "" {
+		return nil, stacktrace.NewError("The destination rule needs a host")
+	}
+
+	var subsets []*istio.Subset
+	for _, restSubset := range lo.FromPtr(restDestinationRule.Subsets) {
+		subset, err := newIstioSubset(restSubset)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid subset of destination rule '%s'", restDestinationRule.Name)
+		}
+		subsets = append(subsets, subset)
+	}
+
+	return &v1alpha3.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restDestinationRule.Name,
+			Namespace: namespace,
+		},
+		Spec: istio.DestinationRule{
+			Host:    restDestinationRule.Host,
+			Subsets: subsets,
+		},
+	}, nil
+}
+
+func newIstioSubset(restSubset rest_types.Subset) (*istio.Subset, error) {
+	if restSubset.Name == "" {
+		return nil, stacktrace.NewError("The subset needs a name")
+	}
+	return &istio.Subset{
+		Name:   restSubset.Name,
+		Labels: lo.FromPtr(restSubset.Labels),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+	"testing"
+)
+
+func TestNewIstioDestinationRule_RoundTrips(t *testing.T) {
+	restDestinationRule := rest_types.DestinationRule{
+		Host: "reviews",
+		Name: "reviews",
+		Subsets: &[]rest_types.Subset{
+			{Name: "v1", Labels: &map[string]string{"version": "v1"}},
+			{Name: "dev", Labels: &map[string]string{"version": "dev"}},
+		},
+	}
+
+	destinationRule, err := newIstioDestinationRule(restDestinationRule, "prod")
+	require.NoError(t, err)
+	require.Equal(t, "prod", destinationRule.Namespace)
+
+	restDestinationRule.Namespace = lo.ToPtr("prod")
+	require.Equal(t, restDestinationRule, newRestDestinationRule(destinationRule))
+}
+
+func TestNewIstioDestinationRule_RejectsInvalidDestinationRules(t *testing.T) {
+	_, err := newIstioDestinationRule(rest_types.DestinationRule{Host: "reviews"}, "prod")
+	require.Error(t, err)
+
+	_, err = newIstioDestinationRule(rest_types.DestinationRule{Name: "reviews"}, "prod")
+	require.Error(t, err)
+
+	_, err = newIstioDestinationRule(rest_types.DestinationRule{Name: "reviews", Host: "reviews", Subsets: &[]rest_types.Subset{{Name: ""}}}, "prod")
+	require.Error(t, err)
+}
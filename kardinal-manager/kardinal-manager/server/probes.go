@@ -0,0 +1,34 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"kardinal.kontrol/kardinal-manager/health"
+	"kardinal.kontrol/kardinal-manager/metrics"
+	"net/http"
+)
+
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+	metricsPath = "/metrics"
+
+	okResponseBody       = "ok"
+	notReadyResponseBody = "the cluster resources weren't synced yet"
+)
+
+// registerProbeHandlers serves the Kubernetes probes and the Prometheus metrics outside the API group, so they are
+// not logged on every request
+func registerProbeHandlers(echoRouter *echo.Echo, readiness *health.Readiness) {
+	echoRouter.GET(healthzPath, func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, okResponseBody)
+	})
+
+	echoRouter.GET(readyzPath, func(ctx echo.Context) error {
+		if !readiness.IsReady() {
+			return ctx.String(http.StatusServiceUnavailable, notReadyResponseBody)
+		}
+		return ctx.String(http.StatusOK, okResponseBody)
+	})
+
+	echoRouter.GET(metricsPath, echo.WrapHandler(metrics.Handler()))
+}
@@ -2,47 +2,363 @@ package server
 
 import (
 	"context"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	rest_api "kardinal.kontrol/kardinal-manager/api/http_rest/server"
 	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"net/http"
 )
 
-type Server struct{}
+// ClusterResourcesPlanner plans the changes for the cluster resources currently published by Kontrol
+type ClusterResourcesPlanner interface {
+	PlanClusterResources(ctx context.Context) (*cluster_manager.Plan, error)
+}
+
+type Server struct {
+	clusterManager *cluster_manager.ClusterManager
+	planner        ClusterResourcesPlanner
+}
 
-func NewServer() Server {
-	return Server{}
+func NewServer(clusterManager *cluster_manager.ClusterManager, planner ClusterResourcesPlanner) Server {
+	return Server{
+		clusterManager: clusterManager,
+		planner:        planner,
+	}
+}
+
+// List destination rules
+// (GET /destination-rules)
+func (server Server) GetDestinationRules(ctx context.Context, request rest_api.GetDestinationRulesRequestObject) (rest_api.GetDestinationRulesResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	destinationRules, err := server.clusterManager.GetDestinationRules(ctx, namespace)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred listing the destination rules in namespace '%s'", namespace)
+		return rest_api.GetDestinationRulesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	response := map[string]rest_types.DestinationRule{}
+	for _, destinationRule := range destinationRules {
+		response[destinationRule.Name] = newRestDestinationRule(destinationRule)
+	}
+
+	return rest_api.GetDestinationRules200JSONResponse(response), nil
+}
+
+// Create destination rule
+// (POST /destination-rules)
+func (server Server) PostDestinationRules(ctx context.Context, request rest_api.PostDestinationRulesRequestObject) (rest_api.PostDestinationRulesResponseObject, error) {
+	if request.Body == nil {
+		return rest_api.PostDestinationRulesdefaultJSONResponse{Body: newMissingBodyResponseInfo(), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	namespace := getNamespaceOrDefault(request.Body.Namespace)
+
+	destinationRule, err := newIstioDestinationRule(*request.Body, namespace)
+	if err != nil {
+		return rest_api.PostDestinationRulesdefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	if err = server.clusterManager.CreateDestinationRule(ctx, destinationRule); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred creating destination rule '%s' in namespace '%s'", destinationRule.Name, namespace)
+		return rest_api.PostDestinationRulesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.PostDestinationRules200JSONResponse(newRestDestinationRule(destinationRule)), nil
+}
+
+// Add or replace a subset of an existing destination rule
+// (POST /destination-rules/{name}/subsets)
+func (server Server) PostDestinationRulesNameSubsets(ctx context.Context, request rest_api.PostDestinationRulesNameSubsetsRequestObject) (rest_api.PostDestinationRulesNameSubsetsResponseObject, error) {
+	if request.Body == nil {
+		return rest_api.PostDestinationRulesNameSubsetsdefaultJSONResponse{Body: newMissingBodyResponseInfo(), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+	name := request.Name
+
+	subset, err := newIstioSubset(*request.Body)
+	if err != nil {
+		return rest_api.PostDestinationRulesNameSubsetsdefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	if err = server.clusterManager.AddSubset(ctx, namespace, name, subset); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred adding subset '%s' to destination rule '%s' in namespace '%s'", subset.Name, name, namespace)
+		return rest_api.PostDestinationRulesNameSubsetsdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	destinationRule, err := server.clusterManager.GetDestinationRule(ctx, namespace, name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting destination rule '%s' in namespace '%s'", name, namespace)
+		return rest_api.PostDestinationRulesNameSubsetsdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.PostDestinationRulesNameSubsets200JSONResponse(newRestDestinationRule(destinationRule)), nil
+}
+
+// Remove a subset of an existing destination rule
+// (DELETE /destination-rules/{name}/subsets/{subset})
+func (server Server) DeleteDestinationRulesNameSubsetsSubset(ctx context.Context, request rest_api.DeleteDestinationRulesNameSubsetsSubsetRequestObject) (rest_api.DeleteDestinationRulesNameSubsetsSubsetResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+	name := request.Name
+
+	if err := server.clusterManager.RemoveSubset(ctx, namespace, name, request.Subset); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred removing subset '%s' from destination rule '%s' in namespace '%s'", request.Subset, name, namespace)
+		return rest_api.DeleteDestinationRulesNameSubsetsSubsetdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	destinationRule, err := server.clusterManager.GetDestinationRule(ctx, namespace, name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting destination rule '%s' in namespace '%s'", name, namespace)
+		return rest_api.DeleteDestinationRulesNameSubsetsSubsetdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.DeleteDestinationRulesNameSubsetsSubset200JSONResponse(newRestDestinationRule(destinationRule)), nil
+}
+
+// Plan the cluster resources changes
+// (GET /plan)
+func (server Server) GetPlan(ctx context.Context, _ rest_api.GetPlanRequestObject) (rest_api.GetPlanResponseObject, error) {
+	plan, err := server.planner.PlanClusterResources(ctx)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred planning the cluster resources changes")
+		return rest_api.GetPlandefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.GetPlan200TextResponse(plan.String()), nil
+}
+
+// Get the topology of a namespace
+// (GET /topology/{namespace})
+func (server Server) GetTopologyNamespace(_ context.Context, request rest_api.GetTopologyNamespaceRequestObject) (rest_api.GetTopologyNamespaceResponseObject, error) {
+	namespace := request.Namespace
+
+	topologyNodes, err := server.clusterManager.GetTopologyForNameSpace(namespace)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting the topology of namespace '%s'", namespace)
+		return rest_api.GetTopologyNamespacedefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.GetTopologyNamespace200JSONResponse(newRestTopology(topologyNodes)), nil
 }
 
 // List virtual services
 // (GET /virtual-services)
-func (Server) GetVirtualServices(ctx context.Context, object rest_api.GetVirtualServicesRequestObject) (rest_api.GetVirtualServicesResponseObject, error) {
-	response := map[string]rest_types.VirtualService{
-		"fake-virtual-service-01": {
-			Name: "fake-virtual-service-01",
-		},
-		"fake-virtual-service-02": {
-			Name: "fake-virtual-service-02",
-		},
+func (server Server) GetVirtualServices(ctx context.Context, request rest_api.GetVirtualServicesRequestObject) (rest_api.GetVirtualServicesResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	virtualServices, err := server.clusterManager.GetVirtualServices(ctx, namespace)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred listing the virtual services in namespace '%s'", namespace)
+		return rest_api.GetVirtualServicesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	response := map[string]rest_types.VirtualService{}
+	for _, virtualService := range virtualServices {
+		response[virtualService.Name] = newRestVirtualService(virtualService)
 	}
 
 	return rest_api.GetVirtualServices200JSONResponse(response), nil
 }
 
-// Create virtual service
+// Add the HTTP routes to an existing virtual service
 // (POST /virtual-services)
-func (Server) PostVirtualServices(ctx context.Context, object rest_api.PostVirtualServicesRequestObject) (rest_api.PostVirtualServicesResponseObject, error) {
-	response := rest_types.VirtualService{
-		Name: "fake-virtual-service-01",
+func (server Server) PostVirtualServices(ctx context.Context, request rest_api.PostVirtualServicesRequestObject) (rest_api.PostVirtualServicesResponseObject, error) {
+	if request.Body == nil {
+		return rest_api.PostVirtualServicesdefaultJSONResponse{Body: newMissingBodyResponseInfo(), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	namespace := getNamespaceOrDefault(request.Body.Namespace)
+	name := request.Body.Name
+
+	routingRules, err := newIstioHTTPRoutes(lo.FromPtr(request.Body.Http))
+	if err != nil {
+		return rest_api.PostVirtualServicesdefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	if _, found := lo.Find(routingRules, func(routingRule *istio.HTTPRoute) bool { return routingRule.GetName() == "" }); found {
+		return rest_api.PostVirtualServicesdefaultJSONResponse{Body: newBadRequestResponseInfo(stacktrace.NewError("The HTTP routes need a name")), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	// The routing rules are added before the ones with the same priority, so they are added backwards to keep the order
+	// they were sent in
+	for index := len(routingRules) - 1; index >= 0; index-- {
+		if err = server.clusterManager.AddRoutingRule(ctx, namespace, name, routingRules[index], defaultRoutingRulePriority); err != nil {
+			statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred adding the routing rules to virtual service '%s' in namespace '%s'", name, namespace)
+			return rest_api.PostVirtualServicesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+		}
 	}
 
-	return rest_api.PostVirtualServices200JSONResponse(response), nil
+	virtualService, err := server.clusterManager.GetVirtualService(ctx, namespace, name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting virtual service '%s' in namespace '%s'", name, namespace)
+		return rest_api.PostVirtualServicesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.PostVirtualServices200JSONResponse(newRestVirtualService(virtualService)), nil
 }
 
 // Delete virtual service
 // (DELETE /virtual-services)
-func (Server) DeleteVirtualServices(ctx context.Context, object rest_api.DeleteVirtualServicesRequestObject) (rest_api.DeleteVirtualServicesResponseObject, error) {
-	response := rest_types.VirtualService{
-		Name: "fake-virtual-service-01",
+func (server Server) DeleteVirtualServices(ctx context.Context, request rest_api.DeleteVirtualServicesRequestObject) (rest_api.DeleteVirtualServicesResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+	name := request.Params.Name
+
+	virtualService, err := server.clusterManager.GetVirtualService(ctx, namespace, name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting virtual service '%s' in namespace '%s'", name, namespace)
+		return rest_api.DeleteVirtualServicesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	if err = server.clusterManager.DeleteVirtualService(ctx, namespace, name); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred deleting virtual service '%s' in namespace '%s'", name, namespace)
+		return rest_api.DeleteVirtualServicesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.DeleteVirtualServices200JSONResponse(newRestVirtualService(virtualService)), nil
+}
+
+// List the routing rules of a virtual service
+// (GET /virtual-services/{name}/routing-rules)
+func (server Server) GetVirtualServicesNameRoutingRules(ctx context.Context, request rest_api.GetVirtualServicesNameRoutingRulesRequestObject) (rest_api.GetVirtualServicesNameRoutingRulesResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	routingRules, err := server.clusterManager.GetRoutingRules(ctx, namespace, request.Name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting the routing rules of virtual service '%s' in namespace '%s'", request.Name, namespace)
+		return rest_api.GetVirtualServicesNameRoutingRulesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.GetVirtualServicesNameRoutingRules200JSONResponse(newRestRoutingRules(routingRules)), nil
+}
+
+// Add a routing rule to a virtual service
+// (POST /virtual-services/{name}/routing-rules)
+func (server Server) PostVirtualServicesNameRoutingRules(ctx context.Context, request rest_api.PostVirtualServicesNameRoutingRulesRequestObject) (rest_api.PostVirtualServicesNameRoutingRulesResponseObject, error) {
+	if request.Body == nil {
+		return rest_api.PostVirtualServicesNameRoutingRulesdefaultJSONResponse{Body: newMissingBodyResponseInfo(), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	routingRule, err := newIstioRoutingRule(*request.Body)
+	if err != nil {
+		return rest_api.PostVirtualServicesNameRoutingRulesdefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	if err = server.clusterManager.AddRoutingRule(ctx, namespace, request.Name, routingRule, getRoutingRulePriority(*request.Body)); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred adding routing rule '%s' to virtual service '%s' in namespace '%s'", routingRule.GetName(), request.Name, namespace)
+		return rest_api.PostVirtualServicesNameRoutingRulesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	routingRules, err := server.clusterManager.GetRoutingRules(ctx, namespace, request.Name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting the routing rules of virtual service '%s' in namespace '%s'", request.Name, namespace)
+		return rest_api.PostVirtualServicesNameRoutingRulesdefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.PostVirtualServicesNameRoutingRules200JSONResponse(newRestRoutingRules(routingRules)), nil
+}
+
+// Replace a routing rule of a virtual service
+// (PUT /virtual-services/{name}/routing-rules/{rule})
+func (server Server) PutVirtualServicesNameRoutingRulesRule(ctx context.Context, request rest_api.PutVirtualServicesNameRoutingRulesRuleRequestObject) (rest_api.PutVirtualServicesNameRoutingRulesRuleResponseObject, error) {
+	if request.Body == nil {
+		return rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: newMissingBodyResponseInfo(), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	// The name of the rule in the path is used when the body doesn't set one
+	restRoutingRule := *request.Body
+	if lo.FromPtr(restRoutingRule.Route.Name) == "" {
+		restRoutingRule.Route.Name = &request.Rule
+	}
+	if *restRoutingRule.Route.Name != request.Rule {
+		err := stacktrace.NewError("The name of the routing rule '%s' doesn't match the one in the path '%s'", *restRoutingRule.Route.Name, request.Rule)
+		return rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	routingRule, err := newIstioRoutingRule(restRoutingRule)
+	if err != nil {
+		return rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: newBadRequestResponseInfo(err), StatusCode: http.StatusBadRequest}, nil
+	}
+
+	if err = server.clusterManager.ReplaceRoutingRule(ctx, namespace, request.Name, routingRule, getRoutingRulePriority(restRoutingRule)); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred replacing routing rule '%s' of virtual service '%s' in namespace '%s'", request.Rule, request.Name, namespace)
+		return rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	routingRules, err := server.clusterManager.GetRoutingRules(ctx, namespace, request.Name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting the routing rules of virtual service '%s' in namespace '%s'", request.Name, namespace)
+		return rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	return rest_api.PutVirtualServicesNameRoutingRulesRule200JSONResponse(newRestRoutingRules(routingRules)), nil
+}
+
+// Remove a routing rule from a virtual service
+// (DELETE /virtual-services/{name}/routing-rules/{rule})
+func (server Server) DeleteVirtualServicesNameRoutingRulesRule(ctx context.Context, request rest_api.DeleteVirtualServicesNameRoutingRulesRuleRequestObject) (rest_api.DeleteVirtualServicesNameRoutingRulesRuleResponseObject, error) {
+	namespace := getNamespaceOrDefault(request.Params.Namespace)
+
+	if err := server.clusterManager.RemoveRoutingRule(ctx, namespace, request.Name, request.Rule); err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred removing routing rule '%s' from virtual service '%s' in namespace '%s'", request.Rule, request.Name, namespace)
+		return rest_api.DeleteVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
+	}
+
+	routingRules, err := server.clusterManager.GetRoutingRules(ctx, namespace, request.Name)
+	if err != nil {
+		statusCode, responseInfo := newErrorResponseInfo(err, "An error occurred getting the routing rules of virtual service '%s' in namespace '%s'", request.Name, namespace)
+		return rest_api.DeleteVirtualServicesNameRoutingRulesRuledefaultJSONResponse{Body: responseInfo, StatusCode: statusCode}, nil
 	}
 
-	return rest_api.DeleteVirtualServices200JSONResponse(response), nil
+	return rest_api.DeleteVirtualServicesNameRoutingRulesRule200JSONResponse(newRestRoutingRules(routingRules)), nil
+}
+
+func getNamespaceOrDefault(namespace *string) string {
+	if namespace == nil || *namespace == "" {
+		return corev1.NamespaceDefault
+	}
+	return *namespace
+}
+
+// newErrorResponseInfo maps the objects not found in the cluster to a 404, the ones already there to a 409, and anything
+// else to a 500
+func newErrorResponseInfo(err error, msg string, args ...interface{}) (int, rest_types.ResponseInfo) {
+	err = stacktrace.Propagate(err, msg, args...)
+	logrus.Errorf("%s", err)
+
+	statusCode := http.StatusInternalServerError
+	switch rootCause := stacktrace.RootCause(err); {
+	case apierrors.IsNotFound(rootCause):
+		statusCode = http.StatusNotFound
+	case apierrors.IsAlreadyExists(rootCause):
+		statusCode = http.StatusConflict
+	}
+
+	return statusCode, rest_types.ResponseInfo{
+		Code:    uint32(statusCode),
+		Message: stacktrace.RootCause(err).Error(),
+		Type:    rest_types.ERROR,
+	}
+}
+
+// newMissingBodyResponseInfo answers the requests which need a body but were sent without one
+func newMissingBodyResponseInfo() rest_types.ResponseInfo {
+	return newBadRequestResponseInfo(stacktrace.NewError("The request body is missing"))
+}
+
+func newBadRequestResponseInfo(err error) rest_types.ResponseInfo {
+	return rest_types.ResponseInfo{
+		Code:    http.StatusBadRequest,
+		Message: stacktrace.RootCause(err).Error(),
+		Type:    rest_types.ERROR,
+	}
 }
@@ -2,41 +2,76 @@ package server
 
 import (
 	"fmt"
+	"github.com/kurtosis-tech/stacktrace"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
 	kardinal_manager_server_rest_server "kardinal.kontrol/kardinal-manager/api/http_rest/server"
+	"kardinal.kontrol/kardinal-manager/cluster_manager"
+	"kardinal.kontrol/kardinal-manager/health"
 	"net"
 )
 
 const (
-	pathToApiGroup         = "/api"
-	restAPIPortAddr uint16 = 8080
-	restAPIHostIP   string = "0.0.0.0"
+	pathToApiGroup = "/api"
+
+	// The kubelet and Prometheus reach the probes and the metrics through the pod IP
+	probesPortAddr uint16 = 8080
+	probesHostIP   string = "0.0.0.0"
+
+	// The API mutates the routing in the cluster and has no authentication, so it's only reachable from inside the pod,
+	// e.g. through `kubectl port-forward`
+	restAPIPortAddr uint16 = 8081
+	restAPIHostIP   string = "127.0.0.1"
 )
 
 var (
-	defaultCORSOrigins = []string{"*"}
 	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
 )
 
-func CreateAndStartRestAPIServer() error {
+// CreateAndStartRestAPIServer serves the probes and the metrics on every interface and the REST API on localhost only,
+// it returns as soon as one of them is down. Browsers can only call the API from the CORS allowed origins, none by default
+func CreateAndStartRestAPIServer(clusterManager *cluster_manager.ClusterManager, planner ClusterResourcesPlanner, readiness *health.Readiness, corsAllowedOrigins []string) error {
 	logrus.Info("Running REST API server...")
 
+	probesRouter := echo.New()
+	registerProbeHandlers(probesRouter, readiness)
+
+	apiRouter := newRestAPIRouter(clusterManager, planner, corsAllowedOrigins)
+
+	errChan := make(chan error, 2)
+	go func() {
+		err := probesRouter.Start(net.JoinHostPort(probesHostIP, fmt.Sprint(probesPortAddr)))
+		errChan <- stacktrace.Propagate(err, "The probes and metrics server is down")
+	}()
+	go func() {
+		err := apiRouter.Start(net.JoinHostPort(restAPIHostIP, fmt.Sprint(restAPIPortAddr)))
+		errChan <- stacktrace.Propagate(err, "The REST API server is down")
+	}()
+
+	return <-errChan
+}
+
+func newRestAPIRouter(clusterManager *cluster_manager.ClusterManager, planner ClusterResourcesPlanner, corsAllowedOrigins []string) *echo.Echo {
 	// This is how you set up a basic Echo router
 	echoRouter := echo.New()
+
 	echoApiRouter := echoRouter.Group(pathToApiGroup)
 	echoApiRouter.Use(middleware.Logger())
+	// A panicking handler answers with a 500 instead of taking the whole manager down
+	echoApiRouter.Use(middleware.Recover())
 
-	// CORS configuration
-	echoApiRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: defaultCORSOrigins,
-		AllowHeaders: defaultCORSHeaders,
-	}))
+	// Echo allows every origin when none is configured, so the CORS middleware is only added for the allowed origins
+	if len(corsAllowedOrigins) > 0 {
+		echoApiRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins: corsAllowedOrigins,
+			AllowHeaders: defaultCORSHeaders,
+		}))
+	}
 
-	server := NewServer()
+	server := NewServer(clusterManager, planner)
 
 	kardinal_manager_server_rest_server.RegisterHandlers(echoApiRouter, kardinal_manager_server_rest_server.NewStrictHandler(server, nil))
 
-	return echoRouter.Start(net.JoinHostPort(restAPIHostIP, fmt.Sprint(restAPIPortAddr)))
+	return echoRouter
 }
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRestAPIRouter_OnlyAllowsTheConfiguredCORSOrigins(t *testing.T) {
+	preflight := func(router *echo.Echo, origin string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodOptions, pathToApiGroup+"/virtual-services", nil)
+		request.Header.Set(echo.HeaderOrigin, origin)
+		request.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// No origin is allowed by default
+	recorder := preflight(newRestAPIRouter(nil, nil, nil), "https://evil.example.com")
+	require.Empty(t, recorder.Header().Get(echo.HeaderAccessControlAllowOrigin))
+
+	router := newRestAPIRouter(nil, nil, []string{"https://app.kardinal.dev"})
+
+	recorder = preflight(router, "https://app.kardinal.dev")
+	require.Equal(t, "https://app.kardinal.dev", recorder.Header().Get(echo.HeaderAccessControlAllowOrigin))
+
+	recorder = preflight(router, "https://evil.example.com")
+	require.Empty(t, recorder.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
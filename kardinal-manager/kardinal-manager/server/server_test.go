@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	rest_api "kardinal.kontrol/kardinal-manager/api/http_rest/server"
+	"net/http"
+	"testing"
+)
+
+func TestNewErrorResponseInfo_MapsTheKubernetesErrors(t *testing.T) {
+	resource := schema.GroupResource{Group: "networking.istio.io", Resource: "destinationrules"}
+
+	statusCode, responseInfo := newErrorResponseInfo(stacktrace.Propagate(apierrors.NewNotFound(resource, "reviews"), "wrapped"), "An error occurred")
+	require.Equal(t, http.StatusNotFound, statusCode)
+	require.Equal(t, uint32(http.StatusNotFound), responseInfo.Code)
+
+	statusCode, _ = newErrorResponseInfo(apierrors.NewAlreadyExists(resource, "reviews"), "An error occurred")
+	require.Equal(t, http.StatusConflict, statusCode)
+
+	statusCode, responseInfo = newErrorResponseInfo(errors.New("connection refused"), "An error occurred")
+	require.Equal(t, http.StatusInternalServerError, statusCode)
+	require.Equal(t, "connection refused", responseInfo.Message)
+}
+
+func TestPostHandlers_AnswerABadRequestWhenTheBodyIsMissing(t *testing.T) {
+	server := NewServer(nil, nil)
+
+	response, err := server.PostVirtualServices(context.Background(), rest_api.PostVirtualServicesRequestObject{Body: nil})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, response.(rest_api.PostVirtualServicesdefaultJSONResponse).StatusCode)
+
+	destinationRuleResponse, err := server.PostDestinationRules(context.Background(), rest_api.PostDestinationRulesRequestObject{Body: nil})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, destinationRuleResponse.(rest_api.PostDestinationRulesdefaultJSONResponse).StatusCode)
+
+	subsetResponse, err := server.PostDestinationRulesNameSubsets(context.Background(), rest_api.PostDestinationRulesNameSubsetsRequestObject{Name: "reviews", Body: nil})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, subsetResponse.(rest_api.PostDestinationRulesNameSubsetsdefaultJSONResponse).StatusCode)
+
+	routingRuleResponse, err := server.PostVirtualServicesNameRoutingRules(context.Background(), rest_api.PostVirtualServicesNameRoutingRulesRequestObject{Name: "reviews", Body: nil})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, routingRuleResponse.(rest_api.PostVirtualServicesNameRoutingRulesdefaultJSONResponse).StatusCode)
+
+	updatedRoutingRuleResponse, err := server.PutVirtualServicesNameRoutingRulesRule(context.Background(), rest_api.PutVirtualServicesNameRoutingRulesRuleRequestObject{Name: "reviews", Rule: "dev", Body: nil})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, updatedRoutingRuleResponse.(rest_api.PutVirtualServicesNameRoutingRulesRuledefaultJSONResponse).StatusCode)
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+	"kardinal.kontrol/kardinal-manager/topology"
+)
+
+func newRestTopology(nodes map[string]*topology.Node) map[string]rest_types.TopologyNode {
+	restNodes := map[string]rest_types.TopologyNode{}
+	for nodeID, node := range nodes {
+		talksTo := node.TalksTo
+		if talksTo == nil {
+			talksTo = []string{}
+		}
+		restNodes[nodeID] = rest_types.TopologyNode{
+			Id:             node.ID,
+			ServiceName:    node.ServiceName,
+			ServiceVersion: node.ServiceVersion,
+			TalksTo:        talksTo,
+		}
+	}
+	return restNodes
+}
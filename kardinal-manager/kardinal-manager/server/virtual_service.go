@@ -0,0 +1,176 @@
+package server
+
+import (
+	"github.com/kurtosis-tech/stacktrace"
+	"github.com/samber/lo"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+	"kardinal.kontrol/kardinal-manager/cluster_manager"
+)
+
+const defaultRoutingRulePriority int32 = 0
+
+// The REST types only cover the part of the Istio VirtualService used by Kardinal flows: the hosts and the HTTP routes
+// with their URI and header matches and their weighted destinations
+
+func newRestVirtualService(virtualService *v1alpha3.VirtualService) rest_types.VirtualService {
+	httpRoutes := lo.Map(virtualService.Spec.GetHttp(), func(httpRoute *istio.HTTPRoute, _ int) rest_types.HTTPRoute {
+		return newRestHTTPRoute(httpRoute)
+	})
+
+	return rest_types.VirtualService{
+		Hosts:     lo.ToPtr(virtualService.Spec.GetHosts()),
+		Http:      &httpRoutes,
+		Name:      virtualService.Name,
+		Namespace: lo.ToPtr(virtualService.Namespace),
+	}
+}
+
+func newRestHTTPRoute(httpRoute *istio.HTTPRoute) rest_types.HTTPRoute {
+	matches := lo.Map(httpRoute.GetMatch(), func(match *istio.HTTPMatchRequest, _ int) rest_types.HTTPMatchRequest {
+		restMatch := rest_types.HTTPMatchRequest{
+			Headers: nil,
+			Uri:     newRestStringMatch(match.GetUri()),
+		}
+		if len(match.GetHeaders()) > 0 {
+			headers := lo.MapValues(match.GetHeaders(), func(headerMatch *istio.StringMatch, _ string) rest_types.StringMatch {
+				return lo.FromPtr(newRestStringMatch(headerMatch))
+			})
+			restMatch.Headers = &headers
+		}
+		return restMatch
+	})
+
+	destinations := lo.Map(httpRoute.GetRoute(), func(destination *istio.HTTPRouteDestination, _ int) rest_types.HTTPRouteDestination {
+		return rest_types.HTTPRouteDestination{
+			Host:   destination.GetDestination().GetHost(),
+			Subset: lo.EmptyableToPtr(destination.GetDestination().GetSubset()),
+			Weight: lo.EmptyableToPtr(destination.GetWeight()),
+		}
+	})
+
+	return rest_types.HTTPRoute{
+		Match: lo.Ternary(len(matches) > 0, &matches, nil),
+		Name:  lo.EmptyableToPtr(httpRoute.GetName()),
+		Route: destinations,
+	}
+}
+
+func newRestRoutingRules(routingRules []*cluster_manager.RoutingRule) []rest_types.RoutingRule {
+	return lo.Map(routingRules, func(routingRule *cluster_manager.RoutingRule, _ int) rest_types.RoutingRule {
+		return rest_types.RoutingRule{
+			Priority: lo.ToPtr(routingRule.Priority),
+			Route:    newRestHTTPRoute(routingRule.HTTPRoute),
+		}
+	})
+}
+
+func newRestStringMatch(stringMatch *istio.StringMatch) *rest_types.StringMatch {
+	if stringMatch == nil {
+		return nil
+	}
+	return &rest_types.StringMatch{
+		Exact:  lo.EmptyableToPtr(stringMatch.GetExact()),
+		Prefix: lo.EmptyableToPtr(stringMatch.GetPrefix()),
+		Regex:  lo.EmptyableToPtr(stringMatch.GetRegex()),
+	}
+}
+
+// newIstioHTTPRoutes validates the routes of a request body while converting them
+func newIstioHTTPRoutes(restHTTPRoutes []rest_types.HTTPRoute) ([]*istio.HTTPRoute, error) {
+	var httpRoutes []*istio.HTTPRoute
+	for _, restHTTPRoute := range restHTTPRoutes {
+		httpRoute, err := newIstioHTTPRoute(restHTTPRoute)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred converting HTTP route '%s'", lo.FromPtr(restHTTPRoute.Name))
+		}
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+	return httpRoutes, nil
+}
+
+func newIstioRoutingRule(restRoutingRule rest_types.RoutingRule) (*istio.HTTPRoute, error) {
+	if lo.FromPtr(restRoutingRule.Route.Name) == "" {
+		return nil, stacktrace.NewError("The routing rules need a name")
+	}
+	return newIstioHTTPRoute(restRoutingRule.Route)
+}
+
+func getRoutingRulePriority(restRoutingRule rest_types.RoutingRule) int32 {
+	return lo.FromPtrOr(restRoutingRule.Priority, defaultRoutingRulePriority)
+}
+
+func newIstioHTTPRoute(restHTTPRoute rest_types.HTTPRoute) (*istio.HTTPRoute, error) {
+	if len(restHTTPRoute.Route) == 0 {
+		return nil, stacktrace.NewError("The HTTP route needs at least one destination")
+	}
+
+	var matches []*istio.HTTPMatchRequest
+	for _, restMatch := range lo.FromPtr(restHTTPRoute.Match) {
+		uriMatch, err := newIstioStringMatch(restMatch.Uri)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid URI match")
+		}
+
+		var headerMatches map[string]*istio.StringMatch
+		for headerName, restHeaderMatch := range lo.FromPtr(restMatch.Headers) {
+			headerMatch, err := newIstioStringMatch(&restHeaderMatch)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Invalid match for header '%s'", headerName)
+			}
+			if headerMatches == nil {
+				headerMatches = map[string]*istio.StringMatch{}
+			}
+			headerMatches[headerName] = headerMatch
+		}
+
+		matches = append(matches, &istio.HTTPMatchRequest{
+			Uri:     uriMatch,
+			Headers: headerMatches,
+		})
+	}
+
+	var destinations []*istio.HTTPRouteDestination
+	for _, restDestination := range restHTTPRoute.Route {
+		if restDestination.Host == "" {
+			return nil, stacktrace.NewError("The HTTP route destinations need a host")
+		}
+		destinations = append(destinations, &istio.HTTPRouteDestination{
+			Destination: &istio.Destination{
+				Host:   restDestination.Host,
+				Subset: lo.FromPtr(restDestination.Subset),
+			},
+			Weight: lo.FromPtr(restDestination.Weight),
+		})
+	}
+
+	return &istio.HTTPRoute{
+		Name:  lo.FromPtr(restHTTPRoute.Name),
+		Match: matches,
+		Route: destinations,
+	}, nil
+}
+
+// newIstioStringMatch returns nil for a nil match, since Istio treats a missing match as matching everything
+func newIstioStringMatch(restStringMatch *rest_types.StringMatch) (*istio.StringMatch, error) {
+	if restStringMatch == nil {
+		return nil, nil
+	}
+
+	var stringMatches []*istio.StringMatch
+	if restStringMatch.Exact != nil {
+		stringMatches = append(stringMatches, &istio.StringMatch{MatchType: &istio.StringMatch_Exact{Exact: *restStringMatch.Exact}})
+	}
+	if restStringMatch.Prefix != nil {
+		stringMatches = append(stringMatches, &istio.StringMatch{MatchType: &istio.StringMatch_Prefix{Prefix: *restStringMatch.Prefix}})
+	}
+	if restStringMatch.Regex != nil {
+		stringMatches = append(stringMatches, &istio.StringMatch{MatchType: &istio.StringMatch_Regex{Regex: *restStringMatch.Regex}})
+	}
+
+	if len(stringMatches) != 1 {
+		return nil, stacktrace.NewError("Exactly one of exact, prefix and regex has to be set, got %d", len(stringMatches))
+	}
+	return stringMatches[0], nil
+}
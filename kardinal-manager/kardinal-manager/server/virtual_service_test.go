@@ -0,0 +1,81 @@
+package server
+
+import (
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	istio "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rest_types "kardinal.kontrol/kardinal-manager/api/http_rest/types"
+	"testing"
+)
+
+func TestNewRestVirtualService(t *testing.T) {
+	virtualService := &v1alpha3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "prod"},
+		Spec: istio.VirtualService{
+			Hosts: []string{"reviews"},
+			Http: []*istio.HTTPRoute{
+				{
+					Name: "dev-flow",
+					Match: []*istio.HTTPMatchRequest{
+						{Headers: map[string]*istio.StringMatch{"x-kardinal-flow": {MatchType: &istio.StringMatch_Exact{Exact: "dev"}}}},
+					},
+					Route: []*istio.HTTPRouteDestination{{Destination: &istio.Destination{Host: "reviews", Subset: "dev"}}},
+				},
+				{
+					Route: []*istio.HTTPRouteDestination{{Destination: &istio.Destination{Host: "reviews", Subset: "v1"}, Weight: 100}},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, rest_types.VirtualService{
+		Hosts: &[]string{"reviews"},
+		Http: &[]rest_types.HTTPRoute{
+			{
+				Name: lo.ToPtr("dev-flow"),
+				Match: &[]rest_types.HTTPMatchRequest{
+					{Headers: &map[string]rest_types.StringMatch{"x-kardinal-flow": {Exact: lo.ToPtr("dev")}}},
+				},
+				Route: []rest_types.HTTPRouteDestination{{Host: "reviews", Subset: lo.ToPtr("dev")}},
+			},
+			{
+				Route: []rest_types.HTTPRouteDestination{{Host: "reviews", Subset: lo.ToPtr("v1"), Weight: lo.ToPtr(int32(100))}},
+			},
+		},
+		Name:      "reviews",
+		Namespace: lo.ToPtr("prod"),
+	}, newRestVirtualService(virtualService))
+}
+
+func TestNewIstioHTTPRoutes_ConvertsTheMatchesAndTheDestinations(t *testing.T) {
+	httpRoutes, err := newIstioHTTPRoutes([]rest_types.HTTPRoute{
+		{
+			Match: &[]rest_types.HTTPMatchRequest{{Uri: &rest_types.StringMatch{Prefix: lo.ToPtr("/api")}}},
+			Route: []rest_types.HTTPRouteDestination{{Host: "reviews", Subset: lo.ToPtr("dev"), Weight: lo.ToPtr(int32(100))}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, httpRoutes, 1)
+	require.Equal(t, "/api", httpRoutes[0].GetMatch()[0].GetUri().GetPrefix())
+	require.Equal(t, "reviews", httpRoutes[0].GetRoute()[0].GetDestination().GetHost())
+	require.Equal(t, "dev", httpRoutes[0].GetRoute()[0].GetDestination().GetSubset())
+	require.Equal(t, int32(100), httpRoutes[0].GetRoute()[0].GetWeight())
+}
+
+func TestNewIstioHTTPRoutes_RejectsInvalidRoutes(t *testing.T) {
+	_, err := newIstioHTTPRoutes([]rest_types.HTTPRoute{{Route: nil}})
+	require.Error(t, err)
+
+	_, err = newIstioHTTPRoutes([]rest_types.HTTPRoute{{Route: []rest_types.HTTPRouteDestination{{Host: ""}}}})
+	require.Error(t, err)
+
+	_, err = newIstioHTTPRoutes([]rest_types.HTTPRoute{
+		{
+			Match: &[]rest_types.HTTPMatchRequest{{Uri: &rest_types.StringMatch{Exact: lo.ToPtr("/"), Prefix: lo.ToPtr("/")}}},
+			Route: []rest_types.HTTPRouteDestination{{Host: "reviews"}},
+		},
+	})
+	require.Error(t, err)
+}
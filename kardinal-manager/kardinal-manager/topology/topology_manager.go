@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/sirupsen/logrus"
 	"io/ioutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -14,6 +13,7 @@ import (
 	"k8s.io/client-go/transport/spdy"
 	"net/http"
 	"os"
+	"sync"
 )
 
 const (
@@ -23,6 +23,9 @@ const (
 
 type Manager struct {
 	k8sConfig *rest.Config
+
+	// The topology is fetched through a fixed local port, so only one fetch can run at a time
+	fetchMutex sync.Mutex
 }
 
 func NewTopologyManager(k8sConfig *rest.Config) *Manager {
@@ -30,16 +33,26 @@ func NewTopologyManager(k8sConfig *rest.Config) *Manager {
 }
 
 func (tf *Manager) FetchTopology(namespace string) (map[string]*Node, error) {
-	stopChan, readyChan := make(chan struct{}, 1), make(chan struct{}, 1)
+	tf.fetchMutex.Lock()
+	defer tf.fetchMutex.Unlock()
+
+	stopChan, readyChan, errChan := make(chan struct{}, 1), make(chan struct{}, 1), make(chan error, 1)
 	go func() {
-		err := setupPortForwarding(tf.k8sConfig, stopChan, readyChan)
-		if err != nil {
-			logrus.Fatalf("Error setting up port forwarding: %v", err)
-		}
+		errChan <- setupPortForwarding(tf.k8sConfig, stopChan, readyChan)
 	}()
-	<-readyChan // Wait for port forwarding to be ready
 
-	defer close(stopChan)
+	// Wait for port forwarding to be ready
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		return nil, fmt.Errorf("Error setting up port forwarding: %v", err)
+	}
+
+	defer func() {
+		close(stopChan)
+		// Wait for the port forwarding to be torn down, so the local port is free for the next fetch
+		<-errChan
+	}()
 
 	var graph map[string]*Node
 
@@ -126,7 +139,7 @@ func setupPortForwarding(config *rest.Config, stopChan, readyChan chan struct{})
 func getPodsForSvc(serviceName string, namespace string, clientset *kubernetes.Clientset) (string, error) {
 	svc, err := clientset.CoreV1().Services(namespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
 	if err != nil {
-		panic(err.Error())
+		return "", fmt.Errorf("error getting service '%v' in namespace '%v': %v", serviceName, namespace, err)
 	}
 
 	// Use the service's selectors to find the pods
@@ -135,7 +148,7 @@ func getPodsForSvc(serviceName string, namespace string, clientset *kubernetes.C
 		LabelSelector: selector.String(),
 	})
 	if err != nil {
-		panic(err.Error())
+		return "", fmt.Errorf("error listing the pods of service '%v' in namespace '%v': %v", serviceName, namespace, err)
 	}
 
 	if len(pods.Items) == 0 {
@@ -33,3 +33,18 @@ func GetIntFromEnvVar(
 	}
 	return intVal, nil
 }
+
+func GetBoolFromEnvVar(
+	key string,
+	subject string,
+) (bool, error) {
+	strVal, err := GetFromEnvVar(key, subject)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "An error occurred getting env var with key '%s'", key)
+	}
+	boolVal, err := strconv.ParseBool(strVal)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "An error occurred converting string value '%s' to bool", strVal)
+	}
+	return boolVal, nil
+}
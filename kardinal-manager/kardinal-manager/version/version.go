@@ -0,0 +1,4 @@
+package version
+
+// Commit is the commit the manager was built from, it's set with the ldflags in default.nix
+var Commit = "dirty"
@@ -93,11 +93,34 @@ type ClientInterface interface {
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetTenantUuidClusterStatus request
+	GetTenantUuidClusterStatus(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostTenantUuidDeployWithBody request with any body
 	PostTenantUuidDeployWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostTenantUuidDeploy(ctx context.Context, uuid Uuid, body PostTenantUuidDeployJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostTenantUuidFlowCanaryAbortWithBody request with any body
+	PostTenantUuidFlowCanaryAbortWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTenantUuidFlowCanaryAbort(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTenantUuidFlowCanaryPromoteWithBody request with any body
+	PostTenantUuidFlowCanaryPromoteWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTenantUuidFlowCanaryPromote(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryPromoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTenantUuidFlowCanaryWeightWithBody request with any body
+	PostTenantUuidFlowCanaryWeightWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTenantUuidFlowCanaryWeight(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryWeightJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTenantUuidFlowChaosWithBody request with any body
+	PostTenantUuidFlowChaosWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTenantUuidFlowChaos(ctx context.Context, uuid Uuid, body PostTenantUuidFlowChaosJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostTenantUuidFlowCreateWithBody request with any body
 	PostTenantUuidFlowCreateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -124,6 +147,18 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetTenantUuidClusterStatus(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTenantUuidClusterStatusRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostTenantUuidDeployWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostTenantUuidDeployRequestWithBody(c.Server, uuid, contentType, body)
 	if err != nil {
@@ -148,6 +183,102 @@ func (c *Client) PostTenantUuidDeploy(ctx context.Context, uuid Uuid, body PostT
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostTenantUuidFlowCanaryAbortWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryAbortRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowCanaryAbort(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryAbortRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowCanaryPromoteWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryPromoteRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowCanaryPromote(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryPromoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryPromoteRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowCanaryWeightWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryWeightRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowCanaryWeight(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryWeightJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowCanaryWeightRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowChaosWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowChaosRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidFlowChaos(ctx context.Context, uuid Uuid, body PostTenantUuidFlowChaosJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidFlowChaosRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostTenantUuidFlowCreateWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostTenantUuidFlowCreateRequestWithBody(c.Server, uuid, contentType, body)
 	if err != nil {
@@ -235,6 +366,40 @@ func NewGetHealthRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewGetTenantUuidClusterStatusRequest generates requests for GetTenantUuidClusterStatus
+func NewGetTenantUuidClusterStatusRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenant/%s/cluster-status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewPostTenantUuidDeployRequest calls the generic PostTenantUuidDeploy builder with application/json body
 func NewPostTenantUuidDeployRequest(server string, uuid Uuid, body PostTenantUuidDeployJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -282,19 +447,19 @@ func NewPostTenantUuidDeployRequestWithBody(server string, uuid Uuid, contentTyp
 	return req, nil
 }
 
-// NewPostTenantUuidFlowCreateRequest calls the generic PostTenantUuidFlowCreate builder with application/json body
-func NewPostTenantUuidFlowCreateRequest(server string, uuid Uuid, body PostTenantUuidFlowCreateJSONRequestBody) (*http.Request, error) {
+// NewPostTenantUuidFlowCanaryAbortRequest calls the generic PostTenantUuidFlowCanaryAbort builder with application/json body
+func NewPostTenantUuidFlowCanaryAbortRequest(server string, uuid Uuid, body PostTenantUuidFlowCanaryAbortJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTenantUuidFlowCreateRequestWithBody(server, uuid, "application/json", bodyReader)
+	return NewPostTenantUuidFlowCanaryAbortRequestWithBody(server, uuid, "application/json", bodyReader)
 }
 
-// NewPostTenantUuidFlowCreateRequestWithBody generates requests for PostTenantUuidFlowCreate with any type of body
-func NewPostTenantUuidFlowCreateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTenantUuidFlowCanaryAbortRequestWithBody generates requests for PostTenantUuidFlowCanaryAbort with any type of body
+func NewPostTenantUuidFlowCanaryAbortRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -309,7 +474,7 @@ func NewPostTenantUuidFlowCreateRequestWithBody(server string, uuid Uuid, conten
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenant/%s/flow/create", pathParam0)
+	operationPath := fmt.Sprintf("/tenant/%s/flow/canary/abort", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -329,19 +494,19 @@ func NewPostTenantUuidFlowCreateRequestWithBody(server string, uuid Uuid, conten
 	return req, nil
 }
 
-// NewPostTenantUuidFlowDeleteRequest calls the generic PostTenantUuidFlowDelete builder with application/json body
-func NewPostTenantUuidFlowDeleteRequest(server string, uuid Uuid, body PostTenantUuidFlowDeleteJSONRequestBody) (*http.Request, error) {
+// NewPostTenantUuidFlowCanaryPromoteRequest calls the generic PostTenantUuidFlowCanaryPromote builder with application/json body
+func NewPostTenantUuidFlowCanaryPromoteRequest(server string, uuid Uuid, body PostTenantUuidFlowCanaryPromoteJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTenantUuidFlowDeleteRequestWithBody(server, uuid, "application/json", bodyReader)
+	return NewPostTenantUuidFlowCanaryPromoteRequestWithBody(server, uuid, "application/json", bodyReader)
 }
 
-// NewPostTenantUuidFlowDeleteRequestWithBody generates requests for PostTenantUuidFlowDelete with any type of body
-func NewPostTenantUuidFlowDeleteRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTenantUuidFlowCanaryPromoteRequestWithBody generates requests for PostTenantUuidFlowCanaryPromote with any type of body
+func NewPostTenantUuidFlowCanaryPromoteRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -356,7 +521,7 @@ func NewPostTenantUuidFlowDeleteRequestWithBody(server string, uuid Uuid, conten
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenant/%s/flow/delete", pathParam0)
+	operationPath := fmt.Sprintf("/tenant/%s/flow/canary/promote", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -376,8 +541,19 @@ func NewPostTenantUuidFlowDeleteRequestWithBody(server string, uuid Uuid, conten
 	return req, nil
 }
 
-// NewGetTenantUuidTopologyRequest generates requests for GetTenantUuidTopology
-func NewGetTenantUuidTopologyRequest(server string, uuid Uuid) (*http.Request, error) {
+// NewPostTenantUuidFlowCanaryWeightRequest calls the generic PostTenantUuidFlowCanaryWeight builder with application/json body
+func NewPostTenantUuidFlowCanaryWeightRequest(server string, uuid Uuid, body PostTenantUuidFlowCanaryWeightJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTenantUuidFlowCanaryWeightRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPostTenantUuidFlowCanaryWeightRequestWithBody generates requests for PostTenantUuidFlowCanaryWeight with any type of body
+func NewPostTenantUuidFlowCanaryWeightRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -392,7 +568,7 @@ func NewGetTenantUuidTopologyRequest(server string, uuid Uuid) (*http.Request, e
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenant/%s/topology", pathParam0)
+	operationPath := fmt.Sprintf("/tenant/%s/flow/canary/weight", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -402,99 +578,321 @@ func NewGetTenantUuidTopologyRequest(server string, uuid Uuid) (*http.Request, e
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostTenantUuidFlowChaosRequest calls the generic PostTenantUuidFlowChaos builder with application/json body
+func NewPostTenantUuidFlowChaosRequest(server string, uuid Uuid, body PostTenantUuidFlowChaosJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTenantUuidFlowChaosRequestWithBody(server, uuid, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewPostTenantUuidFlowChaosRequestWithBody generates requests for PostTenantUuidFlowChaos with any type of body
+func NewPostTenantUuidFlowChaosRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
-
-	// PostTenantUuidDeployWithBodyWithResponse request with any body
-	PostTenantUuidDeployWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidDeployResponse, error)
 
-	PostTenantUuidDeployWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidDeployJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidDeployResponse, error)
-
-	// PostTenantUuidFlowCreateWithBodyWithResponse request with any body
-	PostTenantUuidFlowCreateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCreateResponse, error)
-
-	PostTenantUuidFlowCreateWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCreateResponse, error)
+	operationPath := fmt.Sprintf("/tenant/%s/flow/chaos", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// PostTenantUuidFlowDeleteWithBodyWithResponse request with any body
-	PostTenantUuidFlowDeleteWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowDeleteResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	PostTenantUuidFlowDeleteWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowDeleteResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetTenantUuidTopologyWithResponse request
-	GetTenantUuidTopologyWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidTopologyResponse, error)
-}
+	req.Header.Add("Content-Type", contentType)
 
-type GetHealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *string
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPostTenantUuidFlowCreateRequest calls the generic PostTenantUuidFlowCreate builder with application/json body
+func NewPostTenantUuidFlowCreateRequest(server string, uuid Uuid, body PostTenantUuidFlowCreateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTenantUuidFlowCreateRequestWithBody(server, uuid, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// NewPostTenantUuidFlowCreateRequestWithBody generates requests for PostTenantUuidFlowCreate with any type of body
+func NewPostTenantUuidFlowCreateRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenant/%s/flow/create", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTenantUuidFlowDeleteRequest calls the generic PostTenantUuidFlowDelete builder with application/json body
+func NewPostTenantUuidFlowDeleteRequest(server string, uuid Uuid, body PostTenantUuidFlowDeleteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTenantUuidFlowDeleteRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPostTenantUuidFlowDeleteRequestWithBody generates requests for PostTenantUuidFlowDelete with any type of body
+func NewPostTenantUuidFlowDeleteRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenant/%s/flow/delete", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTenantUuidTopologyRequest generates requests for GetTenantUuidTopology
+func NewGetTenantUuidTopologyRequest(server string, uuid Uuid) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenant/%s/topology", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetTenantUuidClusterStatusWithResponse request
+	GetTenantUuidClusterStatusWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterStatusResponse, error)
+
+	// PostTenantUuidDeployWithBodyWithResponse request with any body
+	PostTenantUuidDeployWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidDeployResponse, error)
+
+	PostTenantUuidDeployWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidDeployJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidDeployResponse, error)
+
+	// PostTenantUuidFlowCanaryAbortWithBodyWithResponse request with any body
+	PostTenantUuidFlowCanaryAbortWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryAbortResponse, error)
+
+	PostTenantUuidFlowCanaryAbortWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryAbortResponse, error)
+
+	// PostTenantUuidFlowCanaryPromoteWithBodyWithResponse request with any body
+	PostTenantUuidFlowCanaryPromoteWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryPromoteResponse, error)
+
+	PostTenantUuidFlowCanaryPromoteWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryPromoteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryPromoteResponse, error)
+
+	// PostTenantUuidFlowCanaryWeightWithBodyWithResponse request with any body
+	PostTenantUuidFlowCanaryWeightWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryWeightResponse, error)
+
+	PostTenantUuidFlowCanaryWeightWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryWeightJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryWeightResponse, error)
+
+	// PostTenantUuidFlowChaosWithBodyWithResponse request with any body
+	PostTenantUuidFlowChaosWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowChaosResponse, error)
+
+	PostTenantUuidFlowChaosWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowChaosJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowChaosResponse, error)
+
+	// PostTenantUuidFlowCreateWithBodyWithResponse request with any body
+	PostTenantUuidFlowCreateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCreateResponse, error)
+
+	PostTenantUuidFlowCreateWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCreateResponse, error)
+
+	// PostTenantUuidFlowDeleteWithBodyWithResponse request with any body
+	PostTenantUuidFlowDeleteWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowDeleteResponse, error)
+
+	PostTenantUuidFlowDeleteWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowDeleteResponse, error)
+
+	// GetTenantUuidTopologyWithResponse request
+	GetTenantUuidTopologyWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidTopologyResponse, error)
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTenantUuidClusterStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClusterStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTenantUuidClusterStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTenantUuidClusterStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type PostTenantUuidDeployResponse struct {
@@ -519,6 +917,94 @@ func (r PostTenantUuidDeployResponse) StatusCode() int {
 	return 0
 }
 
+type PostTenantUuidFlowCanaryAbortResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTenantUuidFlowCanaryAbortResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTenantUuidFlowCanaryAbortResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTenantUuidFlowCanaryPromoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTenantUuidFlowCanaryPromoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTenantUuidFlowCanaryPromoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTenantUuidFlowCanaryWeightResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTenantUuidFlowCanaryWeightResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTenantUuidFlowCanaryWeightResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTenantUuidFlowChaosResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTenantUuidFlowChaosResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTenantUuidFlowChaosResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostTenantUuidFlowCreateResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -594,6 +1080,15 @@ func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEdit
 	return ParseGetHealthResponse(rsp)
 }
 
+// GetTenantUuidClusterStatusWithResponse request returning *GetTenantUuidClusterStatusResponse
+func (c *ClientWithResponses) GetTenantUuidClusterStatusWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterStatusResponse, error) {
+	rsp, err := c.GetTenantUuidClusterStatus(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTenantUuidClusterStatusResponse(rsp)
+}
+
 // PostTenantUuidDeployWithBodyWithResponse request with arbitrary body returning *PostTenantUuidDeployResponse
 func (c *ClientWithResponses) PostTenantUuidDeployWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidDeployResponse, error) {
 	rsp, err := c.PostTenantUuidDeployWithBody(ctx, uuid, contentType, body, reqEditors...)
@@ -611,6 +1106,74 @@ func (c *ClientWithResponses) PostTenantUuidDeployWithResponse(ctx context.Conte
 	return ParsePostTenantUuidDeployResponse(rsp)
 }
 
+// PostTenantUuidFlowCanaryAbortWithBodyWithResponse request with arbitrary body returning *PostTenantUuidFlowCanaryAbortResponse
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryAbortWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryAbortResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryAbortWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryAbortResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryAbortWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryAbortResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryAbort(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryAbortResponse(rsp)
+}
+
+// PostTenantUuidFlowCanaryPromoteWithBodyWithResponse request with arbitrary body returning *PostTenantUuidFlowCanaryPromoteResponse
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryPromoteWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryPromoteResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryPromoteWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryPromoteResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryPromoteWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryPromoteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryPromoteResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryPromote(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryPromoteResponse(rsp)
+}
+
+// PostTenantUuidFlowCanaryWeightWithBodyWithResponse request with arbitrary body returning *PostTenantUuidFlowCanaryWeightResponse
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryWeightWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryWeightResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryWeightWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryWeightResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTenantUuidFlowCanaryWeightWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowCanaryWeightJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCanaryWeightResponse, error) {
+	rsp, err := c.PostTenantUuidFlowCanaryWeight(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowCanaryWeightResponse(rsp)
+}
+
+// PostTenantUuidFlowChaosWithBodyWithResponse request with arbitrary body returning *PostTenantUuidFlowChaosResponse
+func (c *ClientWithResponses) PostTenantUuidFlowChaosWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowChaosResponse, error) {
+	rsp, err := c.PostTenantUuidFlowChaosWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowChaosResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTenantUuidFlowChaosWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidFlowChaosJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowChaosResponse, error) {
+	rsp, err := c.PostTenantUuidFlowChaos(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidFlowChaosResponse(rsp)
+}
+
 // PostTenantUuidFlowCreateWithBodyWithResponse request with arbitrary body returning *PostTenantUuidFlowCreateResponse
 func (c *ClientWithResponses) PostTenantUuidFlowCreateWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidFlowCreateResponse, error) {
 	rsp, err := c.PostTenantUuidFlowCreateWithBody(ctx, uuid, contentType, body, reqEditors...)
@@ -680,6 +1243,32 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	return response, nil
 }
 
+// ParseGetTenantUuidClusterStatusResponse parses an HTTP response from a GetTenantUuidClusterStatusWithResponse call
+func ParseGetTenantUuidClusterStatusResponse(rsp *http.Response) (*GetTenantUuidClusterStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTenantUuidClusterStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClusterStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostTenantUuidDeployResponse parses an HTTP response from a PostTenantUuidDeployWithResponse call
 func ParsePostTenantUuidDeployResponse(rsp *http.Response) (*PostTenantUuidDeployResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -706,6 +1295,110 @@ func ParsePostTenantUuidDeployResponse(rsp *http.Response) (*PostTenantUuidDeplo
 	return response, nil
 }
 
+// ParsePostTenantUuidFlowCanaryAbortResponse parses an HTTP response from a PostTenantUuidFlowCanaryAbortWithResponse call
+func ParsePostTenantUuidFlowCanaryAbortResponse(rsp *http.Response) (*PostTenantUuidFlowCanaryAbortResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTenantUuidFlowCanaryAbortResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTenantUuidFlowCanaryPromoteResponse parses an HTTP response from a PostTenantUuidFlowCanaryPromoteWithResponse call
+func ParsePostTenantUuidFlowCanaryPromoteResponse(rsp *http.Response) (*PostTenantUuidFlowCanaryPromoteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTenantUuidFlowCanaryPromoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTenantUuidFlowCanaryWeightResponse parses an HTTP response from a PostTenantUuidFlowCanaryWeightWithResponse call
+func ParsePostTenantUuidFlowCanaryWeightResponse(rsp *http.Response) (*PostTenantUuidFlowCanaryWeightResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTenantUuidFlowCanaryWeightResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTenantUuidFlowChaosResponse parses an HTTP response from a PostTenantUuidFlowChaosWithResponse call
+func ParsePostTenantUuidFlowChaosResponse(rsp *http.Response) (*PostTenantUuidFlowChaosResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTenantUuidFlowChaosResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostTenantUuidFlowCreateResponse parses an HTTP response from a PostTenantUuidFlowCreateWithResponse call
 func ParsePostTenantUuidFlowCreateResponse(rsp *http.Response) (*PostTenantUuidFlowCreateResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
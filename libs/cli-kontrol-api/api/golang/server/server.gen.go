@@ -28,9 +28,24 @@ type ServerInterface interface {
 	// (GET /health)
 	GetHealth(ctx echo.Context) error
 
+	// (GET /tenant/{uuid}/cluster-status)
+	GetTenantUuidClusterStatus(ctx echo.Context, uuid Uuid) error
+
 	// (POST /tenant/{uuid}/deploy)
 	PostTenantUuidDeploy(ctx echo.Context, uuid Uuid) error
 
+	// (POST /tenant/{uuid}/flow/canary/abort)
+	PostTenantUuidFlowCanaryAbort(ctx echo.Context, uuid Uuid) error
+
+	// (POST /tenant/{uuid}/flow/canary/promote)
+	PostTenantUuidFlowCanaryPromote(ctx echo.Context, uuid Uuid) error
+
+	// (POST /tenant/{uuid}/flow/canary/weight)
+	PostTenantUuidFlowCanaryWeight(ctx echo.Context, uuid Uuid) error
+
+	// (POST /tenant/{uuid}/flow/chaos)
+	PostTenantUuidFlowChaos(ctx echo.Context, uuid Uuid) error
+
 	// (POST /tenant/{uuid}/flow/create)
 	PostTenantUuidFlowCreate(ctx echo.Context, uuid Uuid) error
 
@@ -55,6 +70,22 @@ func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	return err
 }
 
+// GetTenantUuidClusterStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenantUuidClusterStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTenantUuidClusterStatus(ctx, uuid)
+	return err
+}
+
 // PostTenantUuidDeploy converts echo context to params.
 func (w *ServerInterfaceWrapper) PostTenantUuidDeploy(ctx echo.Context) error {
 	var err error
@@ -71,6 +102,70 @@ func (w *ServerInterfaceWrapper) PostTenantUuidDeploy(ctx echo.Context) error {
 	return err
 }
 
+// PostTenantUuidFlowCanaryAbort converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTenantUuidFlowCanaryAbort(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTenantUuidFlowCanaryAbort(ctx, uuid)
+	return err
+}
+
+// PostTenantUuidFlowCanaryPromote converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTenantUuidFlowCanaryPromote(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTenantUuidFlowCanaryPromote(ctx, uuid)
+	return err
+}
+
+// PostTenantUuidFlowCanaryWeight converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTenantUuidFlowCanaryWeight(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTenantUuidFlowCanaryWeight(ctx, uuid)
+	return err
+}
+
+// PostTenantUuidFlowChaos converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTenantUuidFlowChaos(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTenantUuidFlowChaos(ctx, uuid)
+	return err
+}
+
 // PostTenantUuidFlowCreate converts echo context to params.
 func (w *ServerInterfaceWrapper) PostTenantUuidFlowCreate(ctx echo.Context) error {
 	var err error
@@ -148,7 +243,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/tenant/:uuid/cluster-status", wrapper.GetTenantUuidClusterStatus)
 	router.POST(baseURL+"/tenant/:uuid/deploy", wrapper.PostTenantUuidDeploy)
+	router.POST(baseURL+"/tenant/:uuid/flow/canary/abort", wrapper.PostTenantUuidFlowCanaryAbort)
+	router.POST(baseURL+"/tenant/:uuid/flow/canary/promote", wrapper.PostTenantUuidFlowCanaryPromote)
+	router.POST(baseURL+"/tenant/:uuid/flow/canary/weight", wrapper.PostTenantUuidFlowCanaryWeight)
+	router.POST(baseURL+"/tenant/:uuid/flow/chaos", wrapper.PostTenantUuidFlowChaos)
 	router.POST(baseURL+"/tenant/:uuid/flow/create", wrapper.PostTenantUuidFlowCreate)
 	router.POST(baseURL+"/tenant/:uuid/flow/delete", wrapper.PostTenantUuidFlowDelete)
 	router.GET(baseURL+"/tenant/:uuid/topology", wrapper.GetTenantUuidTopology)
@@ -171,6 +271,31 @@ func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetTenantUuidClusterStatusRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+}
+
+type GetTenantUuidClusterStatusResponseObject interface {
+	VisitGetTenantUuidClusterStatusResponse(w http.ResponseWriter) error
+}
+
+type GetTenantUuidClusterStatus200JSONResponse ClusterStatus
+
+func (response GetTenantUuidClusterStatus200JSONResponse) VisitGetTenantUuidClusterStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTenantUuidClusterStatus404Response struct {
+}
+
+func (response GetTenantUuidClusterStatus404Response) VisitGetTenantUuidClusterStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
 type PostTenantUuidDeployRequestObject struct {
 	Uuid Uuid `json:"uuid"`
 	Body *PostTenantUuidDeployJSONRequestBody
@@ -189,6 +314,142 @@ func (response PostTenantUuidDeploy200JSONResponse) VisitPostTenantUuidDeployRes
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTenantUuidFlowCanaryAbortRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PostTenantUuidFlowCanaryAbortJSONRequestBody
+}
+
+type PostTenantUuidFlowCanaryAbortResponseObject interface {
+	VisitPostTenantUuidFlowCanaryAbortResponse(w http.ResponseWriter) error
+}
+
+type PostTenantUuidFlowCanaryAbort200ResponseHeaders struct {
+	KardinalClusterResourcesVersion string
+}
+
+type PostTenantUuidFlowCanaryAbort200JSONResponse struct {
+	Body    string
+	Headers PostTenantUuidFlowCanaryAbort200ResponseHeaders
+}
+
+func (response PostTenantUuidFlowCanaryAbort200JSONResponse) VisitPostTenantUuidFlowCanaryAbortResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Kardinal-Cluster-Resources-Version", fmt.Sprint(response.Headers.KardinalClusterResourcesVersion))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTenantUuidFlowCanaryAbort404Response struct {
+}
+
+func (response PostTenantUuidFlowCanaryAbort404Response) VisitPostTenantUuidFlowCanaryAbortResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostTenantUuidFlowCanaryPromoteRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PostTenantUuidFlowCanaryPromoteJSONRequestBody
+}
+
+type PostTenantUuidFlowCanaryPromoteResponseObject interface {
+	VisitPostTenantUuidFlowCanaryPromoteResponse(w http.ResponseWriter) error
+}
+
+type PostTenantUuidFlowCanaryPromote200ResponseHeaders struct {
+	KardinalClusterResourcesVersion string
+}
+
+type PostTenantUuidFlowCanaryPromote200JSONResponse struct {
+	Body    string
+	Headers PostTenantUuidFlowCanaryPromote200ResponseHeaders
+}
+
+func (response PostTenantUuidFlowCanaryPromote200JSONResponse) VisitPostTenantUuidFlowCanaryPromoteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Kardinal-Cluster-Resources-Version", fmt.Sprint(response.Headers.KardinalClusterResourcesVersion))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTenantUuidFlowCanaryPromote404Response struct {
+}
+
+func (response PostTenantUuidFlowCanaryPromote404Response) VisitPostTenantUuidFlowCanaryPromoteResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostTenantUuidFlowCanaryWeightRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PostTenantUuidFlowCanaryWeightJSONRequestBody
+}
+
+type PostTenantUuidFlowCanaryWeightResponseObject interface {
+	VisitPostTenantUuidFlowCanaryWeightResponse(w http.ResponseWriter) error
+}
+
+type PostTenantUuidFlowCanaryWeight200ResponseHeaders struct {
+	KardinalClusterResourcesVersion string
+}
+
+type PostTenantUuidFlowCanaryWeight200JSONResponse struct {
+	Body    string
+	Headers PostTenantUuidFlowCanaryWeight200ResponseHeaders
+}
+
+func (response PostTenantUuidFlowCanaryWeight200JSONResponse) VisitPostTenantUuidFlowCanaryWeightResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Kardinal-Cluster-Resources-Version", fmt.Sprint(response.Headers.KardinalClusterResourcesVersion))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTenantUuidFlowCanaryWeight404Response struct {
+}
+
+func (response PostTenantUuidFlowCanaryWeight404Response) VisitPostTenantUuidFlowCanaryWeightResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostTenantUuidFlowChaosRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PostTenantUuidFlowChaosJSONRequestBody
+}
+
+type PostTenantUuidFlowChaosResponseObject interface {
+	VisitPostTenantUuidFlowChaosResponse(w http.ResponseWriter) error
+}
+
+type PostTenantUuidFlowChaos200ResponseHeaders struct {
+	KardinalClusterResourcesVersion string
+}
+
+type PostTenantUuidFlowChaos200JSONResponse struct {
+	Body    string
+	Headers PostTenantUuidFlowChaos200ResponseHeaders
+}
+
+func (response PostTenantUuidFlowChaos200JSONResponse) VisitPostTenantUuidFlowChaosResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Kardinal-Cluster-Resources-Version", fmt.Sprint(response.Headers.KardinalClusterResourcesVersion))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostTenantUuidFlowChaos404Response struct {
+}
+
+func (response PostTenantUuidFlowChaos404Response) VisitPostTenantUuidFlowChaosResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
 type PostTenantUuidFlowCreateRequestObject struct {
 	Uuid Uuid `json:"uuid"`
 	Body *PostTenantUuidFlowCreateJSONRequestBody
@@ -198,13 +459,21 @@ type PostTenantUuidFlowCreateResponseObject interface {
 	VisitPostTenantUuidFlowCreateResponse(w http.ResponseWriter) error
 }
 
-type PostTenantUuidFlowCreate200JSONResponse string
+type PostTenantUuidFlowCreate200ResponseHeaders struct {
+	KardinalClusterResourcesVersion string
+}
+
+type PostTenantUuidFlowCreate200JSONResponse struct {
+	Body    string
+	Headers PostTenantUuidFlowCreate200ResponseHeaders
+}
 
 func (response PostTenantUuidFlowCreate200JSONResponse) VisitPostTenantUuidFlowCreateResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Kardinal-Cluster-Resources-Version", fmt.Sprint(response.Headers.KardinalClusterResourcesVersion))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type PostTenantUuidFlowDeleteRequestObject struct {
@@ -248,9 +517,24 @@ type StrictServerInterface interface {
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
 
+	// (GET /tenant/{uuid}/cluster-status)
+	GetTenantUuidClusterStatus(ctx context.Context, request GetTenantUuidClusterStatusRequestObject) (GetTenantUuidClusterStatusResponseObject, error)
+
 	// (POST /tenant/{uuid}/deploy)
 	PostTenantUuidDeploy(ctx context.Context, request PostTenantUuidDeployRequestObject) (PostTenantUuidDeployResponseObject, error)
 
+	// (POST /tenant/{uuid}/flow/canary/abort)
+	PostTenantUuidFlowCanaryAbort(ctx context.Context, request PostTenantUuidFlowCanaryAbortRequestObject) (PostTenantUuidFlowCanaryAbortResponseObject, error)
+
+	// (POST /tenant/{uuid}/flow/canary/promote)
+	PostTenantUuidFlowCanaryPromote(ctx context.Context, request PostTenantUuidFlowCanaryPromoteRequestObject) (PostTenantUuidFlowCanaryPromoteResponseObject, error)
+
+	// (POST /tenant/{uuid}/flow/canary/weight)
+	PostTenantUuidFlowCanaryWeight(ctx context.Context, request PostTenantUuidFlowCanaryWeightRequestObject) (PostTenantUuidFlowCanaryWeightResponseObject, error)
+
+	// (POST /tenant/{uuid}/flow/chaos)
+	PostTenantUuidFlowChaos(ctx context.Context, request PostTenantUuidFlowChaosRequestObject) (PostTenantUuidFlowChaosResponseObject, error)
+
 	// (POST /tenant/{uuid}/flow/create)
 	PostTenantUuidFlowCreate(ctx context.Context, request PostTenantUuidFlowCreateRequestObject) (PostTenantUuidFlowCreateResponseObject, error)
 
@@ -296,6 +580,31 @@ func (sh *strictHandler) GetHealth(ctx echo.Context) error {
 	return nil
 }
 
+// GetTenantUuidClusterStatus operation middleware
+func (sh *strictHandler) GetTenantUuidClusterStatus(ctx echo.Context, uuid Uuid) error {
+	var request GetTenantUuidClusterStatusRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTenantUuidClusterStatus(ctx.Request().Context(), request.(GetTenantUuidClusterStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTenantUuidClusterStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTenantUuidClusterStatusResponseObject); ok {
+		return validResponse.VisitGetTenantUuidClusterStatusResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostTenantUuidDeploy operation middleware
 func (sh *strictHandler) PostTenantUuidDeploy(ctx echo.Context, uuid Uuid) error {
 	var request PostTenantUuidDeployRequestObject
@@ -327,6 +636,130 @@ func (sh *strictHandler) PostTenantUuidDeploy(ctx echo.Context, uuid Uuid) error
 	return nil
 }
 
+// PostTenantUuidFlowCanaryAbort operation middleware
+func (sh *strictHandler) PostTenantUuidFlowCanaryAbort(ctx echo.Context, uuid Uuid) error {
+	var request PostTenantUuidFlowCanaryAbortRequestObject
+
+	request.Uuid = uuid
+
+	var body PostTenantUuidFlowCanaryAbortJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTenantUuidFlowCanaryAbort(ctx.Request().Context(), request.(PostTenantUuidFlowCanaryAbortRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTenantUuidFlowCanaryAbort")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTenantUuidFlowCanaryAbortResponseObject); ok {
+		return validResponse.VisitPostTenantUuidFlowCanaryAbortResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostTenantUuidFlowCanaryPromote operation middleware
+func (sh *strictHandler) PostTenantUuidFlowCanaryPromote(ctx echo.Context, uuid Uuid) error {
+	var request PostTenantUuidFlowCanaryPromoteRequestObject
+
+	request.Uuid = uuid
+
+	var body PostTenantUuidFlowCanaryPromoteJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTenantUuidFlowCanaryPromote(ctx.Request().Context(), request.(PostTenantUuidFlowCanaryPromoteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTenantUuidFlowCanaryPromote")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTenantUuidFlowCanaryPromoteResponseObject); ok {
+		return validResponse.VisitPostTenantUuidFlowCanaryPromoteResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostTenantUuidFlowCanaryWeight operation middleware
+func (sh *strictHandler) PostTenantUuidFlowCanaryWeight(ctx echo.Context, uuid Uuid) error {
+	var request PostTenantUuidFlowCanaryWeightRequestObject
+
+	request.Uuid = uuid
+
+	var body PostTenantUuidFlowCanaryWeightJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTenantUuidFlowCanaryWeight(ctx.Request().Context(), request.(PostTenantUuidFlowCanaryWeightRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTenantUuidFlowCanaryWeight")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTenantUuidFlowCanaryWeightResponseObject); ok {
+		return validResponse.VisitPostTenantUuidFlowCanaryWeightResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostTenantUuidFlowChaos operation middleware
+func (sh *strictHandler) PostTenantUuidFlowChaos(ctx echo.Context, uuid Uuid) error {
+	var request PostTenantUuidFlowChaosRequestObject
+
+	request.Uuid = uuid
+
+	var body PostTenantUuidFlowChaosJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTenantUuidFlowChaos(ctx.Request().Context(), request.(PostTenantUuidFlowChaosRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTenantUuidFlowChaos")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTenantUuidFlowChaosResponseObject); ok {
+		return validResponse.VisitPostTenantUuidFlowChaosResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostTenantUuidFlowCreate operation middleware
 func (sh *strictHandler) PostTenantUuidFlowCreate(ctx echo.Context, uuid Uuid) error {
 	var request PostTenantUuidFlowCreateRequestObject
@@ -417,21 +850,42 @@ func (sh *strictHandler) GetTenantUuidTopology(ctx echo.Context, uuid Uuid) erro
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RXTW/jNhD9KwTbQwvIVtJeCt3auNsGXRQBkpwWe2DIscyNxOEOKWeNQP99QVKyHYs2",
-	"HCwW2Jwsk/Pm470ZinrmEluLBox3vHrmVpBowQPFf12nVfhV4CRp6zUaXvH7++sFwyXzK2AEDjuSwAuu",
-	"w54VfsULbkQLvEr4ghN87jSB4pWnDgru5ApaERz7jQ12zpM2Ne/7ftyM4a+aznmgO7TYYL2J+RFaIK8h",
-	"GoCq04P20MaHnwmWvOI/lbuyysFj+beqgffFGFQQiU34b1C9wsv/qDJe+v0qPwwuiyHBj1trfPgE0gf4",
-	"AtbvGny6tSCndelW1DBrUAqPFAv9IlrbBA8PQj6CUTNRNcKD87w4JLHgDmitJcwkmqWuzy/tNuGuIizH",
-	"1Og4yZtLa7QQ07z6DAtRkkn5jXiAZtp378MyWyLFzgvUzrPVp4acwO9WwLQC4/VSA40NnKxZEGxcOurZ",
-	"C6rBn+s5WZ/j+aB5thM1xMu1T+zCad/kptXoz92L/EYGQ2bZOs/i/yjaCgKTYekmrkdclty4MKF2Y7fs",
-	"DUgwXRtoqoWHJ7Hh27bcPc3WQC44CMQqvT+BRziP51Q0ybF9Q6iOT+t3GrfcvLyETDJRYBvctAP9X2Y1",
-	"zkaf1q4v54vdfrHbnunWIkXIcG5Ha16k07zij3+4ucZSWF0Ka125vozZjbQfhJJIsL6c325FOREo2WYj",
-	"ha0U6XA8to73yp2qFmDaLDG+ZrSPp9TV++vyPzSesGF/3lzzgo+NUvHL+cX8InCMFoywmlf897iUkov8",
-	"lisQTUj0sE3fIbG0x+QK5COTKQov+HBiBJlEsL5WvOL/gP83uQq1OYvGJQV/u7gIPwE+qCisbbSM0PKT",
-	"C9GeT79AX2Z220kJzi27ho2Bgllf8NKDEcaXz+Et3ZeJzNhT6DIp36DzdxFx32mVOilys7swfMj3+86k",
-	"jBeC/mMSFJz/C9XmVfWemqcXU5qhIuXMBLOEiqFpNkymG8bkjtJ/b1kWsGbLBp+YJIhOmPPCdy6vTbAs",
-	"oyWcK1Ag4iohfiiR9u89GV5SykwwNRD0JqRR0MDrpFkkxBubn5DzVhn2C8EayLNw92Med2P1648umt/7",
-	"ojh2Pu/02n5/fINa31D7KckOv5AyzIx7LLwMqY1RIit9/zUAAP//3+Y3mv0NAAA=",
+	"H4sIAAAAAAAC/+RaX28bNxL/KgTvgN4Bu5acP8BVbzm7uRgtAqN22ocgD/RyJLHeJRmSK0UI9N0PHJK7",
+	"Ky0lW9dc6rRPXi05w+HMb/6uP9NKNVpJkM7S2We6BMbB4ONF3VoH5mewqjUV2F/AWKGkX+JgKyO0w580",
+	"LhA1J24JpAp0xCRCshZuGZaWTC6gwOeGSbbAbVoZZ4lwRMgdBtYx11qiZAV+1W5kBdzvaGhBbbWEhnlh",
+	"3EYDnVHrjJALut1uC6qZYQ24eJG2FXws9bt3V5dJ5CQqLajwa5q5JS2oZI3njPQFNfCxFQY4nTnTwgMS",
+	"hMWgRyaZ2byu1fpGQ+XfaKM0GCcA1y2YlaigrJSciwW+Eg4afPi7gTmd0b9NeitNIuvJTaC7QDK6LZIY",
+	"zBi28b8T43CNzxQ+sUbXfssdq+5B8jLtYLQYXWJ44fe7vD50u9Xdb1A5f1i45a8gFkv3Ld2zoM6w+VxU",
+	"pQZTgXRsAWOwXHdrCTLaKE4iKbEgHXEKFzisiGg8l6KX5NnLgjbsk2jahs7Op9OCNkKGX9NOJCEdLMAc",
+	"131W3qxBghfdoBONrcHNpjRtxptvh77ZSuu9Mm4mjeJQEKsGzmyDQoQlqxgG1mCAKFlviK6ZlMAJk5xI",
+	"5QjTuhbAeyPcKVUDk15cMEYZmxfHez6xbVUBcOBkvQQMFAYIM0CkIpG46AE1tvIeaGpmXYk8rZ23denP",
+	"KJ1oMsb/NZ5HPE0Qxkc01bp4MJkLKewSeEGE+87iXS040konaqScC2MdUdLbb65MwxydUc4chCMzqIwm",
+	"KFd91B3t6SLso30pBfOIipwzPUIPqIJ050ffaPU/548Q+feUm1CwIZVqay6/c+QOyBxctQR+Rm4zKYal",
+	"lMLm/gBYgdkg88I/y45lD2VmCasNML4Zg/dQpOwUODbi0GQd5I84763SqlaLzdh9gS9OMPsPfAE5Y0vF",
+	"T+DyVvEMl73rB5ZFFDB3t0tYHU6GGDnLWlXMKZMP5mxWMwfWZb3GizgC2Bu13g3NZAHOpuh9Rl7hyrxW",
+	"6xC3wirWBR9bsM4maPotBWGkwmQXKLrNKRnofK7oePloyEgjvPUHLBiplN4kkkcyK8h6qawX1GolLViM",
+	"iFzYihnu3QB9dsdv0j2IsP1NxMFThfVURZS615OPv2thMctJn8feUw4rWtDA0YMfb0g/ZMz0VysF/M4d",
+	"1BREmQgB4Ae2DxDiIXoEMKyux4Ks9y0/rEfOpyfWIyMvxpAyct+a3UE91tlP/jWZK4Ni+tBwllN/LMKz",
+	"NYDgIJ2YCzBJ7WE38QEnvTrI2TGzAPdYzmH3Yzjvx/7URcTzcuHPx76LJVM2BcCxRHPW1rtBB93aQKNW",
+	"qf6RILwLRuDUbENkVC+7U8aRN7e31126MxABsGst3FkundOl7crEXXF+QNANmRlwrZE9anMxkghpHTDu",
+	"382VWTPDhVyk1q1D4cvp8wEMX37//QCGL6YZIBYUrzoW8xI1wDg/LldBfEInvDXME5Ja3AN5OZ021vvj",
+	"MzsUjuLPEZI8mzL0k/1WDquSnd89q57nSE6LJll9uh4V3phCejQBJ0I6VaD/h93NF3B72TZ3mS4kXTyH",
+	"6bcx7e7l8lzXLcXHdsfnUlTw3pb13UfFlIPUmhmQGc+/xvdIlyMLL0bOudGdnSJlSn4L5mDNfN6LGaZ/",
+	"2in/uLCZlLina9GVmTltXxvFv/o4IZcD9rqJkSj3QvJs49KAtdEdRmspdWcXrGa5BPE21jYJDd0EqVIa",
+	"OBkW3SO2hyLfLdrYlaH6XytzXyvGLeECew2fIMKSn1qJBkKJ5E9fQs1LX2+QX4RxLaujam1ojWMj0fWQ",
+	"bgnCEKNaBzYGrwbrA2yZHXp37EyYI2vf7BBulO4ixQCFfZPSytCf++c5EzU+dPehBe2kfBiOaMdomU5h",
+	"OWTuYmg8cwBdq00T/fFTuVBlApnWq/Ozy3696JdL0WhlkCRgI+ymRRjTzej9v+yZUBOmxYRpbSerc7xB",
+	"8sO9oyplYHV+dtN56ZGDwt7sSX4pnJSf1tBieN2xsjyZkHOFUBcO08jFT1eTH5V0RtXk1fUVHfTM9Pxs",
+	"ejb1OlYaJNOCzuhzfBWEQ/1OlsBqtwyqHmL5tTIkrJFqCdU9qcIptKCxLPJmwpx4xemM/gfcm8CqoF1r",
+	"4bc9m079H08erYiIq5B08psN7f3RyeiuZDfdBKbrYfy2bUEnDiSTbvLZj1+3k+jSg0LlkOS3SPeuFXx3",
+	"BLY7Fn6fj4b9lok/l24//E4NHAu5u/JltPMz2LZ2KePg+Ck/HYkjDuDkbjOcrnvAvJi+OD7lixEtsCBM",
+	"bvYn8BtweaMEhKOjK5uxxrWyA3ME9/49dsB492/FN1/MBDu5NGOBIDNhobfC6UBUzuiLwPb/7SuXqfmu",
+	"DCCTaJ+8bfzOSWg5J1joP9ZM2KAg3Sske1L22vuYktESCr3fbVuQ2IFkO2VM1U71C2l8/5Xte9FLHJs4",
+	"myLX4LPcj9hMsbqM0aPsPtCVgy90OR1GJpNDH/a22yPRwuAoSKqd2Veqt1LOexCI2qhGOTgditeR8BsD",
+	"YxT7gWmQB58HXkEiTFPf98diMBhrEGf+PDhc43fK02EYvm8+RRQOvrzmzIq9QIhwX2J++ccCM1iPtJoz",
+	"B38mcPqx4EmgRIInhcbd8WbGkFc4u0rTLCEz4zrWz8jDzDNNDr8y5l6j+bygceL2xGJhwBdXYH39Dp+E",
+	"dcfQZYCdmHoDxZPC1/DrYS5MoMiDL1VPqEz/epA5iAEONZyGgctA8Y01bV7m/mPlPwyswLiu0O96uX8+",
+	"9SbODf4B4OF5R/fvAk921NFJmNFMWiNChn8o6cH8XwAAAP//AwBBXrqnpigAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
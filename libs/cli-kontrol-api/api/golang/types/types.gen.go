@@ -4,10 +4,19 @@
 package types
 
 import (
+	"time"
+
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// Defines values for DevFlowSpecMode.
+const (
+	Canary DevFlowSpecMode = "canary"
+	Dev    DevFlowSpecMode = "dev"
+	Mirror DevFlowSpecMode = "mirror"
+)
+
 // Defines values for NodeType.
 const (
 	Gateway        NodeType = "gateway"
@@ -16,6 +25,50 @@ const (
 	ServiceVersion NodeType = "service-version"
 )
 
+// Defines values for ResourceStatusStatus.
+const (
+	Applied   ResourceStatusStatus = "applied"
+	Failed    ResourceStatusStatus = "failed"
+	HeldBack  ResourceStatusStatus = "held-back"
+	NotReady  ResourceStatusStatus = "not-ready"
+	Unchanged ResourceStatusStatus = "unchanged"
+)
+
+// CanaryFlowSpec defines model for CanaryFlowSpec.
+type CanaryFlowSpec struct {
+	ServiceConfigs *[]ServiceConfig `json:"service-configs,omitempty"`
+	ServiceName    string           `json:"service-name"`
+}
+
+// CanaryWeightSpec defines model for CanaryWeightSpec.
+type CanaryWeightSpec struct {
+	ServiceConfigs *[]ServiceConfig `json:"service-configs,omitempty"`
+	ServiceName    string           `json:"service-name"`
+
+	// TrafficPercentage Percentage of the prod traffic sent to the dev image
+	TrafficPercentage int `json:"traffic-percentage"`
+}
+
+// ClusterStatus defines model for ClusterStatus.
+type ClusterStatus struct {
+	// DryRun The manager runs in dry-run mode, so the changes of this version were only planned and not applied
+	DryRun *bool `json:"dry-run,omitempty"`
+
+	// Errors The sync succeeded when there are no errors
+	Errors []string `json:"errors"`
+
+	// LastSuccessfulSyncTime When the last sync without errors finished, it's not set until the first one
+	LastSuccessfulSyncTime *time.Time       `json:"last-successful-sync-time,omitempty"`
+	ManagerVersion         string           `json:"manager-version"`
+	Resources              []ResourceStatus `json:"resources"`
+
+	// SyncTime When the sync finished
+	SyncTime time.Time `json:"sync-time"`
+
+	// Version Version of the cluster resources synced, it's not set when they couldn't be fetched. The manager reports a status after every sync, even when the version was already applied
+	Version *string `json:"version,omitempty"`
+}
+
 // ClusterTopology defines model for ClusterTopology.
 type ClusterTopology struct {
 	Edges []Edge `json:"edges"`
@@ -24,11 +77,20 @@ type ClusterTopology struct {
 
 // DevFlowSpec defines model for DevFlowSpec.
 type DevFlowSpec struct {
-	ImageLocator   *string          `json:"image-locator,omitempty"`
+	ImageLocator *string `json:"image-locator,omitempty"`
+
+	// Mode How the dev image gets traffic. A dev flow only gets the requests of the flow, a canary flow gets the traffic percentage of the prod requests and a mirror flow gets a copy of the traffic percentage of the prod requests, whose responses are discarded. When it's not set the flow is a canary if the traffic percentage is set, and a dev flow otherwise
+	Mode           *DevFlowSpecMode `json:"mode,omitempty"`
 	ServiceConfigs *[]ServiceConfig `json:"service-configs,omitempty"`
 	ServiceName    *string          `json:"service-name,omitempty"`
+
+	// TrafficPercentage Percentage of the prod traffic sent to the dev image of a canary flow, or mirrored to the dev image of a mirror flow. A mirror flow gets a copy of all the prod traffic when it's not set
+	TrafficPercentage *int `json:"traffic-percentage,omitempty"`
 }
 
+// DevFlowSpecMode How the dev image gets traffic. A dev flow only gets the requests of the flow, a canary flow gets the traffic percentage of the prod requests and a mirror flow gets a copy of the traffic percentage of the prod requests, whose responses are discarded. When it's not set the flow is a canary if the traffic percentage is set, and a dev flow otherwise
+type DevFlowSpecMode string
+
 // Edge defines model for Edge.
 type Edge struct {
 	// Label Label for the edge.
@@ -41,6 +103,19 @@ type Edge struct {
 	Target string `json:"target"`
 }
 
+// FlowChaosSpec The faults of the flow are removed when neither the delay nor the abort HTTP status are set
+type FlowChaosSpec struct {
+	// AbortHttpStatus Error HTTP status returned to the requests of the flow instead of forwarding them
+	AbortHttpStatus *int `json:"abort-http-status,omitempty"`
+
+	// Delay Delay added to the requests of the flow, as a duration like 500ms or 2s
+	Delay  *string `json:"delay,omitempty"`
+	FlowId string  `json:"flow-id"`
+
+	// Percentage Percentage of the requests of the flow the faults are injected into, all of them when it's not set
+	Percentage *float32 `json:"percentage,omitempty"`
+}
+
 // Node defines model for Node.
 type Node struct {
 	// Id Unique identifier for the node.
@@ -64,6 +139,22 @@ type ProdFlowSpec struct {
 	ServiceConfigs *[]ServiceConfig `json:"service-configs,omitempty"`
 }
 
+// ResourceStatus defines model for ResourceStatus.
+type ResourceStatus struct {
+	Kind    string  `json:"kind"`
+	Message *string `json:"message,omitempty"`
+	Name    string  `json:"name"`
+
+	// Namespace Not set for the cluster scoped resources
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Status The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+	Status ResourceStatusStatus `json:"status"`
+}
+
+// ResourceStatusStatus The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+type ResourceStatusStatus string
+
 // ServiceConfig defines model for ServiceConfig.
 type ServiceConfig struct {
 	Deployment appv1.Deployment `json:"deployment"`
@@ -76,6 +167,18 @@ type Uuid = string
 // PostTenantUuidDeployJSONRequestBody defines body for PostTenantUuidDeploy for application/json ContentType.
 type PostTenantUuidDeployJSONRequestBody = ProdFlowSpec
 
+// PostTenantUuidFlowCanaryAbortJSONRequestBody defines body for PostTenantUuidFlowCanaryAbort for application/json ContentType.
+type PostTenantUuidFlowCanaryAbortJSONRequestBody = CanaryFlowSpec
+
+// PostTenantUuidFlowCanaryPromoteJSONRequestBody defines body for PostTenantUuidFlowCanaryPromote for application/json ContentType.
+type PostTenantUuidFlowCanaryPromoteJSONRequestBody = CanaryFlowSpec
+
+// PostTenantUuidFlowCanaryWeightJSONRequestBody defines body for PostTenantUuidFlowCanaryWeight for application/json ContentType.
+type PostTenantUuidFlowCanaryWeightJSONRequestBody = CanaryWeightSpec
+
+// PostTenantUuidFlowChaosJSONRequestBody defines body for PostTenantUuidFlowChaos for application/json ContentType.
+type PostTenantUuidFlowChaosJSONRequestBody = FlowChaosSpec
+
 // PostTenantUuidFlowCreateJSONRequestBody defines body for PostTenantUuidFlowCreate for application/json ContentType.
 type PostTenantUuidFlowCreateJSONRequestBody = DevFlowSpec
 
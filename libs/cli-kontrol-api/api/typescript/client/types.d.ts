@@ -33,6 +33,9 @@ export interface paths {
       responses: {
         /** @description Dev flow creation status */
         200: {
+          headers: {
+            "Kardinal-Cluster-Resources-Version": components["headers"]["ClusterResourcesVersion"];
+          };
           content: {
             "application/json": string;
           };
@@ -63,6 +66,126 @@ export interface paths {
       };
     };
   };
+  "/tenant/{uuid}/flow/canary/weight": {
+    post: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      /** @description Change the percentage of the prod traffic sent to the dev image of a canary flow */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CanaryWeightSpec"];
+        };
+      };
+      responses: {
+        /** @description Canary flow weight update status */
+        200: {
+          headers: {
+            "Kardinal-Cluster-Resources-Version": components["headers"]["ClusterResourcesVersion"];
+          };
+          content: {
+            "application/json": string;
+          };
+        };
+        /** @description There is no canary flow for the service */
+        404: {
+          content: never;
+        };
+      };
+    };
+  };
+  "/tenant/{uuid}/flow/canary/promote": {
+    post: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      /** @description Promote the dev image of a canary flow to prod, ending the flow */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CanaryFlowSpec"];
+        };
+      };
+      responses: {
+        /** @description Canary flow promotion status */
+        200: {
+          headers: {
+            "Kardinal-Cluster-Resources-Version": components["headers"]["ClusterResourcesVersion"];
+          };
+          content: {
+            "application/json": string;
+          };
+        };
+        /** @description There is no canary flow for the service */
+        404: {
+          content: never;
+        };
+      };
+    };
+  };
+  "/tenant/{uuid}/flow/canary/abort": {
+    post: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      /** @description Abort a canary flow, sending all the prod traffic back to the prod image */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CanaryFlowSpec"];
+        };
+      };
+      responses: {
+        /** @description Canary flow abort status */
+        200: {
+          headers: {
+            "Kardinal-Cluster-Resources-Version": components["headers"]["ClusterResourcesVersion"];
+          };
+          content: {
+            "application/json": string;
+          };
+        };
+        /** @description There is no canary flow for the service */
+        404: {
+          content: never;
+        };
+      };
+    };
+  };
+  "/tenant/{uuid}/flow/chaos": {
+    post: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      /** @description Inject faults into the requests of a flow, or remove them */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["FlowChaosSpec"];
+        };
+      };
+      responses: {
+        /** @description Flow fault injection status */
+        200: {
+          headers: {
+            "Kardinal-Cluster-Resources-Version": components["headers"]["ClusterResourcesVersion"];
+          };
+          content: {
+            "application/json": string;
+          };
+        };
+        /** @description The flow doesn't exist */
+        404: {
+          content: never;
+        };
+      };
+    };
+  };
   "/tenant/{uuid}/deploy": {
     post: {
       parameters: {
@@ -103,6 +226,27 @@ export interface paths {
       };
     };
   };
+  "/tenant/{uuid}/cluster-status": {
+    get: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      responses: {
+        /** @description Result of the last cluster resources sync reported by the manager */
+        200: {
+          content: {
+            "application/json": components["schemas"]["ClusterStatus"];
+          };
+        };
+        /** @description The manager didn't report any cluster status yet */
+        404: {
+          content: never;
+        };
+      };
+    };
+  };
 }
 
 export type webhooks = Record<string, never>;
@@ -117,8 +261,53 @@ export interface components {
       "image-locator"?: string;
       /** @example backend-service-a */
       "service-name"?: string;
+      /**
+       * @description How the dev image gets traffic. A dev flow only gets the requests of the flow, a canary flow gets the traffic percentage of the prod requests and a mirror flow gets a copy of the traffic percentage of the prod requests, whose responses are discarded. When it's not set the flow is a canary if the traffic percentage is set, and a dev flow otherwise
+       * @enum {string}
+       */
+      mode?: "dev" | "canary" | "mirror";
+      /**
+       * @description Percentage of the prod traffic sent to the dev image of a canary flow, or mirrored to the dev image of a mirror flow. A mirror flow gets a copy of all the prod traffic when it's not set
+       * @example 10
+       */
+      "traffic-percentage"?: number;
+      "service-configs"?: components["schemas"]["ServiceConfig"][];
+    };
+    CanaryFlowSpec: {
+      /** @example backend-service-a */
+      "service-name": string;
+      "service-configs"?: components["schemas"]["ServiceConfig"][];
+    };
+    CanaryWeightSpec: {
+      /** @example backend-service-a */
+      "service-name": string;
+      /**
+       * @description Percentage of the prod traffic sent to the dev image
+       * @example 25
+       */
+      "traffic-percentage": number;
       "service-configs"?: components["schemas"]["ServiceConfig"][];
     };
+    /** @description The faults of the flow are removed when neither the delay nor the abort HTTP status are set */
+    FlowChaosSpec: {
+      /** @example dev-a1b2c3 */
+      "flow-id": string;
+      /**
+       * @description Delay added to the requests of the flow, as a duration like 500ms or 2s
+       * @example 2s
+       */
+      delay?: string;
+      /**
+       * @description Error HTTP status returned to the requests of the flow instead of forwarding them
+       * @example 503
+       */
+      "abort-http-status"?: number;
+      /**
+       * @description Percentage of the requests of the flow the faults are injected into, all of them when it's not set
+       * @example 10
+       */
+      percentage?: number;
+    };
     Node: {
       /** @description Unique identifier for the node. */
       id: string;
@@ -144,6 +333,38 @@ export interface components {
       nodes: components["schemas"]["Node"][];
       edges: components["schemas"]["Edge"][];
     };
+    ClusterStatus: {
+      /** @description Version of the cluster resources synced, it's not set when they couldn't be fetched. The manager reports a status after every sync, even when the version was already applied */
+      version?: string;
+      /**
+       * Format: date-time
+       * @description When the sync finished
+       */
+      "sync-time": string;
+      /**
+       * Format: date-time
+       * @description When the last sync without errors finished, it's not set until the first one
+       */
+      "last-successful-sync-time"?: string;
+      /** @description The manager runs in dry-run mode, so the changes of this version were only planned and not applied */
+      "dry-run"?: boolean;
+      "manager-version": string;
+      resources: components["schemas"]["ResourceStatus"][];
+      /** @description The sync succeeded when there are no errors */
+      errors: string[];
+    };
+    ResourceStatus: {
+      kind: string;
+      /** @description Not set for the cluster scoped resources */
+      namespace?: string;
+      name: string;
+      /**
+       * @description The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+       * @enum {string}
+       */
+      status: "applied" | "unchanged" | "failed" | "not-ready" | "held-back";
+      message?: string;
+    };
     ServiceConfig: {
       service: unknown;
       deployment: unknown;
@@ -155,7 +376,10 @@ export interface components {
     uuid: string;
   };
   requestBodies: never;
-  headers: never;
+  headers: {
+    /** @description Version of the cluster resources with the change, the manager reports it in the cluster status once it synced them */
+    ClusterResourcesVersion: string;
+  };
   pathItems: never;
 }
 
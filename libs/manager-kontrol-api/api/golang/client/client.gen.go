@@ -4,6 +4,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -90,11 +91,40 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetTenantUuidClusterResources request
-	GetTenantUuidClusterResources(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetTenantUuidClusterResources(ctx context.Context, uuid Uuid, params *GetTenantUuidClusterResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTenantUuidClusterStatusWithBody request with any body
+	PostTenantUuidClusterStatusWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTenantUuidClusterStatus(ctx context.Context, uuid Uuid, body PostTenantUuidClusterStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetTenantUuidClusterResources(ctx context.Context, uuid Uuid, params *GetTenantUuidClusterResourcesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTenantUuidClusterResourcesRequest(c.Server, uuid, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) GetTenantUuidClusterResources(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetTenantUuidClusterResourcesRequest(c.Server, uuid)
+func (c *Client) PostTenantUuidClusterStatusWithBody(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidClusterStatusRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTenantUuidClusterStatus(ctx context.Context, uuid Uuid, body PostTenantUuidClusterStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTenantUuidClusterStatusRequest(c.Server, uuid, body)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +136,7 @@ func (c *Client) GetTenantUuidClusterResources(ctx context.Context, uuid Uuid, r
 }
 
 // NewGetTenantUuidClusterResourcesRequest generates requests for GetTenantUuidClusterResources
-func NewGetTenantUuidClusterResourcesRequest(server string, uuid Uuid) (*http.Request, error) {
+func NewGetTenantUuidClusterResourcesRequest(server string, uuid Uuid, params *GetTenantUuidClusterResourcesParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -131,6 +161,44 @@ func NewGetTenantUuidClusterResourcesRequest(server string, uuid Uuid) (*http.Re
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Version != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "version", runtime.ParamLocationQuery, *params.Version); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Wait != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "wait", runtime.ParamLocationQuery, *params.Wait); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -139,6 +207,53 @@ func NewGetTenantUuidClusterResourcesRequest(server string, uuid Uuid) (*http.Re
 	return req, nil
 }
 
+// NewPostTenantUuidClusterStatusRequest calls the generic PostTenantUuidClusterStatus builder with application/json body
+func NewPostTenantUuidClusterStatusRequest(server string, uuid Uuid, body PostTenantUuidClusterStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTenantUuidClusterStatusRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewPostTenantUuidClusterStatusRequestWithBody generates requests for PostTenantUuidClusterStatus with any type of body
+func NewPostTenantUuidClusterStatusRequestWithBody(server string, uuid Uuid, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenant/%s/cluster-status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -183,7 +298,12 @@ func WithBaseURL(baseURL string) ClientOption {
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetTenantUuidClusterResourcesWithResponse request
-	GetTenantUuidClusterResourcesWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterResourcesResponse, error)
+	GetTenantUuidClusterResourcesWithResponse(ctx context.Context, uuid Uuid, params *GetTenantUuidClusterResourcesParams, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterResourcesResponse, error)
+
+	// PostTenantUuidClusterStatusWithBodyWithResponse request with any body
+	PostTenantUuidClusterStatusWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidClusterStatusResponse, error)
+
+	PostTenantUuidClusterStatusWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidClusterStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidClusterStatusResponse, error)
 }
 
 type GetTenantUuidClusterResourcesResponse struct {
@@ -209,15 +329,54 @@ func (r GetTenantUuidClusterResourcesResponse) StatusCode() int {
 	return 0
 }
 
+type PostTenantUuidClusterStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTenantUuidClusterStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTenantUuidClusterStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetTenantUuidClusterResourcesWithResponse request returning *GetTenantUuidClusterResourcesResponse
-func (c *ClientWithResponses) GetTenantUuidClusterResourcesWithResponse(ctx context.Context, uuid Uuid, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterResourcesResponse, error) {
-	rsp, err := c.GetTenantUuidClusterResources(ctx, uuid, reqEditors...)
+func (c *ClientWithResponses) GetTenantUuidClusterResourcesWithResponse(ctx context.Context, uuid Uuid, params *GetTenantUuidClusterResourcesParams, reqEditors ...RequestEditorFn) (*GetTenantUuidClusterResourcesResponse, error) {
+	rsp, err := c.GetTenantUuidClusterResources(ctx, uuid, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseGetTenantUuidClusterResourcesResponse(rsp)
 }
 
+// PostTenantUuidClusterStatusWithBodyWithResponse request with arbitrary body returning *PostTenantUuidClusterStatusResponse
+func (c *ClientWithResponses) PostTenantUuidClusterStatusWithBodyWithResponse(ctx context.Context, uuid Uuid, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTenantUuidClusterStatusResponse, error) {
+	rsp, err := c.PostTenantUuidClusterStatusWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidClusterStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTenantUuidClusterStatusWithResponse(ctx context.Context, uuid Uuid, body PostTenantUuidClusterStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTenantUuidClusterStatusResponse, error) {
+	rsp, err := c.PostTenantUuidClusterStatus(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTenantUuidClusterStatusResponse(rsp)
+}
+
 // ParseGetTenantUuidClusterResourcesResponse parses an HTTP response from a GetTenantUuidClusterResourcesWithResponse call
 func ParseGetTenantUuidClusterResourcesResponse(rsp *http.Response) (*GetTenantUuidClusterResourcesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -250,3 +409,29 @@ func ParseGetTenantUuidClusterResourcesResponse(rsp *http.Response) (*GetTenantU
 
 	return response, nil
 }
+
+// ParsePostTenantUuidClusterStatusResponse parses an HTTP response from a PostTenantUuidClusterStatusWithResponse call
+func ParsePostTenantUuidClusterStatusResponse(rsp *http.Response) (*PostTenantUuidClusterStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTenantUuidClusterStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -26,7 +26,10 @@ import (
 type ServerInterface interface {
 	// Cluster resource definition
 	// (GET /tenant/{uuid}/cluster-resources)
-	GetTenantUuidClusterResources(ctx echo.Context, uuid Uuid) error
+	GetTenantUuidClusterResources(ctx echo.Context, uuid Uuid, params GetTenantUuidClusterResourcesParams) error
+	// Result of the last cluster resources sync done by the manager
+	// (POST /tenant/{uuid}/cluster-status)
+	PostTenantUuidClusterStatus(ctx echo.Context, uuid Uuid) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -45,8 +48,40 @@ func (w *ServerInterfaceWrapper) GetTenantUuidClusterResources(ctx echo.Context)
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTenantUuidClusterResourcesParams
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", ctx.QueryParams(), &params.Wait)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter wait: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetTenantUuidClusterResources(ctx, uuid)
+	err = w.Handler.GetTenantUuidClusterResources(ctx, uuid, params)
+	return err
+}
+
+// PostTenantUuidClusterStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTenantUuidClusterStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "uuid" -------------
+	var uuid Uuid
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", ctx.Param("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter uuid: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTenantUuidClusterStatus(ctx, uuid)
 	return err
 }
 
@@ -79,13 +114,15 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/tenant/:uuid/cluster-resources", wrapper.GetTenantUuidClusterResources)
+	router.POST(baseURL+"/tenant/:uuid/cluster-status", wrapper.PostTenantUuidClusterStatus)
 
 }
 
 type NotOkJSONResponse ResponseInfo
 
 type GetTenantUuidClusterResourcesRequestObject struct {
-	Uuid Uuid `json:"uuid"`
+	Uuid   Uuid `json:"uuid"`
+	Params GetTenantUuidClusterResourcesParams
 }
 
 type GetTenantUuidClusterResourcesResponseObject interface {
@@ -101,6 +138,14 @@ func (response GetTenantUuidClusterResources200JSONResponse) VisitGetTenantUuidC
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetTenantUuidClusterResources304Response struct {
+}
+
+func (response GetTenantUuidClusterResources304Response) VisitGetTenantUuidClusterResourcesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(304)
+	return nil
+}
+
 type GetTenantUuidClusterResourcesdefaultJSONResponse struct {
 	Body       ResponseInfo
 	StatusCode int
@@ -113,11 +158,43 @@ func (response GetTenantUuidClusterResourcesdefaultJSONResponse) VisitGetTenantU
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type PostTenantUuidClusterStatusRequestObject struct {
+	Uuid Uuid `json:"uuid"`
+	Body *PostTenantUuidClusterStatusJSONRequestBody
+}
+
+type PostTenantUuidClusterStatusResponseObject interface {
+	VisitPostTenantUuidClusterStatusResponse(w http.ResponseWriter) error
+}
+
+type PostTenantUuidClusterStatus200Response struct {
+}
+
+func (response PostTenantUuidClusterStatus200Response) VisitPostTenantUuidClusterStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostTenantUuidClusterStatusdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostTenantUuidClusterStatusdefaultJSONResponse) VisitPostTenantUuidClusterStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Cluster resource definition
 	// (GET /tenant/{uuid}/cluster-resources)
 	GetTenantUuidClusterResources(ctx context.Context, request GetTenantUuidClusterResourcesRequestObject) (GetTenantUuidClusterResourcesResponseObject, error)
+	// Result of the last cluster resources sync done by the manager
+	// (POST /tenant/{uuid}/cluster-status)
+	PostTenantUuidClusterStatus(ctx context.Context, request PostTenantUuidClusterStatusRequestObject) (PostTenantUuidClusterStatusResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -133,10 +210,11 @@ type strictHandler struct {
 }
 
 // GetTenantUuidClusterResources operation middleware
-func (sh *strictHandler) GetTenantUuidClusterResources(ctx echo.Context, uuid Uuid) error {
+func (sh *strictHandler) GetTenantUuidClusterResources(ctx echo.Context, uuid Uuid, params GetTenantUuidClusterResourcesParams) error {
 	var request GetTenantUuidClusterResourcesRequestObject
 
 	request.Uuid = uuid
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetTenantUuidClusterResources(ctx.Request().Context(), request.(GetTenantUuidClusterResourcesRequestObject))
@@ -157,21 +235,81 @@ func (sh *strictHandler) GetTenantUuidClusterResources(ctx echo.Context, uuid Uu
 	return nil
 }
 
+// PostTenantUuidClusterStatus operation middleware
+func (sh *strictHandler) PostTenantUuidClusterStatus(ctx echo.Context, uuid Uuid) error {
+	var request PostTenantUuidClusterStatusRequestObject
+
+	request.Uuid = uuid
+
+	var body PostTenantUuidClusterStatusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTenantUuidClusterStatus(ctx.Request().Context(), request.(PostTenantUuidClusterStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTenantUuidClusterStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTenantUuidClusterStatusResponseObject); ok {
+		return validResponse.VisitPostTenantUuidClusterStatusResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7xVTW/jNhD9K8a0R9m0k0uhW9C0gVHUKZykPQRBwFJjmbFEcodDJ4ah/76gKH8k/tgc",
-	"dvckSjPz5s2j+LgGZWtnDRr2kK/BSZI1MlL7FoIu4rNAr0g71tZADg8P4+uenfV4jj1CbwMphAx0jDnJ",
-	"c8jAyBohT/UZEH4JmrCAnClgBl7NsZYRmFcu5nkmbUpomiYme2eNx5bAxPLtIi6UNYyG41I6V2klIxnx",
-	"4iOj9R7ir4QzyOEXsZtLpKgX0w56bGY2NfswmME3h4qx6CGRJYgpXXHE/r0KnpGm3cxJMLIOiXV6K9BV",
-	"dlVv1NSMdbt465e23w0rnfPL0eB6mwrZLt7XtbPUztlpmNIhS9LmsPjND7QV0mkRQ2I5amluwInkCtJk",
-	"rE2r0jOFCk/zWY5k5ebycnC9K5mGCs/T2lTtiGnP2kZqqtJouF9a4RZlJOqFQX61tNCmFNvCY6xLyfgq",
-	"Vycp3nTxn0DNIy21OqObsoTL0eAu5Z2nlHKPbmIMndrEpSYOsnr+JpetQP+mik+R+hFbuPtg/39BxXGK",
-	"d8fu4MgoW2B8zizVkqNpaMOXF7AF0oaxRIpINXovSzziHJvszxnAfcxNZrNxpscEsOuRJWZPZwa671qi",
-	"CXVE+GM6vZ1CBuPJn7eQwX9X08l4crMHse9zulODNVcx9rc0skQSf1nDZKve1T9jyGCJ5JM5jQbDwTB2",
-	"tw6NdBpyuGw/pd1rtRSMRhoW62i8jVDJr/q0b1gltv9A3IL2qI8LyOEG+b4tfQi6OLC57N218Hhc412K",
-	"aG2/efrg5RfD4Xdz8gOKR9z8LiiF3s9C1dvwSMY4k6HiUx22lEW6e1orCHUtaQX55gbYXnu9Amfa6LZj",
-	"BizLqA8c6v7UNE3zNQAA//+CQLzCbAcAAA==",
+	"H4sIAAAAAAAC/8RabW/bOPL/KgP+/8DeAXLsbHaBW7/rptte7tAHJGn3Ra/w0uLIYiORKknZMQp/98OQ",
+	"1IMt2cltm+4rS+KQnGf+ZugvLNVlpRUqZ9n8C6u44SU6NP6trqWgX4E2NbJyUis2Z+/eXT0HnYHLEQxa",
+	"XZsUWcIkjVXc5SxhipfI5mF+wgx+rqVBwebO1Jgwm+ZYclrYbSuis85ItWK7XcLWaKzf5nDX92Gg2Tgt",
+	"auvQtAxY4IVBLrZwp/RGwXIbyHhRoGm4+1yj2XbsNXud5mjDpRuy84rfy7IuQdXlEg1xZTHVSlhwGnJd",
+	"iKiezzVaB7pCBbSQVCvItAEOcXMQMsvQoHKQGV36WZXRaylQgFYIfyu0Wk0qXRRSrf5+RBLP44gYUjlc",
+	"oWE7EsSgrbSy6E37Wrs3d/SQauVQeQl5VRUy5STh9JMNRuiW/H+DGZuz/5t2HjMNo3Z6HZe+UpkOmx24",
+	"jML7ClOHAtAYbbxi42Ra+5IrbrZDLd+g1yjpBE2KyvEVdq7ndesVTu+5JkVnwOG9NK7mxQ2atUyxGU/9",
+	"HmDrpUWXAFeNiaxrSCqjRSRgCauMrtA4GRQWpi/i6NBTEkYMjA6QkWzFUxwd7SSj4TL4FZufz2YJK6UK",
+	"b7NkYFLPoDjF0DroYWGDIobqfc3LVp2HSiPV6No7rKmLlixqUVrgglzUaZaMBE0X8h964g9ZilrblyU5",
+	"UPaejj622+nlJ0wdCXoZksF1kwt8JhtaLz7v6+AyjozrwSb0URrYoFzl5L99pVjgBltFjE2nb1tw3KzQ",
+	"wRIzTfRVVWxpCZdjSQHtsLQPRViMkF0rPTeG+/dUq0yuFiWv/CLtaveTlZ5E4lQbXJ+fXXrSV7xiSTc8",
+	"kWWljfegmE0CNUtCNp+zu3/YM6mnvJJTGpquz72NB4wYrRaf9PI4G0vu0pz4MFr9Sy9PcxGJR9nwY8f4",
+	"EFgVels2R9ooJ7yq7Pr87HlLepqXQD7KCg0d58Q6qXxCXXh/OcrP+pwXVc4vzp53U67rAk+z1czqGJPW",
+	"SU2spYVE5SYrPa3uVsSonSp0G23upFpN24ljXOO9M3xh+sG0HzJvfOD5iOFqC9rlaOBOKpFAIe8Q0to6",
+	"XXZHcxKigEfflyiA2+6b1eG5rK0Diy5GHK9kc+ZTrg7rN1m75Iqv0IBCFBauf312CRWaUlqit6BVXKTH",
+	"w8pwRQG8kS6HP+64EVLxol0peA1MJl78STPxjzO4zZFOYgvSgbTqBwe8KPQmxHyY7uUwSOYJwmVcFij6",
+	"wd23dq2sM3XqaoPi7F3v5bS5633KgS+WPM2lQrPtbF6i49P1+XRv6pjRs0JvFhmvCzdi8Bf+e5MhibRv",
+	"U6k+haNdqpgE93Nkf9pYDpTmMGs+Nim+KPTG8zaWF1fc4YZHUFEZTLnrIOj9pPs2MchtgCkWIU6zCUj3",
+	"gwXrZFG0buv0Cr23eyei/A2bXBYI/9bKGV1AqdfYIpJC2r280o/zl5G77xDfjUQPJ5/vyNShtR51bjzd",
+	"mVFKY7QZcf1XYeABdPAXgYLA3JjzV2istA6VW6x1UZe4SAsuywcRwtt23ns/7ZJmPQlasJgadA8ydOPJ",
+	"nogDb44FT1Ndq8ew4umfBfKnZOmxrDwND447zGrC6A8DqJtIfINPg6COtgPeVPxzjSAFKiczGUpwl0vr",
+	"EYTOhg0CSuiQ5lyt0AKu0WzByRL32hg2EgxrmkHp8oh0uh/23z+r7o6XSmS3eqROEma7MPWIvm97oMvU",
+	"yoJUIMx2YmoFpRaYRBjXargxR7QgbNAgaFVsoSq4UgSTlAClXXO4djpfal0gV8QuHknLxI7dqhRsnaaI",
+	"lGs3OXrUZwIaUxri5F4yHRj10N8Kbt3Cr2mtj4GtShfkJkMWfo/7Ac0JzBAq0LWLG0MmlbQ5igglSFaL",
+	"DmrlZBEgkTTWgVbIEpZpU3LH5kxwhxO/5YgTRhMsenExoNlD7o86R5rCOXrFWFJ4hB68ChqZHy3Rn2/4",
+	"0X4D5TZesIVU14UgtL5EyNClOYqA5Vs39njdAgfr5Qae0QYhNdDiCT2rdsnOlXnXbRw475EmSKfAoRH7",
+	"JmtdfqzP0eHdgbaeY+FBK196mbSBpQ4Q9VTrzLfLCJifwRVp8TSU7yP5BChxCf88+U89m12k9LiQwr90",
+	"+aC/F72HajEUA9ygL6eyzO866Ll5YRa5c9XCtglrX+7fSFvwz9vbt40VDbraqA58HTJAW4NU1iEX9C3T",
+	"ZuOLwRZ74T0vqwLZ/OfZRdJ15H7+5ZdeR+6n2WhPTpAdjphnHxSO8ZUAuRaI2vgOQCinf57NSm/RH22f",
+	"OeZfB/EUrfDVjch9/t+e9KFWrf6BHBTkgTMlVDRH2jKEVD9uWfJw5zO02r+2sdlUbkc9+yv7mY3+x+I3",
+	"4vUTbe7/tand61iH5giHVFfbRp5B6JfNxFDwtFM3uba0e7wh8CeokDblRozE5dFOd1j1VE/6m/bCO4/4",
+	"K1rhUYVP3ArfV+mDrfCDo3wA8KiNNm46tDbqfdRoD1vzQKHxQKbbrv75bVNdoYD+iTdY9liyp9NbaTcJ",
+	"Ry8h4EJzYUFIf9Cv0EEYkspj+65fmGMhJkue3g1qcY9Lm/5OA+C6qr7t5pQJpWCPV30DsIEF3MGGkAYI",
+	"o6s2flnCUJGzfmAdQqhVAMf03DYIW3nIARou2ceBVg48ydsxWqZV2BGH6K7mhvciWnjbtVitlspd/MjG",
+	"jrZTHhI+PO6S8JZoD+XxC3R7JIGzUwLdxi0bPf92ff3mmiXs6vWLNyxhvz+7fn31+uWYJncJk1EbTjp/",
+	"lr4KkGza9PGevb1iPWzKzs9mZzPaXVeoeCXZnF34T6Fc87qcOlRcuekXuvbeTaPDT/bg+CrkJzKBP+Gv",
+	"BJuzl+hu/dR3tRSDu6xk71L+w7iOO5Ip7c52yYN0jXCPIPXXy7uPB1fIP85m3+wCeSD1yCXyTVuWtQcV",
+	"WeRi9tN4phhWDEKGijOE4ciFe4PwY1OOxkh2qNBILQDvK++vnrWsAeJjcrWKmoaLdpLG1mXpb7nZ5QFn",
+	"IJDqJheKAcdXZGg2dKCPtMwRN+tyZqXtiJO91XboZfGU+HMu9jFEMFr3qxbbb+0KTS069INGey3ej9cu",
+	"8S8fTXl3WM0N/oiyG/fn444UN6Tyz2CqPTT6Wle4RktwWWddK2G80gWhFR7IOOIszUmw2+12/wUAAP//",
+	"AwBMWy5m3SMAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -4,9 +4,22 @@
 package types
 
 import (
+	"time"
+
 	v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Defines values for ResourceStatusStatus.
+const (
+	Applied   ResourceStatusStatus = "applied"
+	Failed    ResourceStatusStatus = "failed"
+	HeldBack  ResourceStatusStatus = "held-back"
+	NotReady  ResourceStatusStatus = "not-ready"
+	Unchanged ResourceStatusStatus = "unchanged"
 )
 
 // Defines values for ResponseType.
@@ -16,15 +29,115 @@ const (
 	WARNING ResponseType = "WARNING"
 )
 
+// Canary Sends the percentage of the requests to the host of a VirtualService to the canary subset, and the rest to the prod subset
+type Canary struct {
+	CanarySubset string `json:"canary_subset"`
+	Host         string `json:"host"`
+	Namespace    string `json:"namespace"`
+	Percentage   int    `json:"percentage"`
+	ProdSubset   string `json:"prod_subset"`
+
+	// VirtualService Name of the VirtualService the routing rule of the canary is added to
+	VirtualService string `json:"virtual_service"`
+}
+
 // ClusterResources defines model for ClusterResources.
 type ClusterResources struct {
+	// Canaries Canaries of the VirtualServices, their weighted routing rules are added to the VirtualServices they target before applying them
+	Canaries         *[]Canary                   `json:"canaries,omitempty"`
+	ConfigMaps       *[]corev1.ConfigMap         `json:"config_maps,omitempty"`
+	CronJobs         *[]batchv1.CronJob          `json:"cron_jobs,omitempty"`
 	Deployments      *[]appsv1.Deployment        `json:"deployments,omitempty"`
 	DestinationRules *[]v1alpha3.DestinationRule `json:"destination_rules,omitempty"`
-	Gateway          *v1alpha3.Gateway           `json:"gateway,omitempty"`
-	Services         *[]corev1.Service           `json:"services,omitempty"`
-	VirtualServices  *[]v1alpha3.VirtualService  `json:"virtual_services,omitempty"`
+
+	// ExtraResources Objects of any other kind, like custom resources, they are applied as they are so they must set their apiVersion and kind, and the manager needs RBAC permissions on their resources, granted with `kardinal manager deploy --extra-resource`. The ones it isn't allowed to manage are reported as failed
+	ExtraResources *[]unstructured.Unstructured `json:"extra_resources,omitempty"`
+
+	// FlowFaults Faults of the flows, they are injected into the routing rules of the flows before applying their VirtualServices
+	FlowFaults *[]FlowFault `json:"flow_faults,omitempty"`
+	// Deprecated: Use gateways, it's still applied together with them while Kontrol moves to the list
+	Gateway  *v1alpha3.Gateway   `json:"gateway,omitempty"`
+	Gateways *[]v1alpha3.Gateway `json:"gateways,omitempty"`
+	Jobs     *[]batchv1.Job      `json:"jobs,omitempty"`
+
+	// Mirrors Mirrors of the VirtualServices, their routing rules are added to the VirtualServices they target before applying them
+	Mirrors                *[]Mirror                       `json:"mirrors,omitempty"`
+	PersistentVolumeClaims *[]corev1.PersistentVolumeClaim `json:"persistent_volume_claims,omitempty"`
+	Secrets                *[]corev1.Secret                `json:"secrets,omitempty"`
+	ServiceAccounts        *[]corev1.ServiceAccount        `json:"service_accounts,omitempty"`
+	Services               *[]corev1.Service               `json:"services,omitempty"`
+	StatefulSets           *[]appsv1.StatefulSet           `json:"stateful_sets,omitempty"`
+
+	// Version Opaque identifier of this set of cluster resources, it changes every time the resources change
+	Version         *string                    `json:"version,omitempty"`
+	VirtualServices *[]v1alpha3.VirtualService `json:"virtual_services,omitempty"`
+}
+
+// ClusterStatus defines model for ClusterStatus.
+type ClusterStatus struct {
+	// DryRun The manager runs in dry-run mode, so the changes of this version were only planned and not applied
+	DryRun *bool `json:"dry_run,omitempty"`
+
+	// Errors The sync succeeded when there are no errors
+	Errors []string `json:"errors"`
+
+	// LastSuccessfulSyncTime When the last sync without errors finished, it's not set until the first one
+	LastSuccessfulSyncTime *time.Time       `json:"last_successful_sync_time,omitempty"`
+	ManagerVersion         string           `json:"manager_version"`
+	Resources              []ResourceStatus `json:"resources"`
+
+	// SyncTime When the sync finished
+	SyncTime time.Time `json:"sync_time"`
+
+	// Version Version of the cluster resources synced, it's not set when they couldn't be fetched. The manager reports a status after every sync, even when the version was already applied
+	Version *string `json:"version,omitempty"`
+}
+
+// FlowFault Delays, aborts or both the percentage of the requests of a flow. It's injected into the routing rule of the flow, named flow-<flow_id>, so the requests of the other flows aren't affected
+type FlowFault struct {
+	// AbortHttpStatus Error HTTP status returned to the requests of the flow instead of forwarding them
+	AbortHttpStatus *int `json:"abort_http_status,omitempty"`
+
+	// Delay Delay added to the requests of the flow, as a duration like 500ms or 2s
+	Delay     *string `json:"delay,omitempty"`
+	FlowId    string  `json:"flow_id"`
+	Namespace string  `json:"namespace"`
+
+	// Percentage Percentage of the requests of the flow the fault is injected into, all of them when it's not set
+	Percentage *float32 `json:"percentage,omitempty"`
+
+	// VirtualService Name of the VirtualService with the routing rule of the flow
+	VirtualService string `json:"virtual_service"`
+}
+
+// Mirror Sends the requests to the host of a VirtualService to the prod subset, and a copy of the percentage of them to the mirror subset, whose responses are discarded
+type Mirror struct {
+	Host         string  `json:"host"`
+	MirrorSubset string  `json:"mirror_subset"`
+	Namespace    string  `json:"namespace"`
+	Percentage   float32 `json:"percentage"`
+	ProdSubset   string  `json:"prod_subset"`
+
+	// VirtualService Name of the VirtualService the routing rule of the mirror is added to
+	VirtualService string `json:"virtual_service"`
+}
+
+// ResourceStatus defines model for ResourceStatus.
+type ResourceStatus struct {
+	Kind    string  `json:"kind"`
+	Message *string `json:"message,omitempty"`
+	Name    string  `json:"name"`
+
+	// Namespace Not set for the cluster scoped resources
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Status The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+	Status ResourceStatusStatus `json:"status"`
 }
 
+// ResourceStatusStatus The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+type ResourceStatusStatus string
+
 // ResponseInfo defines model for ResponseInfo.
 type ResponseInfo struct {
 	Code    uint32       `json:"code"`
@@ -38,5 +151,23 @@ type ResponseType string
 // Uuid defines model for uuid.
 type Uuid = string
 
+// Version defines model for version.
+type Version = string
+
+// Wait defines model for wait.
+type Wait = int
+
 // NotOk defines model for NotOk.
 type NotOk = ResponseInfo
+
+// GetTenantUuidClusterResourcesParams defines parameters for GetTenantUuidClusterResources.
+type GetTenantUuidClusterResourcesParams struct {
+	// Version Version of the cluster resources already known by the caller
+	Version *Version `form:"version,omitempty" json:"version,omitempty"`
+
+	// Wait Maximum number of seconds to hold the request open waiting for a version different from the provided one (long-polling)
+	Wait *Wait `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// PostTenantUuidClusterStatusJSONRequestBody defines body for PostTenantUuidClusterStatus for application/json ContentType.
+type PostTenantUuidClusterStatusJSONRequestBody = ClusterStatus
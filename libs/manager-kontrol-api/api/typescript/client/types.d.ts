@@ -9,6 +9,10 @@ export interface paths {
     /** Cluster resource definition */
     get: {
       parameters: {
+        query?: {
+          version?: components["parameters"]["version"];
+          wait?: components["parameters"]["wait"];
+        };
         path: {
           uuid: components["parameters"]["uuid"];
         };
@@ -20,6 +24,33 @@ export interface paths {
             "application/json": components["schemas"]["ClusterResources"];
           };
         };
+        /** @description The cluster resources did not change from the provided version before the wait period expired */
+        304: {
+          content: never;
+        };
+        default: components["responses"]["NotOk"];
+      };
+    };
+  };
+  "/tenant/{uuid}/cluster-status": {
+    /** Result of the last cluster resources sync done by the manager */
+    post: {
+      parameters: {
+        path: {
+          uuid: components["parameters"]["uuid"];
+        };
+      };
+      /** @description Cluster status reported by the manager after every sync */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["ClusterStatus"];
+        };
+      };
+      responses: {
+        /** @description The cluster status was recorded */
+        200: {
+          content: never;
+        };
         default: components["responses"]["NotOk"];
       };
     };
@@ -39,12 +70,102 @@ export interface components {
     /** @enum {string} */
     ResponseType: "ERROR" | "INFO" | "WARNING";
     ClusterResources: {
+      /** @description Opaque identifier of this set of cluster resources, it changes every time the resources change */
+      version?: string;
       services?: unknown[];
+      config_maps?: unknown[];
+      secrets?: unknown[];
+      service_accounts?: unknown[];
+      persistent_volume_claims?: unknown[];
       deployments?: unknown[];
+      stateful_sets?: unknown[];
+      jobs?: unknown[];
+      cron_jobs?: unknown[];
       virtual_services?: unknown[];
       destination_rules?: unknown[];
+      /** @description Canaries of the VirtualServices, their weighted routing rules are added to the VirtualServices they target before applying them */
+      canaries?: components["schemas"]["Canary"][];
+      /** @description Mirrors of the VirtualServices, their routing rules are added to the VirtualServices they target before applying them */
+      mirrors?: components["schemas"]["Mirror"][];
+      /** @description Faults of the flows, they are injected into the routing rules of the flows before applying their VirtualServices */
+      flow_faults?: components["schemas"]["FlowFault"][];
+      /** @description Objects of any other kind, like custom resources, they are applied as they are so they must set their apiVersion and kind, and the manager needs RBAC permissions on their resources, granted with `kardinal manager deploy --extra-resource`. The ones it isn't allowed to manage are reported as failed */
+      extra_resources?: unknown[];
+      gateways?: unknown[];
+      /** @deprecated */
       gateway?: unknown;
     };
+    /** @description Sends the percentage of the requests to the host of a VirtualService to the canary subset, and the rest to the prod subset */
+    Canary: {
+      namespace: string;
+      /** @description Name of the VirtualService the routing rule of the canary is added to */
+      virtual_service: string;
+      host: string;
+      prod_subset: string;
+      canary_subset: string;
+      percentage: number;
+    };
+    /** @description Sends the requests to the host of a VirtualService to the prod subset, and a copy of the percentage of them to the mirror subset, whose responses are discarded */
+    Mirror: {
+      namespace: string;
+      /** @description Name of the VirtualService the routing rule of the mirror is added to */
+      virtual_service: string;
+      host: string;
+      prod_subset: string;
+      mirror_subset: string;
+      percentage: number;
+    };
+    /** @description Delays, aborts or both the percentage of the requests of a flow. It's injected into the routing rule of the flow, named flow-<flow_id>, so the requests of the other flows aren't affected */
+    FlowFault: {
+      namespace: string;
+      /** @description Name of the VirtualService with the routing rule of the flow */
+      virtual_service: string;
+      flow_id: string;
+      /**
+       * @description Delay added to the requests of the flow, as a duration like 500ms or 2s
+       * @example 2s
+       */
+      delay?: string;
+      /**
+       * @description Error HTTP status returned to the requests of the flow instead of forwarding them
+       * @example 503
+       */
+      abort_http_status?: number;
+      /** @description Percentage of the requests of the flow the fault is injected into, all of them when it's not set */
+      percentage?: number;
+    };
+    ClusterStatus: {
+      /** @description Version of the cluster resources synced, it's not set when they couldn't be fetched. The manager reports a status after every sync, even when the version was already applied */
+      version?: string;
+      /**
+       * Format: date-time
+       * @description When the sync finished
+       */
+      sync_time: string;
+      /**
+       * Format: date-time
+       * @description When the last sync without errors finished, it's not set until the first one
+       */
+      last_successful_sync_time?: string;
+      /** @description The manager runs in dry-run mode, so the changes of this version were only planned and not applied */
+      dry_run?: boolean;
+      manager_version: string;
+      resources: components["schemas"]["ResourceStatus"][];
+      /** @description The sync succeeded when there are no errors */
+      errors: string[];
+    };
+    ResourceStatus: {
+      kind: string;
+      /** @description Not set for the cluster scoped resources */
+      namespace?: string;
+      name: string;
+      /**
+       * @description The not-ready workloads didn't get ready in time, and the held-back VirtualServices were applied without their routes to them, or not at all when that would drop requests
+       * @enum {string}
+       */
+      status: "applied" | "unchanged" | "failed" | "not-ready" | "held-back";
+      message?: string;
+    };
   };
   responses: {
     /** @description Unexpected error */
@@ -57,6 +178,10 @@ export interface components {
   parameters: {
     /** @description UUID of the resource */
     uuid: string;
+    /** @description Version of the cluster resources already known by the caller */
+    version?: string;
+    /** @description Maximum number of seconds to hold the request open waiting for a version different from the provided one (long-polling) */
+    wait?: number;
   };
   requestBodies: never;
   headers: never;
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+type flowCacheEntry struct {
+	flowID    string
+	expiresAt time.Time
+}
+
+// flowCache remembers the flow of the traces seen in the last TTL, the expired entries are swept at most once per TTL
+type flowCache struct {
+	mutex       sync.Mutex
+	ttl         time.Duration
+	entries     map[string]flowCacheEntry
+	lastSweepAt time.Time
+
+	now func() time.Time
+}
+
+func newFlowCache(ttl time.Duration) *flowCache {
+	return &flowCache{
+		mutex:       sync.Mutex{},
+		ttl:         ttl,
+		entries:     map[string]flowCacheEntry{},
+		lastSweepAt: time.Now(),
+		now:         time.Now,
+	}
+}
+
+func (cache *flowCache) set(traceID string, flowID string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := cache.now()
+	cache.entries[traceID] = flowCacheEntry{flowID: flowID, expiresAt: now.Add(cache.ttl)}
+
+	if now.Sub(cache.lastSweepAt) < cache.ttl {
+		return
+	}
+	for entryTraceID, entry := range cache.entries {
+		if now.After(entry.expiresAt) {
+			delete(cache.entries, entryTraceID)
+		}
+	}
+	cache.lastSweepAt = now
+}
+
+func (cache *flowCache) get(traceID string) (string, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, found := cache.entries[traceID]
+	if !found || cache.now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.flowID, true
+}
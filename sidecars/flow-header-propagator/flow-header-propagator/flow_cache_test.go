@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlowCache_ExpiresTheFlows(t *testing.T) {
+	now := time.Now()
+	flows := newFlowCache(time.Minute)
+	flows.now = func() time.Time { return now }
+
+	flows.set("trace-1", "dev-1")
+	if flowID, found := flows.get("trace-1"); !found || flowID != "dev-1" {
+		t.Fatalf("expected flow 'dev-1', got '%s'", flowID)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, found := flows.get("trace-1"); found {
+		t.Fatal("expected the flow to be expired")
+	}
+
+	// The expired entries are swept on the next set
+	flows.set("trace-2", "dev-2")
+	if len(flows.entries) != 1 {
+		t.Fatalf("expected 1 entry left after the sweep, got %d", len(flows.entries))
+	}
+}
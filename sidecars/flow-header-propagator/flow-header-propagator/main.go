@@ -0,0 +1,91 @@
+// The flow header propagator runs next to a service which doesn't copy the flow header from the requests it receives
+// to the requests it makes, so it still takes part in the dev flows routed by that header.
+//
+// It proxies the inbound requests to the service, remembering the flow of every trace it sees, and it's the HTTP proxy
+// of the service for the outbound requests (e.g. HTTP_PROXY=http://localhost:9081), injecting the flow header of their
+// trace when it's missing. The service only has to forward the trace context headers, which the Istio tracing already
+// asks for.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	flowHeaderNameEnvVarKey = "FLOW_HEADER_NAME"
+	appPortEnvVarKey        = "APP_PORT"
+	inboundPortEnvVarKey    = "INBOUND_PORT"
+	outboundPortEnvVarKey   = "OUTBOUND_PORT"
+	flowTTLEnvVarKey        = "FLOW_TTL"
+
+	defaultFlowHeaderName = "x-kardinal-flow"
+	defaultAppPort        = "8080"
+	defaultInboundPort    = "9080"
+	defaultOutboundPort   = "9081"
+	defaultFlowTTL        = 5 * time.Minute
+
+	shutdownTimeout = 10 * time.Second
+)
+
+func main() {
+	flowHeaderName := getEnvOrDefault(flowHeaderNameEnvVarKey, defaultFlowHeaderName)
+	appURL := &url.URL{Scheme: "http", Host: net.JoinHostPort("localhost", getEnvOrDefault(appPortEnvVarKey, defaultAppPort))}
+	inboundAddr := ":" + getEnvOrDefault(inboundPortEnvVarKey, defaultInboundPort)
+	outboundAddr := ":" + getEnvOrDefault(outboundPortEnvVarKey, defaultOutboundPort)
+
+	flowTTL := defaultFlowTTL
+	if flowTTLStr := os.Getenv(flowTTLEnvVarKey); flowTTLStr != "" {
+		var err error
+		if flowTTL, err = time.ParseDuration(flowTTLStr); err != nil {
+			log.Fatalf("invalid %s '%s': %v", flowTTLEnvVarKey, flowTTLStr, err)
+		}
+	}
+
+	flows := newFlowCache(flowTTL)
+	inboundServer := &http.Server{Addr: inboundAddr, Handler: newInboundProxy(appURL, flowHeaderName, flows)}
+	outboundServer := &http.Server{Addr: outboundAddr, Handler: newOutboundProxy(flowHeaderName, flows)}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	serverErrs := make(chan error, 2)
+	for _, server := range []*http.Server{inboundServer, outboundServer} {
+		go func(server *http.Server) {
+			log.Printf("started server at %s", server.Addr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrs <- err
+			}
+		}(server)
+	}
+	log.Printf("proxying the inbound requests to %s and propagating header '%s'", appURL, flowHeaderName)
+
+	select {
+	case err := <-serverErrs:
+		log.Fatalf("a proxy server failed: %v", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, server := range []*http.Server{inboundServer, outboundServer} {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("error shutting down the server at %s: %v", server.Addr, err)
+		}
+	}
+}
+
+func getEnvOrDefault(envVarKey string, defaultValue string) string {
+	if value := os.Getenv(envVarKey); value != "" {
+		return value
+	}
+	return defaultValue
+}
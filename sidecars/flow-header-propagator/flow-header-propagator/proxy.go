@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// newInboundProxy remembers the flow of the trace of every inbound request carrying the flow header, and proxies it to
+// the service
+func newInboundProxy(appURL *url.URL, flowHeaderName string, flows *flowCache) http.Handler {
+	reverseProxy := &httputil.ReverseProxy{
+		Rewrite: func(proxyRequest *httputil.ProxyRequest) {
+			proxyRequest.SetURL(appURL)
+			proxyRequest.Out.Host = proxyRequest.In.Host
+		},
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if flowID := request.Header.Get(flowHeaderName); flowID != "" {
+			for _, traceID := range getTraceIDs(request.Header) {
+				flows.set(traceID, flowID)
+			}
+		}
+		reverseProxy.ServeHTTP(writer, request)
+	})
+}
+
+// newOutboundProxy is the HTTP proxy of the service, injecting the flow header of their trace into the outbound requests
+// which don't carry it
+func newOutboundProxy(flowHeaderName string, flows *flowCache) http.Handler {
+	reverseProxy := &httputil.ReverseProxy{
+		Rewrite: func(proxyRequest *httputil.ProxyRequest) {
+			// The URL of a proxied request is already the absolute URL of the destination
+			proxyRequest.Out.URL = proxyRequest.In.URL
+			proxyRequest.Out.Host = proxyRequest.In.Host
+			if proxyRequest.Out.Header.Get(flowHeaderName) != "" {
+				return
+			}
+			for _, traceID := range getTraceIDs(proxyRequest.In.Header) {
+				if flowID, found := flows.get(traceID); found {
+					proxyRequest.Out.Header.Set(flowHeaderName, flowID)
+					return
+				}
+			}
+		},
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		// The HTTPS requests are tunneled, so their headers can't be changed anyway
+		if request.Method == http.MethodConnect {
+			log.Printf("refusing to tunnel the request to %s, the flow header can only be propagated in HTTP requests", request.Host)
+			http.Error(writer, "CONNECT is not supported, only HTTP requests can be proxied", http.StatusMethodNotAllowed)
+			return
+		}
+		if !request.URL.IsAbs() {
+			http.Error(writer, "only proxy requests with an absolute URL are supported", http.StatusBadRequest)
+			return
+		}
+		reverseProxy.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const (
+	testTraceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	testFlowID      = "dev-123"
+)
+
+func TestProxies_PropagateTheFlowHeaderOfTheTrace(t *testing.T) {
+	flows := newFlowCache(time.Minute)
+	outboundProxy := httptest.NewServer(newOutboundProxy(defaultFlowHeaderName, flows))
+	defer outboundProxy.Close()
+	outboundProxyURL, err := url.Parse(outboundProxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receivedFlowIDs := make(chan string, 1)
+	downstreamService := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		receivedFlowIDs <- request.Header.Get(defaultFlowHeaderName)
+	}))
+	defer downstreamService.Close()
+
+	// The service forwards the trace context but not the flow header
+	service := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		outboundRequest, err := http.NewRequest(http.MethodGet, downstreamService.URL, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outboundRequest.Header.Set(traceParentHeaderName, request.Header.Get(traceParentHeaderName))
+		client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(outboundProxyURL)}}
+		response, err := client.Do(outboundRequest)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer response.Body.Close()
+		_, _ = io.Copy(writer, response.Body)
+	}))
+	defer service.Close()
+	serviceURL, err := url.Parse(service.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inboundProxy := httptest.NewServer(newInboundProxy(serviceURL, defaultFlowHeaderName, flows))
+	defer inboundProxy.Close()
+
+	inboundRequest, err := http.NewRequest(http.MethodGet, inboundProxy.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inboundRequest.Header.Set(traceParentHeaderName, testTraceParent)
+	inboundRequest.Header.Set(defaultFlowHeaderName, testFlowID)
+	response, err := http.DefaultClient.Do(inboundRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if flowID := <-receivedFlowIDs; flowID != testFlowID {
+		t.Fatalf("expected the downstream service to receive flow '%s', got '%s'", testFlowID, flowID)
+	}
+}
+
+func TestOutboundProxy_LeavesTheRequestsOfUnknownTracesAlone(t *testing.T) {
+	receivedFlowIDs := make(chan string, 1)
+	downstreamService := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		receivedFlowIDs <- request.Header.Get(defaultFlowHeaderName)
+	}))
+	defer downstreamService.Close()
+
+	outboundProxy := newOutboundProxy(defaultFlowHeaderName, newFlowCache(time.Minute))
+	request := httptest.NewRequest(http.MethodGet, downstreamService.URL, nil)
+	request.Header.Set(traceParentHeaderName, testTraceParent)
+	recorder := httptest.NewRecorder()
+	outboundProxy.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	if flowID := <-receivedFlowIDs; flowID != "" {
+		t.Fatalf("expected no flow header, got '%s'", flowID)
+	}
+}
+
+func TestOutboundProxy_RejectsTunnels(t *testing.T) {
+	outboundProxy := newOutboundProxy(defaultFlowHeaderName, newFlowCache(time.Minute))
+	request := httptest.NewRequest(http.MethodConnect, "http://example.com:443", nil)
+	recorder := httptest.NewRecorder()
+	outboundProxy.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status 405, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	traceParentHeaderName = "traceparent"
+	b3HeaderName          = "b3"
+	b3TraceIDHeaderName   = "x-b3-traceid"
+	requestIDHeaderName   = "x-request-id"
+)
+
+// getTraceIDs returns the IDs of the trace of the request found in the W3C, B3 and Envoy request ID headers. All of them
+// are used, since a service may forward some of those headers and not the others
+func getTraceIDs(header http.Header) []string {
+	var traceIDs []string
+	addTraceID := func(traceID string) {
+		// The 64-bit B3 trace IDs are left-padded with zeros when converted to 128 bits
+		traceID = strings.TrimLeft(strings.ToLower(strings.TrimSpace(traceID)), "0")
+		if traceID != "" {
+			traceIDs = append(traceIDs, traceID)
+		}
+	}
+
+	// version-traceid-parentid-flags
+	if traceParentParts := strings.Split(header.Get(traceParentHeaderName), "-"); len(traceParentParts) == 4 {
+		addTraceID(traceParentParts[1])
+	}
+	// traceid-spanid[-sampled[-parentspanid]], or only the sampling decision
+	if b3Parts := strings.Split(header.Get(b3HeaderName), "-"); len(b3Parts) >= 2 {
+		addTraceID(b3Parts[0])
+	}
+	addTraceID(header.Get(b3TraceIDHeaderName))
+	addTraceID(header.Get(requestIDHeaderName))
+	return traceIDs
+}
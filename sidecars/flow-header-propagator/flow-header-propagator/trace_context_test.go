@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetTraceIDs(t *testing.T) {
+	header := http.Header{}
+	header.Set(traceParentHeaderName, "00-00000000000000008448eb211c80319c-b7ad6b7169203331-01")
+	header.Set(b3HeaderName, "8448EB211C80319C-e457b5a2e4d86bd1-1")
+	header.Set(b3TraceIDHeaderName, "8448eb211c80319c")
+	header.Set(requestIDHeaderName, "3c8a4d7e-4e3b-9a8d-a1b2-c3d4e5f60718")
+
+	traceIDs := getTraceIDs(header)
+	expectedTraceIDs := []string{"8448eb211c80319c", "8448eb211c80319c", "8448eb211c80319c", "3c8a4d7e-4e3b-9a8d-a1b2-c3d4e5f60718"}
+	if !reflect.DeepEqual(expectedTraceIDs, traceIDs) {
+		t.Fatalf("expected trace IDs %v, got %v", expectedTraceIDs, traceIDs)
+	}
+
+	// A B3 header with only the sampling decision has no trace ID
+	header = http.Header{}
+	header.Set(b3HeaderName, "0")
+	if traceIDs = getTraceIDs(header); len(traceIDs) != 0 {
+		t.Fatalf("expected no trace IDs, got %v", traceIDs)
+	}
+}
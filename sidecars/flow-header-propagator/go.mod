@@ -0,0 +1,3 @@
+module kardinal.sidecar.flow.header.propagator
+
+go 1.22.3